@@ -3,18 +3,29 @@ package main
 import (
 	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/PxyUp/backend_tech_task/internal/api/app"
 )
 
 func main() {
-	a, err := app.NewApp()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		<-signals
+		cancel()
+	}()
+
+	a, err := app.NewApp(ctx)
 	if err != nil {
 		log.Println(err)
 		return
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 
 	if err := a.Run(ctx); err != nil {
 		log.Println(err)
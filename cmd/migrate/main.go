@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/migrations"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil/migrate"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+const usage = `Usage: migrate <command> [arguments]
+
+Commands:
+  up [version]     apply pending migrations up to version (all by default)
+  down [steps]     rollback last applied migrations (1 by default)
+  status           show applied and pending migrations
+  create <name>    create new migration file
+
+Mongo connection is configured by MONGO_* environment variables as for api.
+`
+
+func main() {
+	dir := flag.String("dir", "internal/migrations", "directory of migration files, used by create command")
+	lockTTL := flag.Duration("lock-ttl", 10*time.Minute, "time after which lock of killed process is taken over, lock is renewed while migrations run")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(context.Background(), flag.Arg(0), flag.Args()[1:], *dir, *lockTTL); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func run(ctx context.Context, command string, args []string, dir string, lockTTL time.Duration) error {
+	if command == "create" {
+		if len(args) != 1 {
+			return fmt.Errorf("create requires migration name")
+		}
+		path, err := create(dir, args[0], time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		fmt.Println("created", path)
+		return nil
+	}
+
+	var cfg mongoutil.Config
+	if err := envconfig.Process("mongo", &cfg); err != nil {
+		return err
+	}
+
+	db, err := mongoutil.NewDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := mongoutil.Close(context.Background(), db); err != nil {
+			log.Println(err)
+		}
+	}()
+
+	migrator, err := migrate.NewMigrator(db, migrations.All(), migrate.WithLockTTL(lockTTL))
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "up":
+		var target int64
+		if len(args) > 0 {
+			if target, err = strconv.ParseInt(args[0], 10, 64); err != nil {
+				return fmt.Errorf("invalid version %q: %w", args[0], err)
+			}
+		}
+		applied, err := migrator.Up(ctx, target)
+		for _, m := range applied {
+			fmt.Printf("applied %d_%s\n", m.Version, m.Name)
+		}
+		return err
+	case "down":
+		var steps = 1
+		if len(args) > 0 {
+			if steps, err = strconv.Atoi(args[0]); err != nil || steps <= 0 {
+				return fmt.Errorf("invalid steps %q", args[0])
+			}
+		}
+		rolledBack, err := migrator.Down(ctx, steps)
+		for _, m := range rolledBack {
+			fmt.Printf("rolled back %d_%s\n", m.Version, m.Name)
+		}
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		printStatus(statuses)
+		return err
+	}
+
+	return fmt.Errorf("unknown command %q", command)
+}
+
+func printStatus(statuses []migrate.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.Applied() {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+	}
+	_ = w.Flush()
+}
+
+var nameRegexp = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+var migrationTemplate = template.Must(template.New("migration").Parse(`package migrations
+
+import (
+	"context"
+
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil/migrate"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func init() {
+	register(migrate.Migration{
+		Version: {{.Version}},
+		Name:    "{{.Name}}",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return nil
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return nil
+		},
+	})
+}
+`))
+
+func create(dir string, name string, now time.Time) (string, error) {
+	name = strings.ToLower(name)
+	if !nameRegexp.MatchString(name) {
+		return "", fmt.Errorf("name %q should contain only latin letters, digits and underscores", name)
+	}
+
+	version := now.Format("20060102150405")
+
+	var b strings.Builder
+	if err := migrationTemplate.Execute(&b, struct {
+		Version string
+		Name    string
+	}{version, name}); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, version+"_"+name+".go")
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("migration %s already exists", path)
+	}
+
+	return path, ioutil.WriteFile(path, []byte(b.String()), 0644)
+}
@@ -15,6 +15,7 @@ require (
 	github.com/tidwall/buntdb v1.2.0
 	go.mongodb.org/mongo-driver v1.4.5
 	golang.org/x/sync v0.0.0-20190911185100-cd5d95a43a6e
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013
 	google.golang.org/grpc v1.36.0
 	google.golang.org/protobuf v1.25.0
 )
@@ -2,24 +2,53 @@ package app
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/PxyUp/backend_tech_task/internal/api/gateway"
 	"github.com/PxyUp/backend_tech_task/internal/api/grpc"
 	"github.com/PxyUp/backend_tech_task/internal/api/grpc/services"
+	"github.com/PxyUp/backend_tech_task/internal/api/interceptors"
 	"github.com/PxyUp/backend_tech_task/internal/application"
+	application_embedded "github.com/PxyUp/backend_tech_task/internal/application/embedded"
 	application_memory "github.com/PxyUp/backend_tech_task/internal/application/memory"
 	application_mongo "github.com/PxyUp/backend_tech_task/internal/application/mongo"
+	"github.com/PxyUp/backend_tech_task/internal/auth"
 	"github.com/PxyUp/backend_tech_task/internal/external"
+	"github.com/PxyUp/backend_tech_task/internal/health"
+	"github.com/PxyUp/backend_tech_task/internal/idempotency"
+	idempotency_memory "github.com/PxyUp/backend_tech_task/internal/idempotency/memory"
+	idempotency_mongo "github.com/PxyUp/backend_tech_task/internal/idempotency/mongo"
+	"github.com/PxyUp/backend_tech_task/internal/migrations"
+	"github.com/PxyUp/backend_tech_task/internal/outbox"
+	outbox_memory "github.com/PxyUp/backend_tech_task/internal/outbox/memory"
+	outbox_mongo "github.com/PxyUp/backend_tech_task/internal/outbox/mongo"
 	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil/migrate"
+	"github.com/PxyUp/backend_tech_task/internal/webhook"
+	webhook_memory "github.com/PxyUp/backend_tech_task/internal/webhook/memory"
+	webhook_mongo "github.com/PxyUp/backend_tech_task/internal/webhook/mongo"
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
 )
 
 type App struct {
 	grpcServer *grpc.Server
+	// gateway serves ApplicationService by HTTP/JSON
+	gateway *gateway.Server
+	// workers run in background while server is running
+	workers []func(ctx context.Context) error
+
+	// closers release resources on shutdown in reverse order
+	closers         []func(ctx context.Context) error
+	shutdownTimeout time.Duration
 }
 
-func NewApp() (*App, error) {
+const defaultShutdownTimeout = 10 * time.Second
+
+func NewApp(ctx context.Context) (_ *App, err error) {
 	log.Logger = log.With().Caller().Logger()
 
 	cfg, err := NewConfig()
@@ -27,45 +56,318 @@ func NewApp() (*App, error) {
 		return nil, err
 	}
 
-	mongoDB, err := mongoutil.NewDB(cfg.Mongo)
-	if err != nil {
-		return nil, err
+	var app = &App{
+		shutdownTimeout: cfg.ShutdownTimeout,
 	}
+	defer func() {
+		if err != nil {
+			if closeErr := app.Close(context.Background()); closeErr != nil {
+				log.Err(closeErr).Msg("couldn't close app after failed start")
+			}
+		}
+	}()
 
 	externalClient, err := external.NewClient(cfg.External)
 	if err != nil {
 		return nil, err
 	}
 
-	var applicationMongoRepository = application_mongo.NewRepository(mongoDB)
-	applicationRepository, err := application_memory.NewRepository(applicationMongoRepository)
+	storage, err := app.newStorage(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
 
+	checker := health.NewChecker(cfg.Health)
+	// calls which don't need external service are served without it
+	checker.AddOptionalCheck("external", externalClient.Ping)
+	for _, dependency := range storage.checks {
+		checker.AddCheck(dependency.name, dependency.check)
+	}
+	app.workers = append(app.workers, checker.Run)
+
+	var (
+		relay      = outbox.NewRelay(cfg.Outbox, storage.outbox, outbox.LogSink{}, webhook.NewSink(storage.webhooks))
+		dispatcher = webhook.NewDispatcher(cfg.Webhook, storage.webhooks)
+		purger     = application.NewPurger(cfg.Purge, storage.repository)
+	)
+	app.workers = append(app.workers, relay.Run, dispatcher.Run, purger.Run)
+
 	var (
 		applicationService = application.NewService(
-			applicationRepository,
+			storage.repository,
 			externalClient,
+			application.WithTransactor(storage.transactor),
+			application.WithOutbox(storage.outbox),
+			application.WithBatchConcurrency(cfg.BatchConcurrency),
+			application.WithIdempotency(storage.idempotency, cfg.IdempotencyTTL),
+			application.WithQuota(cfg.Quota),
+			application.WithReasons(cfg.Reasons),
+			application.WithRequiredAssignee(cfg.RequireAssigneeInProgress),
+			application.WithSLA(cfg.SLA),
+			application.WithRules(cfg.Rules),
 		)
 
 		grpcApplicationService = services.NewApplicationService(applicationService)
-		grpcServer             = grpc.NewServer(cfg.GRPC, grpcApplicationService)
+		grpcWebhookService     = services.NewWebhookService(webhook.NewService(storage.webhooks))
+		slaScanner             = application.NewSLAScanner(cfg.SLA, applicationService)
 	)
+	app.workers = append(app.workers, slaScanner.Run)
+
+	if cfg.Stale.Enabled {
+		staleCloser, err := application.NewStaleCloser(cfg.Stale, applicationService)
+		if err != nil {
+			return nil, err
+		}
+		app.workers = append(app.workers, staleCloser.Run)
+	}
+
+	var (
+		unaryInterceptor  = interceptors.Unary()
+		streamInterceptor = interceptors.Stream()
+	)
+	if cfg.Auth.Enabled {
+		verifier, err := auth.NewVerifier(cfg.Auth)
+		if err != nil {
+			return nil, err
+		}
+		var (
+			policy           = cfg.Auth.Policy.WithDefaults()
+			certificateRoles = cfg.GRPC.TLS.ClientRoles
+		)
+		unaryInterceptor = interceptors.Unary(certificateRoles.UnaryInterceptor, verifier.UnaryInterceptor, policy.UnaryInterceptor)
+		streamInterceptor = interceptors.Stream(certificateRoles.StreamInterceptor, verifier.StreamInterceptor, policy.StreamInterceptor)
+	}
+	app.grpcServer = grpc.NewServer(
+		cfg.GRPC,
+		grpcApplicationService,
+		grpcWebhookService,
+		checker,
+		grpc.WithInterceptors(unaryInterceptor, streamInterceptor),
+	)
+	gatewayOptions := []gateway.Option{gateway.WithUnaryInterceptor(unaryInterceptor)}
+	if cfg.GRPC.TLS.Enabled() {
+		// gateway serves the same services, so it requires the same client certificates
+		tlsConfig, err := grpc.NewTLSConfig(cfg.GRPC.TLS, "http/1.1")
+		if err != nil {
+			return nil, err
+		}
+		gatewayOptions = append(gatewayOptions, gateway.WithTLSConfig(tlsConfig))
+	}
+	app.gateway, err = gateway.NewServer(cfg.Gateway, grpcApplicationService, gatewayOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
+
+type storage struct {
+	repository application.Repository
+	transactor application.Transactor
+	outbox     outbox.Store
+	webhooks   webhook.Repository
+	// idempotency keeps idempotency keys of creation
+	idempotency idempotency.Store
+	// checks are reported by health service
+	checks []dependency
+}
+
+type dependency struct {
+	name  string
+	check health.Check
+}
+
+func (app *App) newStorage(ctx context.Context, cfg *Config) (*storage, error) {
+	switch cfg.Storage.Driver {
+	case StorageDriverEmbedded, StorageDriverMemory:
+		var path = application_embedded.InMemory
+		if cfg.Storage.Driver == StorageDriverEmbedded {
+			if cfg.Storage.Path == "" {
+				return nil, fmt.Errorf("storage path cannot be empty for %s driver", cfg.Storage.Driver)
+			}
+			path = cfg.Storage.Path
+		}
+
+		repository, err := application_embedded.NewRepository(path)
+		if err != nil {
+			return nil, err
+		}
+		app.onClose(func(context.Context) error {
+			return repository.Close()
+		})
+		// embedded storage isn't transactional, so events, webhooks and idempotency keys live in memory as well
+		return &storage{
+			repository:  repository,
+			transactor:  application.NopTransactor{},
+			outbox:      outbox_memory.NewStore(),
+			webhooks:    webhook_memory.NewRepository(),
+			idempotency: idempotency_memory.NewStore(),
+		}, nil
+	}
+
+	mongoDB, err := mongoutil.NewDB(ctx, cfg.Mongo)
+	if err != nil {
+		return nil, err
+	}
+	app.onClose(func(ctx context.Context) error {
+		return mongoutil.Close(ctx, mongoDB)
+	})
+
+	// schema of older binary isn't readable by this one, e.g. dates in seconds before 20210310120000
+	migrator, err := migrate.NewMigrator(mongoDB, migrations.All())
+	if err != nil {
+		return nil, err
+	}
+	if err := migrator.Check(ctx); err != nil {
+		return nil, fmt.Errorf("mongo should be migrated by migrate up: %w", err)
+	}
+
+	var applicationMongoRepository = application_mongo.NewRepository(mongoDB)
+	if err := applicationMongoRepository.EnsureIndexes(ctx, cfg.Mongo.IndexMode); err != nil {
+		return nil, err
+	}
+
+	outboxStore := outbox_mongo.NewStore(mongoDB)
+	if err := outboxStore.EnsureIndexes(ctx, cfg.Mongo.IndexMode); err != nil {
+		return nil, err
+	}
+
+	webhookRepository := webhook_mongo.NewRepository(mongoDB)
+	if err := webhookRepository.EnsureIndexes(ctx, cfg.Mongo.IndexMode); err != nil {
+		return nil, err
+	}
+
+	idempotencyStore := idempotency_mongo.NewStore(mongoDB)
+	if err := idempotencyStore.EnsureIndexes(ctx, cfg.Mongo.IndexMode); err != nil {
+		return nil, err
+	}
 
-	return &App{
-		grpcServer: grpcServer,
+	applicationRepository, err := application_memory.NewRepository(applicationMongoRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactor application.Transactor = application_mongo.NewTransactor(mongoDB)
+	if cfg.Mongo.DisableTransactions {
+		transactor = application.NopTransactor{}
+	}
+
+	return &storage{
+		repository:  applicationRepository,
+		transactor:  application_memory.NewTransactor(applicationRepository, transactor),
+		outbox:      outboxStore,
+		webhooks:    webhookRepository,
+		idempotency: idempotencyStore,
+		checks: []dependency{
+			{name: "mongo", check: func(ctx context.Context) error {
+				return mongoutil.Ping(ctx, mongoDB)
+			}},
+		},
 	}, nil
 }
 
-func (app App) Run(ctx context.Context) error {
-	return app.grpcServer.Run(ctx)
+func (app *App) onClose(closer func(ctx context.Context) error) {
+	app.closers = append(app.closers, closer)
+}
+
+// Run serves requests and runs workers until ctx is done or any of them fails,
+// after that all resources of app are released
+func (app *App) Run(ctx context.Context) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		return app.grpcServer.Run(groupCtx)
+	})
+	group.Go(func() error {
+		return app.gateway.Run(groupCtx)
+	})
+	for _, worker := range app.workers {
+		worker := worker
+		group.Go(func() error {
+			return worker(groupCtx)
+		})
+	}
+	err := group.Wait()
+
+	closeCtx, cancel := context.WithTimeout(context.Background(), app.shutdownTimeout)
+	defer cancel()
+
+	if closeErr := app.Close(closeCtx); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Close releases resources in reverse order of acquiring
+func (app *App) Close(ctx context.Context) error {
+	var err error
+	for i := len(app.closers) - 1; i >= 0; i-- {
+		if closeErr := app.closers[i](ctx); closeErr != nil {
+			log.Err(closeErr).Msg("couldn't close app resource")
+			if err == nil {
+				err = closeErr
+			}
+		}
+	}
+	app.closers = nil
+	log.Info().Msg("app has been closed")
+	return err
+}
+
+type StorageDriver string
+
+const (
+	// StorageDriverMongo stores applications in mongo and caches them in memory
+	StorageDriverMongo StorageDriver = "mongo"
+	// StorageDriverEmbedded stores applications in file, it doesn't need any external services
+	StorageDriverEmbedded StorageDriver = "embedded"
+	// StorageDriverMemory keeps applications in memory only, they are lost on restart
+	StorageDriverMemory StorageDriver = "memory"
+)
+
+type StorageConfig struct {
+	Driver StorageDriver `envconfig:"driver"`
+	// Path is file of embedded storage
+	Path string `envconfig:"path"`
+}
+
+func (cfg StorageConfig) Validate() error {
+	switch cfg.Driver {
+	case StorageDriverMongo, StorageDriverEmbedded, StorageDriverMemory:
+		return nil
+	}
+	return fmt.Errorf("unknown storage driver: %s", cfg.Driver)
 }
 
 type Config struct {
+	ShutdownTimeout time.Duration `envconfig:"shutdown_timeout"`
+	// BatchConcurrency limits concurrent requests to external service made by batch creation
+	BatchConcurrency int `envconfig:"batch_concurrency"`
+	// IdempotencyTTL is time of keeping idempotency keys of creation
+	IdempotencyTTL time.Duration `envconfig:"idempotency_ttl"`
+
 	GRPC     grpc.Config      `envconfig:"grpc"`
+	Health   health.Config    `envconfig:"health"`
+	Gateway  gateway.Config   `envconfig:"gateway"`
+	Auth     auth.Config      `envconfig:"auth"`
+	Storage  StorageConfig    `envconfig:"storage"`
 	Mongo    mongoutil.Config `envconfig:"mongo"`
 	External external.Config  `envconfig:"external"`
+	Outbox   outbox.Config    `envconfig:"outbox"`
+	Webhook  webhook.Config   `envconfig:"webhook"`
+	// Purge configures removing of deleted applications
+	Purge application.PurgeConfig `envconfig:"purge"`
+	// Quota limits applications of every user
+	Quota application.QuotaConfig `envconfig:"quota"`
+	// Reasons are allowed codes of reasons of status updates
+	Reasons application.ReasonConfig `envconfig:"reasons"`
+	// RequireAssigneeInProgress forbids moving unassigned applications to in progress status
+	RequireAssigneeInProgress bool `envconfig:"require_assignee_in_progress"`
+	// SLA configures deadlines of statuses and scanner of breached applications
+	SLA application.SLAConfig `envconfig:"sla"`
+	// Stale configures automatic closing of applications which haven't been updated for long time
+	Stale application.StaleConfig `envconfig:"stale"`
+	// Rules are json array of rules of automatic transitions of applications
+	Rules application.Rules `envconfig:"rules"`
 }
 
 func NewConfig() (*Config, error) {
@@ -73,5 +375,41 @@ func NewConfig() (*Config, error) {
 	if err := envconfig.Process("", cfg); err != nil {
 		return nil, err
 	}
+	if cfg.ShutdownTimeout == 0 {
+		cfg.ShutdownTimeout = defaultShutdownTimeout
+	}
+	if cfg.Storage.Driver == "" {
+		cfg.Storage.Driver = StorageDriverMongo
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
+
+func (cfg Config) Validate() error {
+	if err := cfg.Storage.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.SLA.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.Rules.Validate(cfg.Reasons); err != nil {
+		return err
+	}
+	if err := cfg.Auth.Policy.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.GRPC.TLS.Validate(); err != nil {
+		return err
+	}
+	// roles of client certificates are used only by authentication
+	if len(cfg.GRPC.TLS.ClientRoles) > 0 && !cfg.Auth.Enabled {
+		return fmt.Errorf("roles of client certificates require enabled auth")
+	}
+	// lock of user is held until end of transaction, without them only creations within process are serialized
+	if cfg.Storage.Driver == StorageDriverMongo && cfg.Mongo.DisableTransactions && cfg.Quota.Enabled() {
+		return fmt.Errorf("quota requires mongo transactions")
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package app_test
+
+import (
+	"testing"
+
+	"github.com/PxyUp/backend_tech_task/internal/api/app"
+	"github.com/PxyUp/backend_tech_task/internal/auth"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate_ClientRoles(t *testing.T) {
+	var cfg app.Config
+	cfg.Storage.Driver = app.StorageDriverMemory
+	cfg.GRPC.TLS.CertFile, cfg.GRPC.TLS.KeyFile, cfg.GRPC.TLS.ClientCAFile = "server.crt", "server.key", "ca.crt"
+	cfg.GRPC.TLS.ClientRoles = auth.CertificateRoles{"billing": auth.RoleOperator}
+	assert.EqualError(t, cfg.Validate(), "roles of client certificates require enabled auth")
+
+	cfg.Auth.Enabled = true
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_Validate_QuotaWithoutTransactions(t *testing.T) {
+	var cfg app.Config
+	cfg.Storage.Driver = app.StorageDriverMongo
+	cfg.Mongo.DisableTransactions = true
+	cfg.Quota.MaxActive = 1
+	assert.EqualError(t, cfg.Validate(), "quota requires mongo transactions")
+
+	cfg.Mongo.DisableTransactions = false
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Storage.Driver, cfg.Mongo.DisableTransactions = app.StorageDriverMemory, true
+	assert.NoError(t, cfg.Validate())
+}
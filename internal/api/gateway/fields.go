@@ -0,0 +1,121 @@
+package gateway
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// setField sets field of msg by path of proto names, nested fields are separated by dot,
+// e.g. created_at_timerange.start=2021-03-01T00:00:00Z
+func setField(msg protoreflect.Message, path string, values []string) error {
+	var names = strings.Split(path, ".")
+	for i, name := range names {
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return fmt.Errorf("unknown field %s", path)
+		}
+		if i == len(names)-1 {
+			return setValues(msg, fd, values)
+		}
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+			return fmt.Errorf("field %s doesn't have nested fields", strings.Join(names[:i+1], "."))
+		}
+		msg = msg.Mutable(fd).Message()
+	}
+	return nil
+}
+
+func setValues(msg protoreflect.Message, fd protoreflect.FieldDescriptor, values []string) error {
+	if fd.IsMap() {
+		return fmt.Errorf("map field %s isn't supported", fd.Name())
+	}
+
+	if fd.IsList() {
+		list := msg.Mutable(fd).List()
+		for _, value := range values {
+			v, err := parseValue(msg, fd, value)
+			if err != nil {
+				return err
+			}
+			list.Append(v)
+		}
+		return nil
+	}
+
+	if len(values) != 1 {
+		return fmt.Errorf("field %s should have one value", fd.Name())
+	}
+	v, err := parseValue(msg, fd, values[0])
+	if err != nil {
+		return err
+	}
+	msg.Set(fd, v)
+	return nil
+}
+
+func parseValue(msg protoreflect.Message, fd protoreflect.FieldDescriptor, value string) (protoreflect.Value, error) {
+	var (
+		v   protoreflect.Value
+		err error
+	)
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		var b bool
+		b, err = strconv.ParseBool(value)
+		v = protoreflect.ValueOfBool(b)
+	case protoreflect.StringKind:
+		v = protoreflect.ValueOfString(value)
+	case protoreflect.BytesKind:
+		v = protoreflect.ValueOfBytes([]byte(value))
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var i int64
+		i, err = strconv.ParseInt(value, 10, 32)
+		v = protoreflect.ValueOfInt32(int32(i))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		var i int64
+		i, err = strconv.ParseInt(value, 10, 64)
+		v = protoreflect.ValueOfInt64(i)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		var u uint64
+		u, err = strconv.ParseUint(value, 10, 32)
+		v = protoreflect.ValueOfUint32(uint32(u))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		var u uint64
+		u, err = strconv.ParseUint(value, 10, 64)
+		v = protoreflect.ValueOfUint64(u)
+	case protoreflect.FloatKind:
+		var f float64
+		f, err = strconv.ParseFloat(value, 32)
+		v = protoreflect.ValueOfFloat32(float32(f))
+	case protoreflect.DoubleKind:
+		var f float64
+		f, err = strconv.ParseFloat(value, 64)
+		v = protoreflect.ValueOfFloat64(f)
+	case protoreflect.EnumKind:
+		// enum is set by name or by number
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(value)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		var i int64
+		i, err = strconv.ParseInt(value, 10, 32)
+		v = protoreflect.ValueOfEnum(protoreflect.EnumNumber(i))
+	case protoreflect.MessageKind:
+		// well known types as timestamp and wrappers are parsed from their json form
+		m := msg.NewField(fd).Message()
+		if err = protojson.Unmarshal([]byte(value), m.Interface()); err != nil {
+			m = msg.NewField(fd).Message()
+			err = protojson.Unmarshal([]byte(strconv.Quote(value)), m.Interface())
+		}
+		v = protoreflect.ValueOfMessage(m)
+	default:
+		return v, fmt.Errorf("field %s of kind %s isn't supported", fd.Name(), fd.Kind())
+	}
+	if err != nil {
+		return v, fmt.Errorf("invalid value of field %s: %w", fd.Name(), err)
+	}
+	return v, nil
+}
@@ -0,0 +1,321 @@
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/api/interceptors"
+	api "github.com/PxyUp/backend_tech_task/pkg/proto"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type Config struct {
+	Address string `envconfig:"address"`
+	// ShutdownTimeout limits waiting for in progress requests on shutdown
+	ShutdownTimeout time.Duration `envconfig:"shutdown_timeout"`
+	// MaxBodyBytes limits size of request body, larger requests are rejected with 413
+	MaxBodyBytes int64 `envconfig:"max_body_bytes"`
+}
+
+const (
+	defaultAddress         = ":8081"
+	defaultShutdownTimeout = 5 * time.Second
+	defaultMaxBodyBytes    = 1 << 20
+
+	// OpenAPIPath is path of OpenAPI document of gateway
+	OpenAPIPath = "/openapi.json"
+)
+
+var errBodyTooLarge = errors.New("request body is too large")
+
+var (
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	unmarshalOptions = protojson.UnmarshalOptions{}
+)
+
+// Server translates HTTP/JSON requests to calls of grpc services,
+// field names of json and query parameters are names of proto fields
+type Server struct {
+	cfg     Config
+	routes  []route
+	openAPI []byte
+	// interceptor is called around every call as in grpc server
+	interceptor grpc.UnaryServerInterceptor
+	// tlsConfig enables TLS, it should be the same as config of grpc server to not bypass client certificates
+	tlsConfig *tls.Config
+}
+
+type Option func(srv *Server)
+
+// WithUnaryInterceptor sets interceptor of every call, it's the same chain as one of grpc server
+func WithUnaryInterceptor(interceptor grpc.UnaryServerInterceptor) Option {
+	return func(srv *Server) {
+		srv.interceptor = interceptor
+	}
+}
+
+// WithTLSConfig serves gateway over TLS, client certificate is passed to interceptors as grpc peer
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(srv *Server) {
+		srv.tlsConfig = tlsConfig
+	}
+}
+
+func NewServer(cfg Config, applicationService api.ApplicationServiceServer, opts ...Option) (*Server, error) {
+	if cfg.Address == "" {
+		cfg.Address = defaultAddress
+	}
+	if cfg.ShutdownTimeout == 0 {
+		cfg.ShutdownTimeout = defaultShutdownTimeout
+	}
+	if cfg.MaxBodyBytes == 0 {
+		cfg.MaxBodyBytes = defaultMaxBodyBytes
+	}
+
+	var srv = &Server{cfg: cfg, routes: applicationRoutes(applicationService)}
+	for _, opt := range opts {
+		opt(srv)
+	}
+
+	doc, err := json.Marshal(newOpenAPI(srv.routes))
+	if err != nil {
+		return nil, err
+	}
+	srv.openAPI = doc
+	return srv, nil
+}
+
+func (srv *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", srv.cfg.Address)
+	if err != nil {
+		return err
+	}
+	if srv.tlsConfig != nil {
+		listener = tls.NewListener(listener, srv.tlsConfig)
+	}
+	httpServer := &http.Server{Handler: srv}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), srv.cfg.ShutdownTimeout)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			log.Err(err).Msg("couldn't shutdown gateway gracefully")
+		}
+	}()
+
+	log.Info().Bool("tls", srv.tlsConfig != nil).Msg("gateway started")
+	if err := httpServer.Serve(listener); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == OpenAPIPath && r.Method == http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(srv.openAPI)
+		return
+	}
+
+	var allowed []string
+	for _, route := range srv.routes {
+		params, ok := route.pattern.match(r.URL.Path)
+		if !ok {
+			continue
+		}
+		if route.method != r.Method {
+			allowed = append(allowed, route.method)
+			continue
+		}
+		srv.serve(w, r, route, params)
+		return
+	}
+
+	if len(allowed) != 0 {
+		sort.Strings(allowed)
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		writeStatus(w, http.StatusMethodNotAllowed,
+			status.Newf(codes.Unimplemented, "method %s isn't allowed for %s", r.Method, r.URL.Path))
+		return
+	}
+	writeError(w, status.Errorf(codes.NotFound, "path %s isn't found", r.URL.Path))
+}
+
+func (srv *Server) serve(w http.ResponseWriter, r *http.Request, route route, params map[string]string) {
+	// request id is generated here to be echoed in header, interceptors take it from metadata
+	requestID := r.Header.Get(interceptors.RequestIDMetadata)
+	if requestID == "" {
+		requestID = interceptors.NewRequestID()
+		r.Header.Set(interceptors.RequestIDMetadata, requestID)
+	}
+	w.Header().Set(interceptors.RequestIDMetadata, requestID)
+
+	r.Body = http.MaxBytesReader(w, r.Body, srv.cfg.MaxBodyBytes)
+	req, err := newRequest(r, route, params)
+	if errors.Is(err, errBodyTooLarge) {
+		writeStatus(w, http.StatusRequestEntityTooLarge,
+			status.Newf(codes.InvalidArgument, "request body is larger than %d bytes", srv.cfg.MaxBodyBytes))
+		return
+	}
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+
+	resp, err := srv.call(incomingContext(r), route, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	body, err := marshalOptions.Marshal(proto.MessageV2(resp))
+	if err != nil {
+		log.Err(err).Str("rpc", route.rpc).Msg("couldn't marshal response")
+		writeError(w, status.Error(codes.Internal, "internal server error"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+func (srv *Server) call(ctx context.Context, route route, req proto.Message) (proto.Message, error) {
+	if srv.interceptor == nil {
+		return route.call(ctx, req)
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/" + applicationServiceName + "/" + route.rpc}
+	resp, err := srv.interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return route.call(ctx, req.(proto.Message))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(proto.Message), nil
+}
+
+// newRequest reads request message from json body or from query, path parameters take precedence over both
+func newRequest(r *http.Request, route route, params map[string]string) (proto.Message, error) {
+	var req = route.newRequest()
+	msg := proto.MessageV2(req).ProtoReflect()
+
+	if route.body {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			if isBodyTooLarge(err) {
+				return nil, errBodyTooLarge
+			}
+			return nil, err
+		}
+		if len(body) != 0 {
+			if err := unmarshalOptions.Unmarshal(body, proto.MessageV2(req)); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		for name, values := range r.URL.Query() {
+			if err := setField(msg, name, values); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for name, value := range params {
+		if err := setField(msg, name, []string{value}); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// isBodyTooLarge reports whether body limited by http.MaxBytesReader exceeds the limit,
+// error of http.MaxBytesReader isn't exported
+func isBodyTooLarge(err error) bool {
+	return err.Error() == "http: request body too large"
+}
+
+// incomingContext passes headers of request as grpc metadata, e.g. Idempotency-Key
+func incomingContext(r *http.Request) context.Context {
+	var md = make(metadata.MD, len(r.Header))
+	for name, values := range r.Header {
+		md.Append(strings.ToLower(name), values...)
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+
+	// verified client certificate is available as in grpc server
+	if r.TLS != nil {
+		var addr net.Addr
+		if tcpAddr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+			addr = tcpAddr
+		}
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr, AuthInfo: credentials.TLSInfo{State: *r.TLS}})
+	}
+	return ctx
+}
+
+// writeError writes grpc status of err as json body,
+// e.g. {"code": 5, "message": "application not found", "details": []}
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	writeStatus(w, HTTPStatusFromCode(st.Code()), st)
+}
+
+// writeStatus writes st as json body with given HTTP status, e.g. 405 which doesn't follow from grpc code
+func writeStatus(w http.ResponseWriter, code int, st *status.Status) {
+	body, marshalErr := marshalOptions.Marshal(st.Proto())
+	if marshalErr != nil {
+		log.Err(marshalErr).Msg("couldn't marshal error")
+		body = []byte(`{"code": 13, "message": "internal server error", "details": []}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+// HTTPStatusFromCode returns HTTP status of grpc code
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,227 @@
+package gateway_test
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/api/gateway"
+	api_grpc "github.com/PxyUp/backend_tech_task/internal/api/grpc"
+	"github.com/PxyUp/backend_tech_task/internal/api/grpc/services"
+	"github.com/PxyUp/backend_tech_task/internal/api/interceptors"
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	application_embedded "github.com/PxyUp/backend_tech_task/internal/application/embedded"
+	"github.com/PxyUp/backend_tech_task/internal/auth"
+	"github.com/PxyUp/backend_tech_task/internal/external"
+	external_mock "github.com/PxyUp/backend_tech_task/internal/external/mock"
+	idempotency_memory "github.com/PxyUp/backend_tech_task/internal/idempotency/memory"
+	"github.com/PxyUp/backend_tech_task/internal/util/certtest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	const userID = "603bd5e5967f2dba00c8e325"
+
+	ctrl := gomock.NewController(t)
+
+	repository, err := application_embedded.NewRepository(application_embedded.InMemory)
+	require.NoError(t, err)
+	defer repository.Close()
+
+	externalClient := external_mock.NewMockClient(ctrl)
+	externalClient.EXPECT().GetExternalStatus(gomock.Any(), gomock.Any()).Return(external.StatusProcessed, nil).AnyTimes()
+
+	srv, err := gateway.NewServer(
+		gateway.Config{MaxBodyBytes: 1024},
+		services.NewApplicationService(application.NewService(
+			repository,
+			externalClient,
+			application.WithIdempotency(idempotency_memory.NewStore(), time.Hour),
+		)),
+		gateway.WithUnaryInterceptor(interceptors.Unary()),
+	)
+	require.NoError(t, err)
+
+	httpServer := httptest.NewServer(srv)
+	defer httpServer.Close()
+
+	do := func(t *testing.T, method string, path string, body string, header http.Header) (int, map[string]interface{}) {
+		t.Helper()
+		req, err := http.NewRequest(method, httpServer.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		if id := header.Get(interceptors.RequestIDMetadata); id != "" {
+			assert.Equal(t, id, resp.Header.Get(interceptors.RequestIDMetadata))
+		}
+
+		data, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &res), string(data))
+		return resp.StatusCode, res
+	}
+
+	code, created := do(t, http.MethodPost, "/v1/applications", `{"user_id": "`+userID+`", "priority": "APPLICATION_PRIORITY_HIGH"}`,
+		http.Header{"Idempotency-Key": {"key"}, "X-Request-Id": {"request-1"}})
+	require.Equal(t, http.StatusOK, code, created)
+	assert.Equal(t, "APPLICATION_STATUS_OPEN", created["status"])
+	assert.Equal(t, "APPLICATION_PRIORITY_HIGH", created["priority"])
+	assert.Equal(t, false, created["breached"])
+
+	id, _ := created["id"].(string)
+
+	// header is passed as metadata
+	code, repeated := do(t, http.MethodPost, "/v1/applications", `{"user_id": "`+userID+`", "priority": "APPLICATION_PRIORITY_HIGH"}`,
+		http.Header{"Idempotency-Key": {"key"}})
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, id, repeated["id"])
+
+	code, found := do(t, http.MethodGet, "/v1/applications/"+id, "", nil)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, id, found["id"])
+	assert.Equal(t, created["priority"], found["priority"])
+
+	code, list := do(t, http.MethodGet, "/v1/applications?status=APPLICATION_STATUS_OPEN&user_id="+userID+
+		"&created_at_timerange.start=2021-01-01T00:00:00Z&created_at_timerange.end=2100-01-01T00:00:00Z&page_size=1", "", nil)
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, list["applications"], 1)
+	assert.Equal(t, "", list["next_page_token"])
+
+	code, updated := do(t, http.MethodPatch, "/v1/applications/"+id, `{"status": "APPLICATION_STATUS_IN_PROGRESS", "expected_version": "0"}`, nil)
+	require.Equal(t, http.StatusOK, code, updated)
+	assert.Equal(t, "APPLICATION_STATUS_IN_PROGRESS", updated["status"])
+	assert.Equal(t, "1", updated["version"])
+
+	code, assigned := do(t, http.MethodPost, "/v1/applications/"+id+":assign", `{"assignee_id": "`+userID+`"}`, nil)
+	require.Equal(t, http.StatusOK, code, assigned)
+	assert.Equal(t, userID, assigned["assignee_id"])
+
+	for _, c := range []struct {
+		Method  string
+		Path    string
+		Body    string
+		ExpCode int
+	}{
+		{http.MethodGet, "/v1/applications/603bd5e5967f2dba00c8e000", "", http.StatusNotFound},
+		{http.MethodGet, "/v1/applications?status=DONE", "", http.StatusBadRequest},
+		{http.MethodGet, "/v1/applications?unknown=1", "", http.StatusBadRequest},
+		{http.MethodPost, "/v1/applications", `{"user_id": 1}`, http.StatusBadRequest},
+		{http.MethodPatch, "/v1/applications/" + id, `{"status": "APPLICATION_STATUS_OPEN", "expected_version": "0"}`, http.StatusPreconditionFailed},
+		{http.MethodPut, "/v1/applications/" + id, "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/v1/applications", `{"user_id": "` + strings.Repeat("0", 1024) + `"}`, http.StatusRequestEntityTooLarge},
+		{http.MethodGet, "/v2/applications", "", http.StatusNotFound},
+	} {
+		code, body := do(t, c.Method, c.Path, c.Body, nil)
+		assert.Equal(t, c.ExpCode, code, "%s %s: %v", c.Method, c.Path, body)
+		assert.Contains(t, body, "code")
+		assert.Contains(t, body, "message")
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, httpServer.URL+"/v1/applications", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	assert.Equal(t, "GET, POST", resp.Header.Get("Allow"))
+
+	code, doc := do(t, http.MethodGet, gateway.OpenAPIPath, "", nil)
+	require.Equal(t, http.StatusOK, code)
+	paths, _ := doc["paths"].(map[string]interface{})
+	assert.Contains(t, paths, "/v1/applications/{id}:assign")
+	assert.Len(t, paths, 7)
+}
+
+func TestServer_TLS(t *testing.T) {
+	var (
+		dir      = t.TempDir()
+		certFile = filepath.Join(dir, "server.crt")
+		keyFile  = filepath.Join(dir, "server.key")
+		caFile   = filepath.Join(dir, "ca.crt")
+		ca       = certtest.NewCA(t)
+	)
+	ca.Write(t, caFile, "")
+	certtest.NewServer(t, ca, "server").Write(t, certFile, keyFile)
+
+	tlsConfig, err := api_grpc.NewTLSConfig(api_grpc.TLSConfig{
+		CertFile:          certFile,
+		KeyFile:           keyFile,
+		ClientCAFile:      caFile,
+		RequireClientCert: true,
+	}, "http/1.1")
+	require.NoError(t, err)
+
+	repository, err := application_embedded.NewRepository(application_embedded.InMemory)
+	require.NoError(t, err)
+	defer repository.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	srv, err := gateway.NewServer(
+		gateway.Config{Address: address},
+		services.NewApplicationService(application.NewService(repository, external_mock.NewMockClient(gomock.NewController(t)))),
+		// policy passes only callers authenticated by client certificate
+		gateway.WithUnaryInterceptor(interceptors.Unary(
+			auth.CertificateRoles{"billing": auth.RoleOperator}.UnaryInterceptor,
+			auth.DefaultPolicy().UnaryInterceptor,
+		)),
+		gateway.WithTLSConfig(tlsConfig),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = srv.Run(ctx) }()
+
+	get := func(certificates ...tls.Certificate) (*http.Response, error) {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      ca.Pool(),
+			ServerName:   "localhost",
+			Certificates: certificates,
+		}}}
+		defer client.CloseIdleConnections()
+
+		var (
+			resp *http.Response
+			err  error
+		)
+		// server is started in background
+		for i := 0; i < 50; i++ {
+			resp, err = client.Get("https://" + address + "/v1/applications?status=APPLICATION_STATUS_OPEN")
+			if err == nil || !strings.Contains(err.Error(), "connection refused") {
+				break
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		return resp, err
+	}
+
+	_, err = get()
+	assert.Error(t, err)
+
+	resp, err := get(certtest.NewClient(t, ca, "billing").TLS(t))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,199 @@
+package gateway
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+type object = map[string]interface{}
+
+// newOpenAPI describes routes by OpenAPI 3 document, schemas are built from descriptors of proto messages
+func newOpenAPI(routes []route) object {
+	var (
+		schemas = schemas{
+			"Error": object{
+				"type": "object",
+				"properties": object{
+					"code":    object{"type": "integer", "format": "int32", "description": "grpc status code"},
+					"message": object{"type": "string"},
+					"details": object{"type": "array", "items": object{"type": "object"}},
+				},
+			},
+		}
+		paths = make(map[string]object)
+	)
+
+	for _, r := range routes {
+		method, ok := findMethod(r.rpc)
+		if !ok {
+			log.Warn().Str("rpc", r.rpc).Msg("method of route isn't found")
+			continue
+		}
+
+		var parameters []object
+		for _, name := range r.pattern.params() {
+			parameters = append(parameters, object{
+				"name": name, "in": "path", "required": true, "schema": object{"type": "string"},
+			})
+		}
+
+		operation := object{
+			"operationId": r.rpc,
+			"responses": object{
+				"200": object{
+					"description": "OK",
+					"content":     object{"application/json": object{"schema": schemas.ref(method.Output())}},
+				},
+				"default": object{
+					"description": "Error",
+					"content":     object{"application/json": object{"schema": object{"$ref": "#/components/schemas/Error"}}},
+				},
+			},
+		}
+		if r.body {
+			operation["requestBody"] = object{
+				"content": object{"application/json": object{"schema": schemas.ref(method.Input())}},
+			}
+		} else {
+			parameters = append(parameters, schemas.query(method.Input(), "", r.pattern.params())...)
+		}
+		if len(parameters) != 0 {
+			operation["parameters"] = parameters
+		}
+
+		if paths[r.pattern.raw] == nil {
+			paths[r.pattern.raw] = make(object)
+		}
+		paths[r.pattern.raw][strings.ToLower(r.method)] = operation
+	}
+
+	return object{
+		"openapi":    "3.0.3",
+		"info":       object{"title": applicationServiceName, "version": "v1"},
+		"paths":      paths,
+		"components": object{"schemas": schemas},
+	}
+}
+
+func findMethod(rpc string) (protoreflect.MethodDescriptor, bool) {
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(applicationServiceName)
+	if err != nil {
+		return nil, false
+	}
+	service, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, false
+	}
+	method := service.Methods().ByName(protoreflect.Name(rpc))
+	return method, method != nil
+}
+
+// schemas are named schemas of messages
+type schemas object
+
+func (s schemas) ref(md protoreflect.MessageDescriptor) object {
+	if schema, ok := wellKnownSchema(md); ok {
+		return schema
+	}
+
+	var name = string(md.FullName())
+	if _, ok := s[name]; !ok {
+		// placeholder stops recursion of nested messages
+		s[name] = nil
+
+		var properties = make(object)
+		for i := 0; i < md.Fields().Len(); i++ {
+			fd := md.Fields().Get(i)
+			properties[string(fd.Name())] = s.field(fd)
+		}
+		s[name] = object{"type": "object", "properties": properties}
+	}
+	return object{"$ref": "#/components/schemas/" + name}
+}
+
+func (s schemas) field(fd protoreflect.FieldDescriptor) object {
+	if fd.IsList() {
+		return object{"type": "array", "items": s.value(fd)}
+	}
+	return s.value(fd)
+}
+
+func (s schemas) value(fd protoreflect.FieldDescriptor) object {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return object{"type": "boolean"}
+	case protoreflect.StringKind:
+		return object{"type": "string"}
+	case protoreflect.BytesKind:
+		return object{"type": "string", "format": "byte"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return object{"type": "integer", "format": "int32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// 64-bit integers are strings in json
+		return object{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return object{"type": "number"}
+	case protoreflect.EnumKind:
+		var values []string
+		for i := 0; i < fd.Enum().Values().Len(); i++ {
+			values = append(values, string(fd.Enum().Values().Get(i).Name()))
+		}
+		return object{"type": "string", "enum": values}
+	case protoreflect.MessageKind:
+		return s.ref(fd.Message())
+	}
+	return object{}
+}
+
+// query returns query parameters of fields of message, nested fields are prefixed by name of parent
+func (s schemas) query(md protoreflect.MessageDescriptor, prefix string, exclude []string) []object {
+	var res []object
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		name := prefix + string(fd.Name())
+		if contains(exclude, name) {
+			continue
+		}
+
+		if fd.Kind() == protoreflect.MessageKind && !fd.IsList() {
+			if _, ok := wellKnownSchema(fd.Message()); !ok {
+				res = append(res, s.query(fd.Message(), name+".", nil)...)
+				continue
+			}
+		}
+		res = append(res, object{"name": name, "in": "query", "schema": s.field(fd)})
+	}
+	return res
+}
+
+func wellKnownSchema(md protoreflect.MessageDescriptor) (object, bool) {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		return object{"type": "string", "format": "date-time"}, true
+	case "google.protobuf.Int64Value", "google.protobuf.UInt64Value":
+		return object{"type": "string", "format": "int64"}, true
+	case "google.protobuf.Int32Value", "google.protobuf.UInt32Value":
+		return object{"type": "integer", "format": "int32"}, true
+	case "google.protobuf.BoolValue":
+		return object{"type": "boolean"}, true
+	case "google.protobuf.StringValue":
+		return object{"type": "string"}, true
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue":
+		return object{"type": "number"}, true
+	}
+	return nil, false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	api "github.com/PxyUp/backend_tech_task/pkg/proto"
+
+	"github.com/golang/protobuf/proto"
+)
+
+type route struct {
+	method  string
+	pattern pattern
+	// rpc is name of method of ApplicationService
+	rpc string
+	// body means request is read from json body, otherwise it's read from query
+	body       bool
+	newRequest func() proto.Message
+	call       func(ctx context.Context, req proto.Message) (proto.Message, error)
+}
+
+const applicationServiceName = "api.ApplicationService"
+
+func applicationRoutes(svc api.ApplicationServiceServer) []route {
+	return []route{
+		{
+			method: http.MethodPost, pattern: newPattern("/v1/applications"), rpc: "CreateApplication", body: true,
+			newRequest: func() proto.Message { return new(api.CreateApplicationRequest) },
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return svc.CreateApplication(ctx, req.(*api.CreateApplicationRequest))
+			},
+		},
+		{
+			method: http.MethodPost, pattern: newPattern("/v1/applications:batchCreate"), rpc: "CreateApplications", body: true,
+			newRequest: func() proto.Message { return new(api.CreateApplicationsRequest) },
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return svc.CreateApplications(ctx, req.(*api.CreateApplicationsRequest))
+			},
+		},
+		{
+			method: http.MethodGet, pattern: newPattern("/v1/applications"), rpc: "GetApplicationsByFilters",
+			newRequest: func() proto.Message { return new(api.GetApplicationsByFiltersRequest) },
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return svc.GetApplicationsByFilters(ctx, req.(*api.GetApplicationsByFiltersRequest))
+			},
+		},
+		{
+			method: http.MethodPost, pattern: newPattern("/v1/applications:bulkUpdateStatus"), rpc: "BulkUpdateApplicationsStatus", body: true,
+			newRequest: func() proto.Message { return new(api.BulkUpdateApplicationsStatusRequest) },
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return svc.BulkUpdateApplicationsStatus(ctx, req.(*api.BulkUpdateApplicationsStatusRequest))
+			},
+		},
+		{
+			method: http.MethodGet, pattern: newPattern("/v1/applications/{id}"), rpc: "GetApplicationById",
+			newRequest: func() proto.Message { return new(api.GetApplicationByIdRequest) },
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return svc.GetApplicationById(ctx, req.(*api.GetApplicationByIdRequest))
+			},
+		},
+		{
+			method: http.MethodPatch, pattern: newPattern("/v1/applications/{id}"), rpc: "UpdateApplication", body: true,
+			newRequest: func() proto.Message { return new(api.UpdateApplicationRequest) },
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return svc.UpdateApplication(ctx, req.(*api.UpdateApplicationRequest))
+			},
+		},
+		{
+			method: http.MethodDelete, pattern: newPattern("/v1/applications/{id}"), rpc: "DeleteApplication",
+			newRequest: func() proto.Message { return new(api.DeleteApplicationRequest) },
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return svc.DeleteApplication(ctx, req.(*api.DeleteApplicationRequest))
+			},
+		},
+		{
+			method: http.MethodPost, pattern: newPattern("/v1/applications/{id}:restore"), rpc: "RestoreApplication", body: true,
+			newRequest: func() proto.Message { return new(api.RestoreApplicationRequest) },
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return svc.RestoreApplication(ctx, req.(*api.RestoreApplicationRequest))
+			},
+		},
+		{
+			method: http.MethodPost, pattern: newPattern("/v1/applications/{id}:assign"), rpc: "AssignApplication", body: true,
+			newRequest: func() proto.Message { return new(api.AssignApplicationRequest) },
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return svc.AssignApplication(ctx, req.(*api.AssignApplicationRequest))
+			},
+		},
+		{
+			method: http.MethodPost, pattern: newPattern("/v1/applications/{id}:unassign"), rpc: "UnassignApplication", body: true,
+			newRequest: func() proto.Message { return new(api.UnassignApplicationRequest) },
+			call: func(ctx context.Context, req proto.Message) (proto.Message, error) {
+				return svc.UnassignApplication(ctx, req.(*api.UnassignApplicationRequest))
+			},
+		},
+	}
+}
+
+// pattern is path with parameters, e.g. /v1/applications/{id}:assign,
+// parameter takes whole segment except of static suffix
+type pattern struct {
+	raw      string
+	segments []segment
+}
+
+type segment struct {
+	// param is name of field of request, it's empty for static segment
+	param string
+	// value is static segment or suffix of parameter
+	value string
+}
+
+func newPattern(raw string) pattern {
+	var p = pattern{raw: raw}
+	for _, s := range strings.Split(strings.Trim(raw, "/"), "/") {
+		if strings.HasPrefix(s, "{") {
+			end := strings.Index(s, "}")
+			p.segments = append(p.segments, segment{param: s[1:end], value: s[end+1:]})
+			continue
+		}
+		p.segments = append(p.segments, segment{value: s})
+	}
+	return p
+}
+
+// match returns parameters of path if it matches pattern
+func (p pattern) match(path string) (map[string]string, bool) {
+	var parts = strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != len(p.segments) {
+		return nil, false
+	}
+
+	var params = make(map[string]string)
+	for i, s := range p.segments {
+		if s.param == "" {
+			if parts[i] != s.value {
+				return nil, false
+			}
+			continue
+		}
+
+		if !strings.HasSuffix(parts[i], s.value) {
+			return nil, false
+		}
+		value := strings.TrimSuffix(parts[i], s.value)
+		if value == "" || strings.Contains(value, ":") {
+			return nil, false
+		}
+		params[s.param] = value
+	}
+	return params, true
+}
+
+// params returns names of parameters of pattern
+func (p pattern) params() []string {
+	var res []string
+	for _, s := range p.segments {
+		if s.param != "" {
+			res = append(res, s.param)
+		}
+	}
+	return res
+}
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"github.com/PxyUp/backend_tech_task/internal/api/grpc/services"
+	"github.com/PxyUp/backend_tech_task/internal/health"
 	api "github.com/PxyUp/backend_tech_task/pkg/proto"
 
 	"context"
@@ -9,48 +10,104 @@ import (
 
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 type Config struct {
 	Address string `envconfig:"address"`
+	// Reflection enables server reflection, e.g. for grpcurl
+	Reflection bool `envconfig:"reflection"`
+	// TLS is disabled if cert and key aren't set
+	TLS TLSConfig `envconfig:"tls"`
 }
 
 type Server struct {
 	cfg Config
 
 	applicationService *services.ApplicationService
+	webhookService     *services.WebhookService
+	checker            *health.Checker
+
+	unaryInterceptor  grpc.UnaryServerInterceptor
+	streamInterceptor grpc.StreamServerInterceptor
+}
+
+type ServerOption func(srv *Server)
+
+// WithInterceptors sets interceptors of every call, chain of interceptors should be joined in one
+func WithInterceptors(unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) ServerOption {
+	return func(srv *Server) {
+		srv.unaryInterceptor = unary
+		srv.streamInterceptor = stream
+	}
 }
 
 func NewServer(
 	cfg Config,
 	applicationService *services.ApplicationService,
+	webhookService *services.WebhookService,
+	checker *health.Checker,
+	opts ...ServerOption,
 ) *Server {
 	if cfg.Address == "" {
 		cfg.Address = ":8080"
 	}
 
-	return &Server{
+	srv := &Server{
 		cfg:                cfg,
 		applicationService: applicationService,
+		webhookService:     webhookService,
+		checker:            checker,
+	}
+	for _, opt := range opts {
+		opt(srv)
 	}
+	return srv
 }
 
 func (srv Server) Run(ctx context.Context) error {
+	var serverOptions []grpc.ServerOption
+	if srv.cfg.TLS.Enabled() {
+		tlsConfig, err := NewTLSConfig(srv.cfg.TLS, "h2")
+		if err != nil {
+			return err
+		}
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
 	listener, err := net.Listen("tcp", srv.cfg.Address)
 	if err != nil {
 		return err
 	}
 
-	grpcServer := grpc.NewServer()
+	if srv.unaryInterceptor != nil {
+		serverOptions = append(serverOptions, grpc.UnaryInterceptor(srv.unaryInterceptor))
+	}
+	if srv.streamInterceptor != nil {
+		serverOptions = append(serverOptions, grpc.StreamInterceptor(srv.streamInterceptor))
+	}
+	grpcServer := grpc.NewServer(serverOptions...)
 
 	api.RegisterApplicationServiceServer(grpcServer, srv.applicationService)
+	api.RegisterWebhookServiceServer(grpcServer, srv.webhookService)
+	for name := range grpcServer.GetServiceInfo() {
+		srv.checker.AddService(name)
+	}
+	grpc_health_v1.RegisterHealthServer(grpcServer, srv.checker.Server())
+	if srv.cfg.Reflection {
+		reflection.Register(grpcServer)
+	}
 
 	go func() {
 		<-ctx.Done()
+		// clients stop sending new requests before connections are closed
+		srv.checker.Shutdown()
 		grpcServer.GracefulStop()
 		_ = listener.Close()
 	}()
 
-	log.Info().Msg("grpc server started")
+	log.Info().Bool("tls", srv.cfg.TLS.Enabled()).Msg("grpc server started")
 	return grpcServer.Serve(listener)
 }
@@ -3,13 +3,21 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 
 	"github.com/PxyUp/backend_tech_task/internal/application"
+	"github.com/PxyUp/backend_tech_task/internal/auth"
 	api "github.com/PxyUp/backend_tech_task/pkg/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+// DefaultPageSize is number of applications of page of GetApplicationsByFilters without page_size
+const DefaultPageSize = 100
+
 type ApplicationService struct {
 	applicationService application.Service
 }
@@ -25,7 +33,12 @@ func (svc ApplicationService) CreateApplication(ctx context.Context, req *api.Cr
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	app, err := svc.applicationService.Create(ctx, req.GetUserId())
+	params := ParseCreateApplicationRequest(ctx, req)
+	if err := ownUserID(ctx, &params.UserID); err != nil {
+		return nil, err
+	}
+
+	app, err := svc.applicationService.Create(ctx, params)
 	if err != nil {
 		if errors.Is(err, application.ErrInvalidArgument) {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -33,17 +46,163 @@ func (svc ApplicationService) CreateApplication(ctx context.Context, req *api.Cr
 		if errors.Is(err, application.ErrApplicationNotFound) {
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
+		if errors.Is(err, application.ErrIdempotencyKeyReused) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		if errors.Is(err, application.ErrRequestInProgress) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		if errors.Is(err, application.ErrQuotaExceeded) {
+			return nil, NewQuotaError(err)
+		}
 		return nil, StatusInternal.Err()
 	}
 	return NewApplication(app), nil
 }
 
+// IdempotencyKeyMetadata is metadata key of idempotency key, field of request takes precedence over it
+const IdempotencyKeyMetadata = "idempotency-key"
+
+func ParseCreateApplicationRequest(ctx context.Context, req *api.CreateApplicationRequest) *application.CreateParams {
+	var params = &application.CreateParams{
+		UserID:         req.GetUserId(),
+		IdempotencyKey: req.GetIdempotencyKey(),
+		Priority:       ParseApplicationPriority(req.GetPriority()),
+	}
+	if params.IdempotencyKey == "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(IdempotencyKeyMetadata); len(values) > 0 {
+				params.IdempotencyKey = values[0]
+			}
+		}
+	}
+	return params
+}
+
+// NewQuotaError returns ResourceExhausted status with violated quota, its limit and usage in details
+func NewQuotaError(err error) error {
+	st := status.New(codes.ResourceExhausted, err.Error())
+
+	var quotaErr *application.QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		return st.Err()
+	}
+
+	var metadata = map[string]string{
+		"quota": quotaErr.Quota,
+		"limit": strconv.Itoa(quotaErr.Limit),
+		"usage": strconv.Itoa(quotaErr.Usage),
+	}
+	if quotaErr.Window != 0 {
+		metadata["window"] = quotaErr.Window.String()
+	}
+	detailed, detailsErr := st.WithDetails(
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{
+				Subject:     "user:" + quotaErr.UserID,
+				Description: fmt.Sprintf("%s applications: limit %d, usage %d", quotaErr.Quota, quotaErr.Limit, quotaErr.Usage),
+			}},
+		},
+		&errdetails.ErrorInfo{
+			Reason:   "APPLICATION_QUOTA_EXCEEDED",
+			Metadata: metadata,
+		},
+	)
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func (svc ApplicationService) CreateApplications(ctx context.Context, req *api.CreateApplicationsRequest) (*api.CreateApplicationsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	params := ParseCreateApplicationsRequest(req)
+	for i := range params.UserIDs {
+		if err := ownUserID(ctx, &params.UserIDs[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	results, err := svc.applicationService.CreateMany(ctx, params)
+	if err != nil {
+		if errors.Is(err, application.ErrInvalidArgument) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, StatusInternal.Err()
+	}
+	return NewCreateApplicationsResponse(results), nil
+}
+
+func ParseCreateApplicationsRequest(req *api.CreateApplicationsRequest) *application.CreateManyParams {
+	params := &application.CreateManyParams{
+		UserIDs:    make([]string, len(req.GetApplications())),
+		Priorities: make([]application.Priority, len(req.GetApplications())),
+		Mode:       application.BatchModeAllOrNothing,
+	}
+	if req.GetMode() == api.CreateApplicationsRequest_CREATE_APPLICATIONS_MODE_BEST_EFFORT {
+		params.Mode = application.BatchModeBestEffort
+	}
+	for i, item := range req.GetApplications() {
+		params.UserIDs[i] = item.GetUserId()
+		params.Priorities[i] = ParseApplicationPriority(item.GetPriority())
+	}
+	return params
+}
+
+func NewCreateApplicationsResponse(results []application.CreateResult) *api.CreateApplicationsResponse {
+	var resp = &api.CreateApplicationsResponse{
+		Results: make([]*api.CreateApplicationsResponse_Result, len(results)),
+	}
+	for i, result := range results {
+		resp.Results[i] = &api.CreateApplicationsResponse_Result{
+			Application: NewApplication(result.Application),
+			Error:       NewBatchItemError(result.Err),
+		}
+	}
+	return resp
+}
+
+// NewBatchItemError converts error of item of batch, internal errors are hidden as for single calls
+func NewBatchItemError(err error) *api.Error {
+	if err == nil {
+		return nil
+	}
+
+	var st = StatusInternal
+	switch {
+	case errors.Is(err, application.ErrInvalidArgument):
+		st = status.New(codes.InvalidArgument, err.Error())
+	case errors.Is(err, application.ErrApplicationAlreadyExists):
+		st = status.New(codes.AlreadyExists, err.Error())
+	case errors.Is(err, application.ErrQuotaExceeded):
+		st = status.New(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, application.ErrBatchAborted):
+		st = status.New(codes.Aborted, err.Error())
+	case errors.Is(err, application.ErrExternalService):
+		st = status.New(codes.Unavailable, application.ErrExternalService.Error())
+	}
+	return &api.Error{
+		Code:    int32(st.Code()),
+		Message: st.Message(),
+	}
+}
+
 func (svc ApplicationService) GetApplicationById(ctx context.Context, req *api.GetApplicationByIdRequest) (*api.Application, error) {
 	if err := req.Validate(); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	app, err := svc.applicationService.GetByID(ctx, req.GetId())
+	if err := requireAdminForDeleted(ctx, req.GetIncludeDeleted()); err != nil {
+		return nil, err
+	}
+
+	app, err := svc.applicationService.GetByID(ctx, &application.GetByIDParams{
+		ID:             req.GetId(),
+		IncludeDeleted: req.GetIncludeDeleted(),
+	})
 	if err != nil {
 		if errors.Is(err, application.ErrInvalidArgument) {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -53,6 +212,10 @@ func (svc ApplicationService) GetApplicationById(ctx context.Context, req *api.G
 		}
 		return nil, StatusInternal.Err()
 	}
+	// existence of applications of other users isn't disclosed
+	if customer, ok := auth.CustomerFromContext(ctx); ok && app.UserID != customer {
+		return nil, status.Error(codes.NotFound, application.ErrApplicationNotFound.Error())
+	}
 	return NewApplication(app), nil
 }
 
@@ -61,10 +224,29 @@ func (svc ApplicationService) GetApplicationsByFilters(ctx context.Context, req
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if err := requireAdminForDeleted(ctx, req.GetIncludeDeleted()); err != nil {
+		return nil, err
+	}
+
 	params, err := ParseGetApplicationsByFiltersRequest(req)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	if customer, ok := auth.CustomerFromContext(ctx); ok {
+		params.UserID = &customer
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	// one more application is requested to know whether there is the next page
+	params.Limit = pageSize + 1
+	if req.GetPageToken() != "" {
+		afterID := req.GetPageToken()
+		params.AfterID = &afterID
+	}
+
 	apps, err := svc.applicationService.GetByFilters(ctx, params)
 	if err != nil {
 		if errors.Is(err, application.ErrInvalidArgument) {
@@ -72,12 +254,20 @@ func (svc ApplicationService) GetApplicationsByFilters(ctx context.Context, req
 		}
 		return nil, StatusInternal.Err()
 	}
-	return NewGetApplicationsByFiltersResponse(apps), nil
+
+	var nextPageToken string
+	if len(apps) > pageSize {
+		apps = apps[:pageSize]
+		nextPageToken = apps[pageSize-1].ID
+	}
+	resp := NewGetApplicationsByFiltersResponse(apps)
+	resp.NextPageToken = nextPageToken
+	return resp, nil
 }
 
 func ParseGetApplicationsByFiltersRequest(req *api.GetApplicationsByFiltersRequest) (*application.GetByFilterParams, error) {
 	var (
-		params = new(application.GetByFilterParams)
+		params = &application.GetByFilterParams{IncludeDeleted: req.GetIncludeDeleted()}
 		err    error
 	)
 
@@ -98,6 +288,23 @@ func ParseGetApplicationsByFiltersRequest(req *api.GetApplicationsByFiltersReque
 		userID := req.GetUserId()
 		params.UserID = &userID
 	}
+	if req.GetReason() != "" {
+		reason := req.GetReason()
+		params.Reason = &reason
+	}
+	if req.GetAssigneeId() != "" {
+		assigneeID := req.GetAssigneeId()
+		params.AssigneeID = &assigneeID
+	}
+	params.Unassigned = req.GetUnassigned()
+	params.Overdue = req.GetOverdue()
+	if req.GetDueBefore() != nil {
+		if err := req.GetDueBefore().CheckValid(); err != nil {
+			return nil, err
+		}
+		dueBefore := req.GetDueBefore().AsTime()
+		params.DueBefore = &dueBefore
+	}
 
 	return params, nil
 }
@@ -123,14 +330,174 @@ func (svc ApplicationService) UpdateApplication(ctx context.Context, req *api.Up
 		if errors.Is(err, application.ErrApplicationNotFound) {
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
+		if errors.Is(err, application.ErrPreconditionFailed) ||
+			errors.Is(err, application.ErrApplicationDeleted) ||
+			errors.Is(err, application.ErrAssigneeRequired) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		return nil, StatusInternal.Err()
 	}
 	return NewApplication(app), nil
 }
 
+func (svc ApplicationService) BulkUpdateApplicationsStatus(ctx context.Context, req *api.BulkUpdateApplicationsStatusRequest) (*api.BulkUpdateApplicationsStatusResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if req.GetFilter().GetPageSize() != 0 || req.GetFilter().GetPageToken() != "" {
+		return nil, status.Error(codes.InvalidArgument, "page_size and page_token of filter can't be used by bulk update")
+	}
+	filter, err := ParseGetApplicationsByFiltersRequest(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	result, err := svc.applicationService.BulkUpdateStatus(ctx, &application.BulkUpdateStatusParams{
+		Filter: filter,
+		Status: ParseApplicationStatus(req.GetStatus()),
+		Reason: application.Reason{
+			Code:    req.GetReason(),
+			Comment: req.GetComment(),
+		},
+		DryRun: req.GetDryRun(),
+	})
+	if err != nil {
+		if errors.Is(err, application.ErrInvalidArgument) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, application.ErrBulkUpdateTooLarge) || errors.Is(err, application.ErrAssigneeRequired) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, StatusInternal.Err()
+	}
+	return &api.BulkUpdateApplicationsStatusResponse{
+		MatchedCount: int64(result.Matched),
+		UpdatedCount: int64(result.Updated),
+		SampleIds:    result.SampleIDs,
+	}, nil
+}
+
+func (svc ApplicationService) DeleteApplication(ctx context.Context, req *api.DeleteApplicationRequest) (*api.Application, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	app, err := svc.applicationService.Delete(ctx, req.GetId())
+	if err != nil {
+		return nil, NewTombstoneError(err)
+	}
+	return NewApplication(app), nil
+}
+
+func (svc ApplicationService) RestoreApplication(ctx context.Context, req *api.RestoreApplicationRequest) (*api.Application, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	app, err := svc.applicationService.Restore(ctx, req.GetId())
+	if err != nil {
+		return nil, NewTombstoneError(err)
+	}
+	return NewApplication(app), nil
+}
+
+func (svc ApplicationService) AssignApplication(ctx context.Context, req *api.AssignApplicationRequest) (*api.Application, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	app, err := svc.applicationService.Assign(ctx, &application.AssignParams{
+		ID:         req.GetId(),
+		AssigneeID: req.GetAssigneeId(),
+	})
+	if err != nil {
+		return nil, NewAssignError(err)
+	}
+	return NewApplication(app), nil
+}
+
+func (svc ApplicationService) UnassignApplication(ctx context.Context, req *api.UnassignApplicationRequest) (*api.Application, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	app, err := svc.applicationService.Unassign(ctx, req.GetId())
+	if err != nil {
+		return nil, NewAssignError(err)
+	}
+	return NewApplication(app), nil
+}
+
+// NewAssignError converts errors of assigning and unassigning of application
+func NewAssignError(err error) error {
+	switch {
+	case errors.Is(err, application.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, application.ErrApplicationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, application.ErrApplicationDeleted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return StatusInternal.Err()
+}
+
+// NewTombstoneError converts errors of deleting and restoring of application
+func NewTombstoneError(err error) error {
+	switch {
+	case errors.Is(err, application.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, application.ErrApplicationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, application.ErrApplicationDeleted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, application.ErrPreconditionFailed):
+		return status.Error(codes.FailedPrecondition, "application isn't deleted")
+	}
+	return StatusInternal.Err()
+}
+
 func ParseUpdateApplicationRequest(req *api.UpdateApplicationRequest) *application.UpdateParams {
-	return &application.UpdateParams{
+	params := &application.UpdateParams{
 		ID:     req.GetId(),
 		Status: ParseApplicationStatus(req.GetStatus()),
+		Reason: application.Reason{
+			Code:    req.GetReason(),
+			Comment: req.GetComment(),
+		},
+	}
+	if req.GetExpectedStatus() != api.Application_APPLICATION_STATUS_UNSPECIFIED {
+		s := ParseApplicationStatus(req.GetExpectedStatus())
+		params.ExpectedStatus = &s
+	}
+	if req.GetExpectedVersion() != nil {
+		v := req.GetExpectedVersion().GetValue()
+		params.ExpectedVersion = &v
+	}
+	return params
+}
+
+// ownUserID checks that customer creates own application, empty user id is replaced by id of customer
+func ownUserID(ctx context.Context, userID *string) error {
+	customer, ok := auth.CustomerFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if *userID == "" {
+		*userID = customer
+	}
+	if *userID != customer {
+		return status.Error(codes.PermissionDenied, "application of another user can't be created")
+	}
+	return nil
+}
+
+// requireAdminForDeleted allows only admins to read deleted applications
+func requireAdminForDeleted(ctx context.Context, includeDeleted bool) error {
+	if !includeDeleted {
+		return nil
+	}
+	if err := auth.RequireRole(ctx, auth.RoleAdmin, "include_deleted"); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
 	}
+	return nil
 }
@@ -0,0 +1,158 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PxyUp/backend_tech_task/internal/api/grpc/services"
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	application_embedded "github.com/PxyUp/backend_tech_task/internal/application/embedded"
+	"github.com/PxyUp/backend_tech_task/internal/auth"
+	"github.com/PxyUp/backend_tech_task/internal/external"
+	external_mock "github.com/PxyUp/backend_tech_task/internal/external/mock"
+	api "github.com/PxyUp/backend_tech_task/pkg/proto"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestApplicationService_CustomerIsolation(t *testing.T) {
+	const (
+		customerID = "603bd5e5967f2dba00c8e325"
+		anotherID  = "603bd5e5967f2dba00c8e326"
+	)
+
+	ctrl := gomock.NewController(t)
+
+	repository, err := application_embedded.NewRepository(application_embedded.InMemory)
+	require.NoError(t, err)
+	defer repository.Close()
+
+	externalClient := external_mock.NewMockClient(ctrl)
+	externalClient.EXPECT().GetExternalStatus(gomock.Any(), gomock.Any()).Return(external.StatusProcessed, nil).AnyTimes()
+
+	svc := services.NewApplicationService(application.NewService(repository, externalClient))
+
+	var (
+		customerCtx = auth.WithPrincipal(context.Background(), &auth.Principal{Subject: customerID, Roles: []string{auth.RoleCustomer}})
+		operatorCtx = auth.WithPrincipal(context.Background(), &auth.Principal{Subject: anotherID, Roles: []string{auth.RoleOperator}})
+	)
+
+	own, err := svc.CreateApplication(customerCtx, &api.CreateApplicationRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, customerID, own.GetUserId())
+
+	_, err = svc.CreateApplication(customerCtx, &api.CreateApplicationRequest{UserId: anotherID})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = svc.CreateApplications(customerCtx, &api.CreateApplicationsRequest{Applications: []*api.CreateApplicationRequest{
+		{UserId: customerID},
+		{UserId: anotherID},
+	}})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	another, err := svc.CreateApplication(operatorCtx, &api.CreateApplicationRequest{UserId: anotherID})
+	require.NoError(t, err)
+
+	got, err := svc.GetApplicationById(customerCtx, &api.GetApplicationByIdRequest{Id: own.GetId()})
+	require.NoError(t, err)
+	assert.Equal(t, own.GetId(), got.GetId())
+
+	_, err = svc.GetApplicationById(customerCtx, &api.GetApplicationByIdRequest{Id: another.GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = svc.GetApplicationById(operatorCtx, &api.GetApplicationByIdRequest{Id: own.GetId()})
+	assert.NoError(t, err)
+
+	found, err := svc.GetApplicationsByFilters(customerCtx, &api.GetApplicationsByFiltersRequest{UserId: anotherID})
+	require.NoError(t, err)
+	require.Len(t, found.GetApplications(), 1)
+	assert.Equal(t, own.GetId(), found.GetApplications()[0].GetId())
+
+	found, err = svc.GetApplicationsByFilters(operatorCtx, &api.GetApplicationsByFiltersRequest{Status: api.Application_APPLICATION_STATUS_OPEN})
+	require.NoError(t, err)
+	assert.Len(t, found.GetApplications(), 2)
+
+	// deleted applications are visible only for admins
+	adminCtx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: anotherID, Roles: []string{auth.RoleAdmin}})
+	_, err = svc.DeleteApplication(adminCtx, &api.DeleteApplicationRequest{Id: own.GetId()})
+	require.NoError(t, err)
+
+	for _, ctx := range []context.Context{customerCtx, operatorCtx} {
+		_, err = svc.GetApplicationById(ctx, &api.GetApplicationByIdRequest{Id: own.GetId(), IncludeDeleted: true})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "role:admin")
+
+		_, err = svc.GetApplicationsByFilters(ctx, &api.GetApplicationsByFiltersRequest{UserId: customerID, IncludeDeleted: true})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	}
+
+	got, err = svc.GetApplicationById(adminCtx, &api.GetApplicationByIdRequest{Id: own.GetId(), IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.Equal(t, own.GetId(), got.GetId())
+
+	found, err = svc.GetApplicationsByFilters(adminCtx, &api.GetApplicationsByFiltersRequest{UserId: customerID, IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.Len(t, found.GetApplications(), 1)
+
+	// principal with unknown role isn't limited to own applications, policy denies it by default
+	serviceCtx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "billing", Roles: []string{"billing"}})
+	found, err = svc.GetApplicationsByFilters(serviceCtx, &api.GetApplicationsByFiltersRequest{Status: api.Application_APPLICATION_STATUS_OPEN})
+	require.NoError(t, err)
+	assert.Len(t, found.GetApplications(), 1)
+}
+
+func TestApplicationService_GetApplicationsByFiltersPages(t *testing.T) {
+	const userID = "603bd5e5967f2dba00c8e325"
+
+	ctrl := gomock.NewController(t)
+
+	repository, err := application_embedded.NewRepository(application_embedded.InMemory)
+	require.NoError(t, err)
+	defer repository.Close()
+
+	externalClient := external_mock.NewMockClient(ctrl)
+	externalClient.EXPECT().GetExternalStatus(gomock.Any(), gomock.Any()).Return(external.StatusProcessed, nil).AnyTimes()
+
+	svc := services.NewApplicationService(application.NewService(repository, externalClient))
+
+	ctx := context.Background()
+	var created []string
+	for i := 0; i < 5; i++ {
+		app, err := svc.CreateApplication(ctx, &api.CreateApplicationRequest{UserId: userID})
+		require.NoError(t, err)
+		created = append(created, app.GetId())
+	}
+
+	var (
+		req   = &api.GetApplicationsByFiltersRequest{UserId: userID, PageSize: 2}
+		found []string
+		pages int
+	)
+	for {
+		resp, err := svc.GetApplicationsByFilters(ctx, req)
+		require.NoError(t, err)
+		pages++
+		for _, app := range resp.GetApplications() {
+			found = append(found, app.GetId())
+		}
+		if resp.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+	assert.Equal(t, 3, pages)
+	assert.ElementsMatch(t, created, found)
+
+	_, err = svc.GetApplicationsByFilters(ctx, &api.GetApplicationsByFiltersRequest{UserId: userID, PageToken: "page"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = svc.BulkUpdateApplicationsStatus(ctx, &api.BulkUpdateApplicationsStatusRequest{
+		Filter: &api.GetApplicationsByFiltersRequest{UserId: userID, PageSize: 2},
+		Status: api.Application_APPLICATION_STATUS_IN_PROGRESS,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
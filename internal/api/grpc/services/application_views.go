@@ -12,14 +12,27 @@ func NewApplication(app *application.Application) *api.Application {
 		return nil
 	}
 
-	return &api.Application{
+	view := &api.Application{
 		Id:             app.ID,
 		Status:         NewApplicationStatus(app.Status),
 		UserId:         app.UserID,
 		CreatedAt:      timestamppb.New(app.CreatedAt),
 		UpdatedAt:      timestamppb.New(app.UpdatedAt),
 		ExternalStatus: NewApplicationExternalStatus(app.ExternalStatus),
+		Version:        app.Version,
+		Reason:         app.Reason.Code,
+		ReasonComment:  app.Reason.Comment,
+		AssigneeId:     app.AssigneeID,
+		Priority:       NewApplicationPriority(app.Priority),
+		Breached:       app.Breached,
 	}
+	if app.DeletedAt != nil {
+		view.DeletedAt = timestamppb.New(*app.DeletedAt)
+	}
+	if app.DueAt != nil {
+		view.DueAt = timestamppb.New(*app.DueAt)
+	}
+	return view
 }
 
 func NewApplicationStatus(status application.Status) api.Application_Status {
@@ -30,6 +43,14 @@ func ParseApplicationStatus(status api.Application_Status) application.Status {
 	return application.Status(status)
 }
 
+func NewApplicationPriority(priority application.Priority) api.Application_Priority {
+	return api.Application_Priority(priority)
+}
+
+func ParseApplicationPriority(priority api.Application_Priority) application.Priority {
+	return application.Priority(priority)
+}
+
 func NewApplicationExternalStatus(status external.Status) api.Application_ExternalStatus {
 	return api.Application_ExternalStatus(status)
 }
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/PxyUp/backend_tech_task/internal/webhook"
+	api "github.com/PxyUp/backend_tech_task/pkg/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type WebhookService struct {
+	webhookService webhook.Service
+}
+
+func NewWebhookService(webhookService webhook.Service) *WebhookService {
+	return &WebhookService{webhookService: webhookService}
+}
+
+func (svc WebhookService) CreateWebhookSubscription(ctx context.Context, req *api.CreateWebhookSubscriptionRequest) (*api.WebhookSubscription, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	subscription, err := svc.webhookService.CreateSubscription(ctx, ParseCreateWebhookSubscriptionRequest(req))
+	if err != nil {
+		return nil, NewWebhookError(err)
+	}
+	return NewWebhookSubscription(subscription), nil
+}
+
+func (svc WebhookService) GetWebhookSubscription(ctx context.Context, req *api.GetWebhookSubscriptionRequest) (*api.WebhookSubscription, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	subscription, err := svc.webhookService.GetSubscription(ctx, req.GetId())
+	if err != nil {
+		return nil, NewWebhookError(err)
+	}
+	return NewWebhookSubscription(subscription), nil
+}
+
+func (svc WebhookService) ListWebhookSubscriptions(ctx context.Context, req *api.ListWebhookSubscriptionsRequest) (*api.ListWebhookSubscriptionsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	subscriptions, err := svc.webhookService.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, NewWebhookError(err)
+	}
+
+	var resp = &api.ListWebhookSubscriptionsResponse{}
+	for i := range subscriptions {
+		resp.Subscriptions = append(resp.Subscriptions, NewWebhookSubscription(&subscriptions[i]))
+	}
+	return resp, nil
+}
+
+func (svc WebhookService) UpdateWebhookSubscription(ctx context.Context, req *api.UpdateWebhookSubscriptionRequest) (*api.WebhookSubscription, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	subscription, err := svc.webhookService.UpdateSubscription(ctx, ParseUpdateWebhookSubscriptionRequest(req))
+	if err != nil {
+		return nil, NewWebhookError(err)
+	}
+	return NewWebhookSubscription(subscription), nil
+}
+
+func (svc WebhookService) DeleteWebhookSubscription(ctx context.Context, req *api.DeleteWebhookSubscriptionRequest) (*api.DeleteWebhookSubscriptionResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := svc.webhookService.DeleteSubscription(ctx, req.GetId()); err != nil {
+		return nil, NewWebhookError(err)
+	}
+	return &api.DeleteWebhookSubscriptionResponse{}, nil
+}
+
+func (svc WebhookService) ListWebhookDeliveries(ctx context.Context, req *api.ListWebhookDeliveriesRequest) (*api.ListWebhookDeliveriesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	deliveries, err := svc.webhookService.ListDeliveries(ctx, req.GetSubscriptionId())
+	if err != nil {
+		return nil, NewWebhookError(err)
+	}
+
+	var resp = &api.ListWebhookDeliveriesResponse{}
+	for i := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, NewWebhookDelivery(&deliveries[i]))
+	}
+	return resp, nil
+}
+
+func (svc WebhookService) RedeliverWebhookDelivery(ctx context.Context, req *api.RedeliverWebhookDeliveryRequest) (*api.WebhookDelivery, error) {
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	delivery, err := svc.webhookService.Redeliver(ctx, req.GetId())
+	if err != nil {
+		return nil, NewWebhookError(err)
+	}
+	return NewWebhookDelivery(delivery), nil
+}
+
+func NewWebhookError(err error) error {
+	switch {
+	case errors.Is(err, webhook.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, webhook.ErrSubscriptionNotFound), errors.Is(err, webhook.ErrDeliveryNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, webhook.ErrDeliveryPending):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return StatusInternal.Err()
+}
+
+func ParseCreateWebhookSubscriptionRequest(req *api.CreateWebhookSubscriptionRequest) *webhook.Subscription {
+	subscription := &webhook.Subscription{
+		URL:        req.GetUrl(),
+		Secret:     req.GetSecret(),
+		EventTypes: req.GetEventTypes(),
+	}
+	if req.GetUserId() != "" {
+		userID := req.GetUserId()
+		subscription.UserID = &userID
+	}
+	if req.GetStatus() != api.Application_APPLICATION_STATUS_UNSPECIFIED {
+		s := ParseApplicationStatus(req.GetStatus())
+		subscription.Status = &s
+	}
+	return subscription
+}
+
+func ParseUpdateWebhookSubscriptionRequest(req *api.UpdateWebhookSubscriptionRequest) *webhook.Subscription {
+	subscription := ParseCreateWebhookSubscriptionRequest(&api.CreateWebhookSubscriptionRequest{
+		Url:        req.GetUrl(),
+		Secret:     req.GetSecret(),
+		EventTypes: req.GetEventTypes(),
+		UserId:     req.GetUserId(),
+		Status:     req.GetStatus(),
+	})
+	subscription.ID = req.GetId()
+	return subscription
+}
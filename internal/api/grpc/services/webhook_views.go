@@ -0,0 +1,54 @@
+package services
+
+import (
+	"github.com/PxyUp/backend_tech_task/internal/webhook"
+	api "github.com/PxyUp/backend_tech_task/pkg/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NewWebhookSubscription returns view of subscription, secret is never returned
+func NewWebhookSubscription(subscription *webhook.Subscription) *api.WebhookSubscription {
+	if subscription == nil {
+		return nil
+	}
+
+	view := &api.WebhookSubscription{
+		Id:         subscription.ID,
+		Url:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		CreatedAt:  timestamppb.New(subscription.CreatedAt),
+		UpdatedAt:  timestamppb.New(subscription.UpdatedAt),
+	}
+	if subscription.UserID != nil {
+		view.UserId = *subscription.UserID
+	}
+	if subscription.Status != nil {
+		view.Status = NewApplicationStatus(*subscription.Status)
+	}
+	return view
+}
+
+func NewWebhookDelivery(delivery *webhook.Delivery) *api.WebhookDelivery {
+	if delivery == nil {
+		return nil
+	}
+
+	view := &api.WebhookDelivery{
+		Id:             delivery.ID,
+		SubscriptionId: delivery.SubscriptionID,
+		EventId:        delivery.EventID,
+		EventType:      delivery.EventType,
+		State:          api.WebhookDelivery_State(delivery.State),
+		NextAttemptAt:  timestamppb.New(delivery.NextAttemptAt),
+		CreatedAt:      timestamppb.New(delivery.CreatedAt),
+		UpdatedAt:      timestamppb.New(delivery.UpdatedAt),
+	}
+	for _, attempt := range delivery.Attempts {
+		view.Attempts = append(view.Attempts, &api.WebhookDelivery_Attempt{
+			At:         timestamppb.New(attempt.At),
+			StatusCode: int32(attempt.StatusCode),
+			Error:      attempt.Error,
+		})
+	}
+	return view
+}
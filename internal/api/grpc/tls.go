@@ -0,0 +1,167 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/auth"
+
+	"github.com/rs/zerolog/log"
+)
+
+type TLSConfig struct {
+	// CertFile and KeyFile enable TLS, they are PEM encoded certificate chain and private key of server
+	CertFile string `envconfig:"cert_file"`
+	KeyFile  string `envconfig:"key_file"`
+	// ClientCAFile is PEM bundle of CA of client certificates, client certificates are verified only if it's set
+	ClientCAFile string `envconfig:"client_ca_file"`
+	// RequireClientCert rejects connections without valid client certificate (mutual TLS)
+	RequireClientCert bool `envconfig:"require_client_cert"`
+	// ReloadInterval is minimal interval of checking files for changes, files are checked on handshake
+	ReloadInterval time.Duration `envconfig:"reload_interval"`
+	// ClientRoles maps identity of client certificate (URI or DNS SAN, or CN) to role, e.g. spiffe://cluster/billing:operator
+	ClientRoles auth.CertificateRoles `envconfig:"client_roles"`
+}
+
+const defaultReloadInterval = 10 * time.Second
+
+func (cfg TLSConfig) Enabled() bool {
+	return cfg.CertFile != "" || cfg.KeyFile != ""
+}
+
+func (cfg TLSConfig) Validate() error {
+	if !cfg.Enabled() {
+		if cfg.ClientCAFile != "" || cfg.RequireClientCert || len(cfg.ClientRoles) > 0 {
+			return fmt.Errorf("client certificates require tls, cert and key files should be set")
+		}
+		return nil
+	}
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return fmt.Errorf("both cert and key files are required by tls")
+	}
+	if (cfg.RequireClientCert || len(cfg.ClientRoles) > 0) && cfg.ClientCAFile == "" {
+		return fmt.Errorf("client certificates can't be verified without client ca file")
+	}
+	return nil
+}
+
+// NewTLSConfig returns server config which reloads certificate and client CA after change of their files,
+// invalid files are logged and previous ones are kept. nextProtos are ALPN protocols of server, e.g. h2 of grpc
+func NewTLSConfig(cfg TLSConfig, nextProtos ...string) (*tls.Config, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.ReloadInterval == 0 {
+		cfg.ReloadInterval = defaultReloadInterval
+	}
+
+	r := &reloader{cfg: cfg, nextProtos: nextProtos, now: time.Now}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return &tls.Config{GetConfigForClient: r.getConfigForClient}, nil
+}
+
+type reloader struct {
+	cfg        TLSConfig
+	nextProtos []string
+	now        func() time.Time
+
+	mu        sync.Mutex
+	config    *tls.Config
+	files     map[string]fileVersion
+	checkedAt time.Time
+}
+
+// fileVersion detects change of file, including replacing of symlink of mounted secret
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+func (r *reloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now := r.now(); now.Sub(r.checkedAt) >= r.cfg.ReloadInterval {
+		r.checkedAt = now
+		if r.changed() {
+			if err := r.load(); err != nil {
+				log.Error().Err(err).Msg("couldn't reload tls certificates, previous ones are used")
+			} else {
+				log.Info().Msg("tls certificates have been reloaded")
+			}
+		}
+	}
+	return r.config, nil
+}
+
+func (r *reloader) changed() bool {
+	for path, version := range r.files {
+		current, err := stat(path)
+		if err != nil || current != version {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *reloader) load() error {
+	var (
+		paths = []string{r.cfg.CertFile, r.cfg.KeyFile}
+		files = make(map[string]fileVersion, 3)
+	)
+	if r.cfg.ClientCAFile != "" {
+		paths = append(paths, r.cfg.ClientCAFile)
+	}
+	// versions are taken before reading, so change made during reading is loaded by the next check
+	for _, path := range paths {
+		version, err := stat(path)
+		if err != nil {
+			return err
+		}
+		files[path] = version
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("couldn't load tls certificate: %w", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   r.nextProtos,
+	}
+
+	if r.cfg.ClientCAFile != "" {
+		data, err := ioutil.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("couldn't read client ca file: %w", err)
+		}
+		config.ClientCAs = x509.NewCertPool()
+		if !config.ClientCAs.AppendCertsFromPEM(data) {
+			return fmt.Errorf("client ca file %s doesn't contain certificates", r.cfg.ClientCAFile)
+		}
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+		if r.cfg.RequireClientCert {
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	r.config = config
+	r.files = files
+	return nil
+}
+
+func stat(path string) (fileVersion, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileVersion{}, err
+	}
+	return fileVersion{modTime: info.ModTime(), size: info.Size()}, nil
+}
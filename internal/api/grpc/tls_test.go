@@ -0,0 +1,117 @@
+package grpc_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	api_grpc "github.com/PxyUp/backend_tech_task/internal/api/grpc"
+	"github.com/PxyUp/backend_tech_task/internal/auth"
+	"github.com/PxyUp/backend_tech_task/internal/util/certtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	var (
+		dir      = t.TempDir()
+		certFile = filepath.Join(dir, "server.crt")
+		keyFile  = filepath.Join(dir, "server.key")
+		caFile   = filepath.Join(dir, "ca.crt")
+	)
+
+	var (
+		ca     = certtest.NewCA(t)
+		server = certtest.NewServer(t, ca, "server")
+		client = certtest.NewClient(t, ca, "billing")
+	)
+	ca.Write(t, caFile, "")
+	server.Write(t, certFile, keyFile)
+
+	tlsConfig, err := api_grpc.NewTLSConfig(api_grpc.TLSConfig{
+		CertFile:          certFile,
+		KeyFile:           keyFile,
+		ClientCAFile:      caFile,
+		RequireClientCert: true,
+		ReloadInterval:    time.Nanosecond,
+	}, "h2")
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+
+	roots := ca.Pool()
+	check := func(t *testing.T, certificates ...tls.Certificate) (*x509.Certificate, error) {
+		t.Helper()
+		var peerCert *x509.Certificate
+		clientConfig := &tls.Config{
+			RootCAs:      roots,
+			ServerName:   "localhost",
+			Certificates: certificates,
+			VerifyPeerCertificate: func(_ [][]byte, chains [][]*x509.Certificate) error {
+				peerCert = chains[0][0]
+				return nil
+			},
+		}
+		conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(clientConfig)))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		return peerCert, err
+	}
+
+	t.Run("client certificate is required", func(t *testing.T) {
+		_, err := check(t)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("mutual tls", func(t *testing.T) {
+		peerCert, err := check(t, client.TLS(t))
+		require.NoError(t, err)
+		assert.Equal(t, "server", peerCert.Subject.CommonName)
+	})
+
+	t.Run("certificate is reloaded", func(t *testing.T) {
+		certtest.NewServer(t, ca, "renewed server").Write(t, certFile, keyFile)
+
+		peerCert, err := check(t, client.TLS(t))
+		require.NoError(t, err)
+		assert.Equal(t, "renewed server", peerCert.Subject.CommonName)
+	})
+
+	t.Run("invalid files keep previous certificate", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(certFile, []byte("broken"), 0600))
+
+		peerCert, err := check(t, client.TLS(t))
+		require.NoError(t, err)
+		assert.Equal(t, "renewed server", peerCert.Subject.CommonName)
+	})
+}
+
+func TestTLSConfig_Validate(t *testing.T) {
+	assert.NoError(t, api_grpc.TLSConfig{}.Validate())
+	assert.NoError(t, api_grpc.TLSConfig{CertFile: "crt", KeyFile: "key"}.Validate())
+	assert.Error(t, api_grpc.TLSConfig{CertFile: "crt"}.Validate())
+	assert.Error(t, api_grpc.TLSConfig{ClientCAFile: "ca"}.Validate())
+	assert.Error(t, api_grpc.TLSConfig{CertFile: "crt", KeyFile: "key", RequireClientCert: true}.Validate())
+	assert.Error(t, api_grpc.TLSConfig{CertFile: "crt", KeyFile: "key", ClientRoles: auth.CertificateRoles{"billing": auth.RoleOperator}}.Validate())
+}
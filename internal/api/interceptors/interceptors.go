@@ -0,0 +1,167 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"runtime/debug"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/util/logutil"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDMetadata is metadata key of request id, it's echoed in response header
+const RequestIDMetadata = "x-request-id"
+
+// maxRequestIDLength limits request id passed by client, longer one is replaced
+const maxRequestIDLength = 128
+
+// Unary returns chain of interceptors of every unary call: request id, access log and panic recovery,
+// extra interceptors are called after them
+func Unary(extra ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return ChainUnary(append([]grpc.UnaryServerInterceptor{RequestID, AccessLog, Recovery}, extra...)...)
+}
+
+// Stream is the same chain as Unary for streams
+func Stream(extra ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return ChainStream(append([]grpc.StreamServerInterceptor{StreamRequestID, StreamAccessLog, StreamRecovery}, extra...)...)
+}
+
+// ChainUnary calls interceptors in order, the first one is the outermost
+func ChainUnary(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var next = handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+// ChainStream calls interceptors in order, the first one is the outermost
+func ChainStream(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		var next = handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(srv interface{}, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, inner)
+			}
+		}
+		return next(srv, ss)
+	}
+}
+
+// NewRequestID returns random request id
+func NewRequestID() string {
+	var b = make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		log.Err(err).Msg("couldn't generate request id")
+	}
+	return hex.EncodeToString(b)
+}
+
+// RequestID takes request id from metadata or generates new one, echoes it in header
+// and puts logger with the id into context
+func RequestID(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, id := requestContext(ctx, info.FullMethod)
+	// header can't be set outside of grpc transport, e.g. in gateway, which sets header itself
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadata, id))
+	return handler(ctx, req)
+}
+
+func StreamRequestID(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, id := requestContext(ss.Context(), info.FullMethod)
+	if err := ss.SetHeader(metadata.Pairs(RequestIDMetadata, id)); err != nil {
+		logutil.FromContext(ctx).Err(err).Msg("couldn't set request id header")
+	}
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+func requestContext(ctx context.Context, method string) (context.Context, string) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDMetadata); len(values) > 0 && len(values[0]) <= maxRequestIDLength {
+			id = values[0]
+		}
+	}
+	if id == "" {
+		id = NewRequestID()
+	}
+
+	logger := logutil.FromContext(ctx).With().Str("request_id", id).Str("method", method).Logger()
+	return logutil.WithLogger(ctx, logger), id
+}
+
+// AccessLog logs method, code and latency of every call
+func AccessLog(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logAccess(ctx, err, time.Since(start))
+	return resp, err
+}
+
+func StreamAccessLog(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	logAccess(ss.Context(), err, time.Since(start))
+	return err
+}
+
+func logAccess(ctx context.Context, err error, latency time.Duration) {
+	var (
+		code  = status.Code(err)
+		event *zerolog.Event
+	)
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		event = logutil.FromContext(ctx).Error()
+	default:
+		event = logutil.FromContext(ctx).Info()
+	}
+	event.Str("code", code.String()).Dur("latency", latency).Msg("request has been handled")
+}
+
+// Recovery converts panic of handler to Internal status
+func Recovery(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ctx, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func StreamRecovery(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ss.Context(), r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+func recovered(ctx context.Context, r interface{}) error {
+	logutil.FromContext(ctx).Error().Interface("panic", r).Str("stack", string(debug.Stack())).Msg("handler has panicked")
+	return status.Error(codes.Internal, "internal server error")
+}
+
+// serverStream replaces context of stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,96 @@
+package interceptors_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/PxyUp/backend_tech_task/internal/api/interceptors"
+	"github.com/PxyUp/backend_tech_task/internal/util/logutil"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestUnary(t *testing.T) {
+	var (
+		buf  bytes.Buffer
+		info = &grpc.UnaryServerInfo{FullMethod: "/api.ApplicationService/GetApplicationById"}
+		ctx  = metadata.NewIncomingContext(
+			logutil.WithLogger(context.Background(), zerolog.New(&buf)),
+			metadata.Pairs(interceptors.RequestIDMetadata, "request-1"),
+		)
+	)
+
+	_, err := interceptors.Unary()(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		logutil.FromContext(ctx).Info().Msg("handler")
+		panic("unexpected")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		lines = append(lines, entry)
+	}
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		assert.Equal(t, "request-1", line["request_id"])
+		assert.Equal(t, info.FullMethod, line["method"])
+	}
+	assert.Equal(t, "handler", lines[0]["message"])
+	assert.Equal(t, "unexpected", lines[1]["panic"])
+	assert.Equal(t, "Internal", lines[2]["code"])
+	assert.Contains(t, lines[2], "latency")
+}
+
+func TestUnary_Header(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.UnaryInterceptor(interceptors.Unary()))
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := grpc_health_v1.NewHealthClient(conn)
+	for _, requestID := range []string{"request-1", ""} {
+		var (
+			header metadata.MD
+			ctx    = context.Background()
+		)
+		if requestID != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, interceptors.RequestIDMetadata, requestID)
+		}
+
+		_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.Header(&header))
+		require.NoError(t, err)
+
+		// request id is generated if client doesn't pass it
+		values := header.Get(interceptors.RequestIDMetadata)
+		require.Len(t, values, 1)
+		if requestID != "" {
+			assert.Equal(t, requestID, values[0])
+		} else {
+			assert.Len(t, values[0], 32)
+		}
+	}
+}
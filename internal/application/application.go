@@ -14,6 +14,31 @@ type Application struct {
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 	ExternalStatus external.Status
+	// Version is incremented on every update, it's used for optimistic concurrency
+	Version int64
+	// PreviousStatus is status before the last update
+	PreviousStatus Status
+	// DeletedAt is tombstone of soft deleted application, it's nil for alive ones
+	DeletedAt *time.Time
+	// Reason explains the last status update, it's empty if update hasn't had reason
+	Reason Reason
+	// AssigneeID is id of operator handling application, it's empty for unassigned one
+	AssigneeID string
+	Priority   Priority
+	// DueAt is deadline of current status by SLA policy, it's nil if status doesn't have SLA
+	DueAt *time.Time
+	// Breached is set by SLA scanner when application hasn't left status before DueAt
+	Breached bool
+}
+
+// Reason is code from configured list of reasons and free text comment
+type Reason struct {
+	Code    string
+	Comment string
+}
+
+func (a Application) Deleted() bool {
+	return a.DeletedAt != nil
 }
 
 type Status int32
@@ -54,3 +79,55 @@ const (
 	StatusInProgress
 	StatusClosed
 )
+
+type Priority int32
+
+var ErrInvalidPriority = fmt.Errorf("invalid priority")
+
+func NewPriority(val int32) Priority {
+	return Priority(val)
+}
+
+// ParsePriority parses name of priority returned by String
+func ParsePriority(name string) (Priority, error) {
+	for _, p := range []Priority{PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent} {
+		if p.String() == name {
+			return p, nil
+		}
+	}
+	return PriorityUnspecified, fmt.Errorf("%w: %q", ErrInvalidPriority, name)
+}
+
+func (p Priority) Validate() error {
+	switch p {
+	case PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent:
+		return nil
+	}
+	return ErrInvalidPriority
+}
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	case PriorityUrgent:
+		return "urgent"
+	}
+	return "unspecified"
+}
+
+func (p Priority) Int32() int32 {
+	return int32(p)
+}
+
+const (
+	PriorityUnspecified Priority = iota
+	PriorityLow
+	PriorityNormal
+	PriorityHigh
+	PriorityUrgent
+)
@@ -0,0 +1,388 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBatchAborted is error of item which hasn't been created because another item of batch has failed
+var ErrBatchAborted = fmt.Errorf("batch is aborted")
+
+type BatchMode int32
+
+const (
+	// BatchModeAllOrNothing creates applications only if every item succeeds
+	BatchModeAllOrNothing BatchMode = iota
+	// BatchModeBestEffort creates every item which succeeds
+	BatchModeBestEffort
+)
+
+const (
+	MaxBatchSize = 1000
+
+	defaultBatchConcurrency = 16
+)
+
+type CreateManyParams struct {
+	UserIDs []string
+	// Priorities are optional priorities of applications in order of UserIDs
+	Priorities []Priority
+	Mode       BatchMode
+}
+
+func (p CreateManyParams) Validate() error {
+	if len(p.UserIDs) == 0 || len(p.UserIDs) > MaxBatchSize {
+		return fmt.Errorf("%w: batch should contain from 1 to %d items", ErrInvalidArgument, MaxBatchSize)
+	}
+	if len(p.Priorities) != 0 && len(p.Priorities) != len(p.UserIDs) {
+		return fmt.Errorf("%w: priorities should be set for every item or for none", ErrInvalidArgument)
+	}
+	if p.Mode != BatchModeAllOrNothing && p.Mode != BatchModeBestEffort {
+		return fmt.Errorf("%w: unknown batch mode %d", ErrInvalidArgument, p.Mode)
+	}
+	return nil
+}
+
+// CreateResult is result of item of batch, it has either application or error
+type CreateResult struct {
+	Application *Application
+	Err         error
+}
+
+// WithBatchConcurrency limits number of concurrent requests to external service made by batch
+func WithBatchConcurrency(n int) ServiceOption {
+	return func(svc *service) {
+		if n > 0 {
+			svc.batchConcurrency = n
+		}
+	}
+}
+
+func (svc service) CreateMany(ctx context.Context, params *CreateManyParams) ([]CreateResult, error) {
+	logger(ctx).Info().Int("count", len(params.UserIDs)).Int32("mode", int32(params.Mode)).Msg("try to create applications")
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		results = make([]CreateResult, len(params.UserIDs))
+		now     = time.Now().UTC()
+	)
+	for i, userID := range params.UserIDs {
+		if err := validateObjectID(userID, "user_id"); err != nil {
+			results[i].Err = err
+			continue
+		}
+		var priority = PriorityUnspecified
+		if len(params.Priorities) != 0 {
+			priority = params.Priorities[i]
+		}
+		if err := validatePriority(priority); err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Application = svc.newApplication(userID, priority, now)
+	}
+	if svc.abortOnFailure(params.Mode, results) {
+		return results, nil
+	}
+
+	svc.resolveExternalStatuses(ctx, results)
+	if svc.abortOnFailure(params.Mode, results) {
+		return results, nil
+	}
+
+	var (
+		apps []*Application
+		// indexes are positions of apps in results
+		indexes []int
+	)
+	for i, result := range results {
+		if result.Err == nil {
+			apps = append(apps, result.Application)
+			indexes = append(indexes, i)
+		}
+	}
+	if len(apps) == 0 {
+		return results, nil
+	}
+
+	unlock := svc.lockUsers(apps...)
+	defer unlock()
+
+	var (
+		itemErrs []error
+		// ruled are applications moved by rules, apps aren't changed in transaction because it could be retried
+		ruled map[int]*Application
+	)
+	err := svc.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		ruled = make(map[int]*Application)
+		if itemErrs, err = svc.createWithinQuota(ctx, params.Mode, apps, now); err != nil {
+			return err
+		}
+
+		for i, app := range apps {
+			if itemErrs[i] != nil {
+				if params.Mode == BatchModeAllOrNothing {
+					// rolls back transaction
+					return ErrBatchAborted
+				}
+				continue
+			}
+			if err := svc.appendEvent(ctx, EventApplicationCreated, app); err != nil {
+				return err
+			}
+			updated, err := svc.applyRules(ctx, app)
+			if err != nil {
+				return err
+			}
+			if updated != nil {
+				ruled[i] = updated
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, ErrBatchAborted) {
+		logger(ctx).Err(err).Msg("couldn't save applications")
+		for _, i := range indexes {
+			results[i].Err = ErrRepository
+		}
+		return svc.clearFailed(results), nil
+	}
+
+	for i, itemErr := range itemErrs {
+		if itemErr != nil {
+			results[indexes[i]].Err = createError(itemErr)
+		}
+	}
+	if err == nil {
+		for i, app := range ruled {
+			*apps[i] = *app
+		}
+	}
+	svc.abortOnFailure(params.Mode, results)
+
+	logger(ctx).Info().Msg("applications have been created")
+	return svc.clearFailed(results), nil
+}
+
+// createWithinQuota creates applications which don't exceed quota and returns error of every item
+func (svc service) createWithinQuota(ctx context.Context, mode BatchMode, apps []*Application, now time.Time) ([]error, error) {
+	itemErrs, err := svc.checkQuota(ctx, apps, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		allowed []*Application
+		// indexes are positions of allowed in apps
+		indexes []int
+	)
+	for i, app := range apps {
+		if itemErrs[i] == nil {
+			allowed = append(allowed, app)
+			indexes = append(indexes, i)
+		}
+	}
+	if len(allowed) == 0 || (mode == BatchModeAllOrNothing && len(allowed) != len(apps)) {
+		return itemErrs, nil
+	}
+
+	createErrs, err := svc.repository.CreateMany(ctx, allowed)
+	if err != nil {
+		return nil, err
+	}
+	for i, createErr := range createErrs {
+		itemErrs[indexes[i]] = createErr
+	}
+	return itemErrs, nil
+}
+
+// resolveExternalStatuses gets external statuses of applications concurrently
+func (svc service) resolveExternalStatuses(ctx context.Context, results []CreateResult) {
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, svc.batchConcurrency)
+	)
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(result *CreateResult) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			externalStatus, err := svc.externalClient.GetExternalStatus(ctx, result.Application.ID)
+			if err != nil {
+				logger(ctx).Err(err).Str("id", result.Application.ID).Msg("couldn't get external status")
+				result.Err = fmt.Errorf("%w: %s", ErrExternalService, err.Error())
+				return
+			}
+			result.Application.ExternalStatus = externalStatus
+		}(&results[i])
+	}
+	wg.Wait()
+}
+
+// abortOnFailure marks succeeded items as aborted if batch can't be applied partially
+func (svc service) abortOnFailure(mode BatchMode, results []CreateResult) bool {
+	if mode != BatchModeAllOrNothing {
+		return false
+	}
+
+	var failed bool
+	for _, result := range results {
+		if result.Err != nil && !errors.Is(result.Err, ErrBatchAborted) {
+			failed = true
+			break
+		}
+	}
+	if !failed {
+		return false
+	}
+
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Err = ErrBatchAborted
+		}
+	}
+	svc.clearFailed(results)
+	return true
+}
+
+// clearFailed removes applications from failed items, so every item has either application or error
+func (svc service) clearFailed(results []CreateResult) []CreateResult {
+	for i := range results {
+		if results[i].Err != nil {
+			results[i].Application = nil
+		}
+	}
+	return results
+}
+
+func createError(err error) error {
+	if errors.Is(err, ErrApplicationAlreadyExists) || isQuotaError(err) {
+		return err
+	}
+	return ErrRepository
+}
+
+const (
+	// MaxBulkUpdateSize limits number of applications changed by one bulk update
+	MaxBulkUpdateSize = 10000
+	// bulkUpdateSampleSize is number of ids returned by bulk update
+	bulkUpdateSampleSize = 10
+)
+
+var ErrBulkUpdateTooLarge = fmt.Errorf("too many applications match filter")
+
+type BulkUpdateStatusParams struct {
+	Filter *GetByFilterParams
+	Status Status
+	Reason Reason
+	// DryRun only counts applications which would be changed
+	DryRun bool
+}
+
+func (p BulkUpdateStatusParams) Validate() error {
+	if p.Filter == nil {
+		return fmt.Errorf("%w: filter is required", ErrInvalidArgument)
+	}
+	if err := p.Filter.Validate(); err != nil {
+		return err
+	}
+	if err := p.Status.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidArgument, err.Error())
+	}
+	return nil
+}
+
+type BulkUpdateStatusResult struct {
+	// Matched is number of applications which match filter and don't have target status
+	Matched int
+	// Updated is number of changed applications, it's 0 for dry run
+	Updated int
+	// SampleIDs are ids of some matched applications
+	SampleIDs []string
+}
+
+func (svc service) BulkUpdateStatus(ctx context.Context, params *BulkUpdateStatusParams) (*BulkUpdateStatusResult, error) {
+	logger(ctx).Info().Interface("params", params).Msg("try to update status of applications")
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if err := svc.reasons.check(params.Status, params.Reason); err != nil {
+		return nil, err
+	}
+
+	// deleted applications can't be updated
+	var filter = *params.Filter
+	filter.IncludeDeleted = false
+	// one more application is found to reject too broad filter without loading all matched applications
+	filter.Limit = MaxBulkUpdateSize + 1
+
+	apps, err := svc.repository.FindByFilters(ctx, &filter)
+	if err != nil {
+		logger(ctx).Err(err).Msg("couldn't find applications")
+		return nil, ErrRepository
+	}
+	if len(apps) > MaxBulkUpdateSize {
+		return nil, fmt.Errorf("%w: more than %d applications", ErrBulkUpdateTooLarge, MaxBulkUpdateSize)
+	}
+
+	var ids []string
+	for _, app := range apps {
+		if app.Status == params.Status {
+			continue
+		}
+		if err := (UpdateParams{ID: app.ID, Status: params.Status, Reason: params.Reason}).Validate(); err != nil {
+			return nil, err
+		}
+		if svc.assigneeRequired(params.Status) && app.AssigneeID == "" {
+			return nil, fmt.Errorf("%w: application %s", ErrAssigneeRequired, app.ID)
+		}
+		ids = append(ids, app.ID)
+	}
+
+	var result = &BulkUpdateStatusResult{Matched: len(ids)}
+	if len(ids) > bulkUpdateSampleSize {
+		result.SampleIDs = ids[:bulkUpdateSampleSize]
+	} else {
+		result.SampleIDs = ids
+	}
+	if params.DryRun || len(ids) == 0 {
+		return result, nil
+	}
+
+	if err := svc.WithinTransaction(ctx, func(ctx context.Context) error {
+		updated, err := svc.repository.UpdateStatusMany(ctx, ids, params.Status, params.Reason, svc.deadlines[params.Status])
+		if err != nil {
+			return err
+		}
+		result.Updated = len(updated)
+
+		for i := range updated {
+			if err := svc.appendEvent(ctx, EventApplicationStatusChanged, &updated[i]); err != nil {
+				return err
+			}
+			if _, err := svc.applyRules(ctx, &updated[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		logger(ctx).Err(err).Msg("couldn't update status of applications")
+		return nil, ErrRepository
+	}
+
+	logger(ctx).Info().Int("count", result.Updated).Msg("status of applications has been updated")
+	return result, nil
+}
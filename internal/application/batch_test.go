@@ -0,0 +1,216 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	application_embedded "github.com/PxyUp/backend_tech_task/internal/application/embedded"
+	application_mock "github.com/PxyUp/backend_tech_task/internal/application/mock"
+	"github.com/PxyUp/backend_tech_task/internal/external"
+	external_mock "github.com/PxyUp/backend_tech_task/internal/external/mock"
+	outbox_memory "github.com/PxyUp/backend_tech_task/internal/outbox/memory"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_CreateMany(t *testing.T) {
+	var (
+		userID  = "603bd5e5967f2dba00c8e325"
+		invalid = "invalid"
+	)
+
+	var cases = map[string]struct {
+		Params *application.CreateManyParams
+
+		External_Error error
+		External_Times int
+
+		ExpErrors []error
+		ExpError  error
+	}{
+		"success": {
+			Params:         &application.CreateManyParams{UserIDs: []string{userID, userID, userID}},
+			External_Times: 3,
+			ExpErrors:      []error{nil, nil, nil},
+		},
+		"all_or_nothing_invalid_user": {
+			Params:    &application.CreateManyParams{UserIDs: []string{userID, invalid}},
+			ExpErrors: []error{application.ErrBatchAborted, application.ErrInvalidArgument},
+		},
+		"all_or_nothing_external": {
+			Params:         &application.CreateManyParams{UserIDs: []string{userID, userID}},
+			External_Error: fmt.Errorf("timeout"),
+			External_Times: 2,
+			ExpErrors:      []error{application.ErrExternalService, application.ErrExternalService},
+		},
+		"best_effort_invalid_user": {
+			Params: &application.CreateManyParams{
+				UserIDs: []string{userID, invalid, userID},
+				Mode:    application.BatchModeBestEffort,
+			},
+			External_Times: 2,
+			ExpErrors:      []error{nil, application.ErrInvalidArgument, nil},
+		},
+		"failed_empty": {
+			Params:   &application.CreateManyParams{},
+			ExpError: application.ErrInvalidArgument,
+		},
+		"failed_mode": {
+			Params:   &application.CreateManyParams{UserIDs: []string{userID}, Mode: 10},
+			ExpError: application.ErrInvalidArgument,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repository, err := application_embedded.NewRepository(application_embedded.InMemory)
+			require.NoError(t, err)
+			defer repository.Close()
+
+			externalClient := external_mock.NewMockClient(ctrl)
+			externalClient.
+				EXPECT().
+				GetExternalStatus(gomock.Any(), gomock.Any()).
+				Return(external.StatusProcessed, c.External_Error).
+				Times(c.External_Times)
+
+			svc := application.NewService(repository, externalClient, application.WithBatchConcurrency(2))
+
+			results, err := svc.CreateMany(context.Background(), c.Params)
+			if c.ExpError != nil {
+				assert.True(t, errors.Is(err, c.ExpError), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, results, len(c.ExpErrors))
+
+			var created int
+			for i, result := range results {
+				if c.ExpErrors[i] == nil {
+					require.NoError(t, result.Err)
+					assert.Equal(t, external.StatusProcessed, result.Application.ExternalStatus)
+					created++
+					continue
+				}
+				assert.True(t, errors.Is(result.Err, c.ExpErrors[i]), "unexpected error of item %d: %v", i, result.Err)
+				assert.Nil(t, result.Application)
+			}
+
+			apps, err := repository.FindAll(context.Background())
+			require.NoError(t, err)
+			assert.Len(t, apps, created)
+		})
+	}
+}
+
+func TestService_BulkUpdateStatus(t *testing.T) {
+	var (
+		userID    = "603bd5e5967f2dba00c8e325"
+		other     = "603bd5e5967f2dba00c8e326"
+		closed    = application.StatusClosed
+		duplicate = application.Reason{Code: "duplicate"}
+	)
+
+	var cases = map[string]struct {
+		Params *application.BulkUpdateStatusParams
+
+		ExpMatched int
+		ExpUpdated int
+		ExpError   error
+	}{
+		"success": {
+			Params:     &application.BulkUpdateStatusParams{Filter: &application.GetByFilterParams{UserID: &userID}, Status: closed, Reason: duplicate},
+			ExpMatched: 2,
+			ExpUpdated: 2,
+		},
+		"success_dry_run": {
+			Params:     &application.BulkUpdateStatusParams{Filter: &application.GetByFilterParams{UserID: &userID}, Status: closed, Reason: duplicate, DryRun: true},
+			ExpMatched: 2,
+		},
+		"success_nothing_matched": {
+			Params: &application.BulkUpdateStatusParams{Filter: &application.GetByFilterParams{Status: &closed}, Status: closed, Reason: duplicate},
+		},
+		"failed_empty_filter": {
+			Params:   &application.BulkUpdateStatusParams{Filter: &application.GetByFilterParams{}, Status: closed, Reason: duplicate},
+			ExpError: application.ErrInvalidArgument,
+		},
+		"failed_status": {
+			Params:   &application.BulkUpdateStatusParams{Filter: &application.GetByFilterParams{UserID: &userID}},
+			ExpError: application.ErrInvalidArgument,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+
+			repository, err := application_embedded.NewRepository(application_embedded.InMemory)
+			require.NoError(t, err)
+			defer repository.Close()
+
+			for _, app := range []*application.Application{
+				{ID: "603bd5e5967f2dba00c8e401", UserID: userID, Status: application.StatusOpen},
+				{ID: "603bd5e5967f2dba00c8e402", UserID: userID, Status: application.StatusInProgress},
+				{ID: "603bd5e5967f2dba00c8e403", UserID: other, Status: application.StatusOpen},
+			} {
+				require.NoError(t, repository.Create(ctx, app))
+			}
+
+			outboxStore := outbox_memory.NewStore()
+			svc := application.NewService(repository, external_mock.NewMockClient(ctrl), application.WithOutbox(outboxStore))
+
+			result, err := svc.BulkUpdateStatus(ctx, c.Params)
+			if c.ExpError != nil {
+				assert.True(t, errors.Is(err, c.ExpError), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.ExpMatched, result.Matched)
+			assert.Equal(t, c.ExpUpdated, result.Updated)
+			assert.Len(t, result.SampleIDs, c.ExpMatched)
+
+			apps, err := repository.FindByFilters(ctx, &application.GetByFilterParams{Status: &closed})
+			require.NoError(t, err)
+			assert.Len(t, apps, c.ExpUpdated)
+
+			events, err := outboxStore.Pending(ctx, 0)
+			require.NoError(t, err)
+			assert.Len(t, events, c.ExpUpdated)
+		})
+	}
+}
+
+func TestService_BulkUpdateStatus_TooLarge(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		userID = "603bd5e5967f2dba00c8e325"
+	)
+
+	ctrl := gomock.NewController(t)
+
+	// repository is asked for one application more than limit, so broad filter isn't loaded
+	repository := application_mock.NewMockRepository(ctrl)
+	repository.EXPECT().FindByFilters(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, filter *application.GetByFilterParams) ([]application.Application, error) {
+			assert.Equal(t, application.MaxBulkUpdateSize+1, filter.Limit)
+			return make([]application.Application, filter.Limit), nil
+		},
+	)
+
+	svc := application.NewService(repository, external_mock.NewMockClient(ctrl))
+	_, err := svc.BulkUpdateStatus(ctx, &application.BulkUpdateStatusParams{
+		Filter: &application.GetByFilterParams{UserID: &userID},
+		Status: application.StatusClosed,
+		Reason: application.Reason{Code: "duplicate"},
+		DryRun: true,
+	})
+	assert.True(t, errors.Is(err, application.ErrBulkUpdateTooLarge), "unexpected error: %v", err)
+}
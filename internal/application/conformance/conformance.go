@@ -0,0 +1,782 @@
+package application_conformance
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	"github.com/PxyUp/backend_tech_task/internal/external"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RunRepositoryTests checks that repository follows behaviour expected from application.Repository,
+// every implementation should pass it. newRepository should return empty repository on every call.
+func RunRepositoryTests(t *testing.T, newRepository func() application.Repository) {
+	t.Run("Create", func(t *testing.T) { testCreate(t, newRepository()) })
+	t.Run("CreateMany", func(t *testing.T) { testCreateMany(t, newRepository()) })
+	t.Run("FindByID", func(t *testing.T) { testFindByID(t, newRepository()) })
+	t.Run("FindAll", func(t *testing.T) { testFindAll(t, newRepository()) })
+	t.Run("FindByFilters", func(t *testing.T) { testFindByFilters(t, newRepository()) })
+	t.Run("Update", func(t *testing.T) { testUpdate(t, newRepository()) })
+	t.Run("ConcurrentUpdate", func(t *testing.T) { testConcurrentUpdate(t, newRepository()) })
+	t.Run("UpdateStatusMany", func(t *testing.T) { testUpdateStatusMany(t, newRepository()) })
+	t.Run("DeleteAndRestore", func(t *testing.T) { testDeleteAndRestore(t, newRepository()) })
+	t.Run("Purge", func(t *testing.T) { testPurge(t, newRepository()) })
+	t.Run("CountByUser", func(t *testing.T) { testCountByUser(t, newRepository()) })
+	t.Run("Assign", func(t *testing.T) { testAssign(t, newRepository()) })
+	t.Run("SLA", func(t *testing.T) { testSLA(t, newRepository()) })
+}
+
+// base is start of time used by tests, repositories keep time with milliseconds precision
+var base = time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+func newApplication(userID string, status application.Status, createdAt, updatedAt time.Time) *application.Application {
+	return &application.Application{
+		ID:             primitive.NewObjectID().Hex(),
+		Status:         status,
+		UserID:         userID,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+		ExternalStatus: external.StatusProcessed,
+	}
+}
+
+func ids(apps []application.Application) []string {
+	var res = make([]string, 0, len(apps))
+	for _, app := range apps {
+		res = append(res, app.ID)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func testCreate(t *testing.T, repository application.Repository) {
+	ctx := context.Background()
+
+	app := newApplication(primitive.NewObjectID().Hex(), application.StatusOpen, base.Add(123*time.Millisecond), time.Time{})
+	require.NoError(t, repository.Create(ctx, app))
+
+	found, err := repository.FindByID(ctx, app.ID)
+	require.NoError(t, err)
+	assert.Equal(t, app, found)
+
+	duplicate := *app
+	duplicate.Status = application.StatusClosed
+	err = repository.Create(ctx, &duplicate)
+	assert.True(t, errors.Is(err, application.ErrApplicationAlreadyExists), "unexpected error: %v", err)
+
+	found, err = repository.FindByID(ctx, app.ID)
+	require.NoError(t, err)
+	assert.Equal(t, application.StatusOpen, found.Status)
+}
+
+func testCreateMany(t *testing.T, repository application.Repository) {
+	ctx := context.Background()
+
+	existing := newApplication(primitive.NewObjectID().Hex(), application.StatusOpen, base, time.Time{})
+	require.NoError(t, repository.Create(ctx, existing))
+
+	duplicate := *existing
+	duplicate.Status = application.StatusClosed
+
+	var apps = []*application.Application{
+		newApplication(existing.UserID, application.StatusOpen, base.Add(time.Minute), time.Time{}),
+		&duplicate,
+		newApplication(primitive.NewObjectID().Hex(), application.StatusOpen, base.Add(2*time.Minute), time.Time{}),
+	}
+
+	errs, err := repository.CreateMany(ctx, apps)
+	require.NoError(t, err)
+	require.Len(t, errs, len(apps))
+	assert.NoError(t, errs[0])
+	assert.True(t, errors.Is(errs[1], application.ErrApplicationAlreadyExists), "unexpected error: %v", errs[1])
+	assert.NoError(t, errs[2])
+
+	for _, app := range []*application.Application{apps[0], existing, apps[2]} {
+		found, err := repository.FindByID(ctx, app.ID)
+		require.NoError(t, err)
+		assert.Equal(t, app, found)
+	}
+}
+
+func testFindByID(t *testing.T, repository application.Repository) {
+	ctx := context.Background()
+
+	first := newApplication(primitive.NewObjectID().Hex(), application.StatusOpen, base, base)
+	second := newApplication(first.UserID, application.StatusInProgress, base.Add(time.Hour), base.Add(2*time.Hour))
+	require.NoError(t, repository.Create(ctx, first))
+	require.NoError(t, repository.Create(ctx, second))
+
+	found, err := repository.FindByID(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, second, found)
+
+	_, err = repository.FindByID(ctx, primitive.NewObjectID().Hex())
+	assert.True(t, errors.Is(err, application.ErrApplicationNotFound), "unexpected error: %v", err)
+}
+
+func testFindAll(t *testing.T, repository application.Repository) {
+	ctx := context.Background()
+
+	apps, err := repository.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, apps)
+
+	var created []application.Application
+	for i := 0; i < 5; i++ {
+		app := newApplication(primitive.NewObjectID().Hex(), application.StatusOpen, base.Add(time.Duration(i)*time.Minute), time.Time{})
+		require.NoError(t, repository.Create(ctx, app))
+		created = append(created, *app)
+	}
+
+	apps, err = repository.FindAll(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, created, apps)
+}
+
+func testFindByFilters(t *testing.T, repository application.Repository) {
+	ctx := context.Background()
+
+	var (
+		firstUser  = primitive.NewObjectID().Hex()
+		secondUser = primitive.NewObjectID().Hex()
+		users      = []string{firstUser, secondUser}
+		statuses   = []application.Status{application.StatusOpen, application.StatusInProgress, application.StatusClosed}
+		apps       []application.Application
+	)
+
+	// every user has application in every status created and updated in different hours
+	for i := 0; i < 12; i++ {
+		var (
+			createdAt = base.Add(time.Duration(i) * time.Hour)
+			updatedAt = createdAt.Add(time.Duration(12-i) * time.Hour)
+		)
+		app := newApplication(users[i%len(users)], statuses[i%len(statuses)], createdAt, updatedAt)
+		require.NoError(t, repository.Create(ctx, app))
+		apps = append(apps, *app)
+	}
+
+	var (
+		status    = application.StatusInProgress
+		createdAt = &application.TimeRange{Start: base.Add(2 * time.Hour), End: base.Add(8 * time.Hour)}
+		updatedAt = &application.TimeRange{Start: base.Add(12 * time.Hour), End: base.Add(16 * time.Hour)}
+	)
+
+	// every combination of filters is checked against expected result calculated here
+	for mask := 1; mask < 1<<4; mask++ {
+		var params = new(application.GetByFilterParams)
+		if mask&1 != 0 {
+			params.Status = &status
+		}
+		if mask&2 != 0 {
+			params.UserID = &firstUser
+		}
+		if mask&4 != 0 {
+			params.CreatedAt = createdAt
+		}
+		if mask&8 != 0 {
+			params.UpdatedAt = updatedAt
+		}
+
+		var expected []application.Application
+		for _, app := range apps {
+			if match(params, app) {
+				expected = append(expected, app)
+			}
+		}
+
+		found, err := repository.FindByFilters(ctx, params)
+		require.NoError(t, err)
+		assert.Equal(t, ids(expected), ids(found), "filters mask %04b", mask)
+		assert.ElementsMatch(t, expected, found, "filters mask %04b", mask)
+	}
+
+	t.Run("time_range_boundaries", func(t *testing.T) {
+		// start is inclusive, end is exclusive
+		found, err := repository.FindByFilters(ctx, &application.GetByFilterParams{
+			CreatedAt: &application.TimeRange{Start: base.Add(3 * time.Hour), End: base.Add(4 * time.Hour)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, ids(apps[3:4]), ids(found))
+
+		found, err = repository.FindByFilters(ctx, &application.GetByFilterParams{
+			CreatedAt: &application.TimeRange{Start: base.Add(3*time.Hour + time.Millisecond), End: base.Add(4*time.Hour + time.Millisecond)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, ids(apps[4:5]), ids(found))
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		// pages are ordered by id and don't overlap, user has 6 applications
+		var (
+			params = &application.GetByFilterParams{UserID: &firstUser, Limit: 4}
+			found  []string
+		)
+		for _, size := range []int{4, 2, 0} {
+			apps, err := repository.FindByFilters(ctx, params)
+			require.NoError(t, err)
+			require.Len(t, apps, size)
+			for _, app := range apps {
+				found = append(found, app.ID)
+			}
+			if len(apps) != 0 {
+				params.AfterID = &apps[len(apps)-1].ID
+			}
+		}
+		assert.True(t, sort.StringsAreSorted(found), found)
+
+		var expected []application.Application
+		for _, app := range apps {
+			if app.UserID == firstUser {
+				expected = append(expected, app)
+			}
+		}
+		assert.Equal(t, ids(expected), found)
+	})
+
+	t.Run("nothing_found", func(t *testing.T) {
+		var unknownUser = primitive.NewObjectID().Hex()
+		found, err := repository.FindByFilters(ctx, &application.GetByFilterParams{UserID: &unknownUser})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+
+		found, err = repository.FindByFilters(ctx, &application.GetByFilterParams{
+			CreatedAt: &application.TimeRange{Start: base.Add(-2 * time.Hour), End: base.Add(-time.Hour)},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+}
+
+func match(params *application.GetByFilterParams, app application.Application) bool {
+	inRange := func(tr *application.TimeRange, t time.Time) bool {
+		return !t.Before(tr.Start) && t.Before(tr.End)
+	}
+
+	if params.Status != nil && app.Status != *params.Status {
+		return false
+	}
+	if params.UserID != nil && app.UserID != *params.UserID {
+		return false
+	}
+	if params.CreatedAt != nil && !inRange(params.CreatedAt, app.CreatedAt) {
+		return false
+	}
+	if params.UpdatedAt != nil && !inRange(params.UpdatedAt, app.UpdatedAt) {
+		return false
+	}
+	return true
+}
+
+func testUpdate(t *testing.T, repository application.Repository) {
+	ctx := context.Background()
+
+	app := newApplication(primitive.NewObjectID().Hex(), application.StatusOpen, base, time.Time{})
+	require.NoError(t, repository.Create(ctx, app))
+
+	t.Run("success", func(t *testing.T) {
+		before := time.Now().UTC().Truncate(time.Millisecond)
+
+		updated, err := repository.Update(ctx, &application.UpdateParams{ID: app.ID, Status: application.StatusInProgress})
+		require.NoError(t, err)
+		assert.Equal(t, application.StatusInProgress, updated.Status)
+		assert.Equal(t, application.StatusOpen, updated.PreviousStatus)
+		assert.Equal(t, app.Version+1, updated.Version)
+		assert.Equal(t, app.CreatedAt, updated.CreatedAt)
+		assert.Equal(t, app.UserID, updated.UserID)
+		assert.Equal(t, app.ExternalStatus, updated.ExternalStatus)
+		assert.False(t, updated.UpdatedAt.Before(before), "updated at %s is before %s", updated.UpdatedAt, before)
+
+		found, err := repository.FindByID(ctx, app.ID)
+		require.NoError(t, err)
+		assert.Equal(t, updated, found)
+	})
+
+	t.Run("success_with_expected_state", func(t *testing.T) {
+		var (
+			status  = application.StatusInProgress
+			version = app.Version + 1
+		)
+		updated, err := repository.Update(ctx, &application.UpdateParams{
+			ID:              app.ID,
+			Status:          application.StatusClosed,
+			Reason:          application.Reason{Code: "resolved", Comment: "fixed by support"},
+			ExpectedStatus:  &status,
+			ExpectedVersion: &version,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, application.StatusClosed, updated.Status)
+		assert.Equal(t, application.StatusInProgress, updated.PreviousStatus)
+		assert.Equal(t, version+1, updated.Version)
+		assert.Equal(t, application.Reason{Code: "resolved", Comment: "fixed by support"}, updated.Reason)
+
+		reason := "resolved"
+		found, err := repository.FindByFilters(ctx, &application.GetByFilterParams{Reason: &reason})
+		require.NoError(t, err)
+		assert.Equal(t, []string{app.ID}, ids(found))
+	})
+
+	t.Run("reason_is_stored_as_text", func(t *testing.T) {
+		reason := application.Reason{Code: "$reason", Comment: "$$ROOT $user_id"}
+		updated, err := repository.Update(ctx, &application.UpdateParams{ID: app.ID, Status: application.StatusClosed, Reason: reason})
+		require.NoError(t, err)
+		assert.Equal(t, reason, updated.Reason)
+
+		found, err := repository.FindByID(ctx, app.ID)
+		require.NoError(t, err)
+		assert.Equal(t, reason, found.Reason)
+
+		all, err := repository.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{app.ID}, ids(all))
+	})
+
+	t.Run("failed_expected_status", func(t *testing.T) {
+		var status = application.StatusOpen
+		_, err := repository.Update(ctx, &application.UpdateParams{
+			ID:             app.ID,
+			Status:         application.StatusInProgress,
+			ExpectedStatus: &status,
+		})
+		assert.True(t, errors.Is(err, application.ErrPreconditionFailed), "unexpected error: %v", err)
+
+		found, err := repository.FindByID(ctx, app.ID)
+		require.NoError(t, err)
+		assert.Equal(t, application.StatusClosed, found.Status)
+	})
+
+	t.Run("failed_expected_version", func(t *testing.T) {
+		var version = app.Version
+		_, err := repository.Update(ctx, &application.UpdateParams{
+			ID:              app.ID,
+			Status:          application.StatusOpen,
+			ExpectedVersion: &version,
+		})
+		assert.True(t, errors.Is(err, application.ErrPreconditionFailed), "unexpected error: %v", err)
+	})
+
+	t.Run("failed_not_found", func(t *testing.T) {
+		var status = application.StatusOpen
+		_, err := repository.Update(ctx, &application.UpdateParams{
+			ID:     primitive.NewObjectID().Hex(),
+			Status: application.StatusClosed,
+		})
+		assert.True(t, errors.Is(err, application.ErrApplicationNotFound), "unexpected error: %v", err)
+
+		_, err = repository.Update(ctx, &application.UpdateParams{
+			ID:             primitive.NewObjectID().Hex(),
+			Status:         application.StatusClosed,
+			ExpectedStatus: &status,
+		})
+		assert.True(t, errors.Is(err, application.ErrApplicationNotFound), "unexpected error: %v", err)
+	})
+}
+
+// testConcurrentUpdate checks that only one of concurrent updates of the same version succeeds
+func testConcurrentUpdate(t *testing.T, repository application.Repository) {
+	ctx := context.Background()
+
+	for i := 0; i < 20; i++ {
+		app := newApplication(primitive.NewObjectID().Hex(), application.StatusOpen, base, time.Time{})
+		require.NoError(t, repository.Create(ctx, app))
+
+		var (
+			statuses = []application.Status{application.StatusInProgress, application.StatusClosed}
+			results  = make([]*application.Application, len(statuses))
+			errs     = make([]error, len(statuses))
+			start    = make(chan struct{})
+			wg       sync.WaitGroup
+		)
+		for j, status := range statuses {
+			wg.Add(1)
+			go func(j int, status application.Status) {
+				defer wg.Done()
+				version := app.Version
+				<-start
+				results[j], errs[j] = repository.Update(ctx, &application.UpdateParams{
+					ID:              app.ID,
+					Status:          status,
+					ExpectedVersion: &version,
+				})
+			}(j, status)
+		}
+		close(start)
+		wg.Wait()
+
+		var succeeded int
+		for j, err := range errs {
+			if err != nil {
+				assert.True(t, errors.Is(err, application.ErrPreconditionFailed), "unexpected error: %v", err)
+				continue
+			}
+			succeeded++
+			assert.Equal(t, statuses[j], results[j].Status)
+			assert.Equal(t, app.Version+1, results[j].Version)
+		}
+		require.Equal(t, 1, succeeded, "errors: %v", errs)
+	}
+}
+
+func testUpdateStatusMany(t *testing.T, repository application.Repository) {
+	ctx := context.Background()
+
+	var (
+		userID = primitive.NewObjectID().Hex()
+		open   = newApplication(userID, application.StatusOpen, base, time.Time{})
+		closed = newApplication(userID, application.StatusClosed, base, time.Time{})
+		other  = newApplication(userID, application.StatusInProgress, base, time.Time{})
+		gone   = newApplication(userID, application.StatusInProgress, base, time.Time{})
+	)
+	for _, app := range []*application.Application{open, closed, other, gone} {
+		require.NoError(t, repository.Create(ctx, app))
+	}
+	_, err := repository.Delete(ctx, gone.ID)
+	require.NoError(t, err)
+
+	before := time.Now().UTC().Truncate(time.Millisecond)
+	updated, err := repository.UpdateStatusMany(
+		ctx,
+		[]string{open.ID, closed.ID, gone.ID, primitive.NewObjectID().Hex()},
+		application.StatusClosed,
+		application.Reason{Code: "duplicate"},
+		nil,
+	)
+	require.NoError(t, err)
+	require.Len(t, updated, 1)
+	assert.Equal(t, open.ID, updated[0].ID)
+	assert.Equal(t, application.StatusClosed, updated[0].Status)
+	assert.Equal(t, application.Reason{Code: "duplicate"}, updated[0].Reason)
+	assert.Equal(t, application.StatusOpen, updated[0].PreviousStatus)
+	assert.Equal(t, open.Version+1, updated[0].Version)
+	assert.False(t, updated[0].UpdatedAt.Before(before), "updated at %s is before %s", updated[0].UpdatedAt, before)
+
+	for _, c := range []struct {
+		App       *application.Application
+		ExpStatus application.Status
+	}{
+		{open, application.StatusClosed},
+		{closed, application.StatusClosed},
+		{other, application.StatusInProgress},
+		{gone, application.StatusInProgress},
+	} {
+		found, err := repository.FindByID(ctx, c.App.ID)
+		require.NoError(t, err)
+		assert.Equal(t, c.ExpStatus, found.Status)
+	}
+
+	found, err := repository.FindByID(ctx, closed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, closed, found)
+
+	t.Run("only_changed_are_returned", func(t *testing.T) {
+		// application closed by previous call is skipped even if it's updated in the same millisecond
+		for i := 0; i < 20; i++ {
+			var (
+				first  = newApplication(userID, application.StatusOpen, base, time.Time{})
+				second = newApplication(userID, application.StatusOpen, base, time.Time{})
+			)
+			require.NoError(t, repository.Create(ctx, first))
+			require.NoError(t, repository.Create(ctx, second))
+
+			_, err := repository.UpdateStatusMany(ctx, []string{first.ID}, application.StatusClosed, application.Reason{}, nil)
+			require.NoError(t, err)
+			updated, err := repository.UpdateStatusMany(ctx, []string{first.ID, second.ID}, application.StatusClosed, application.Reason{}, nil)
+			require.NoError(t, err)
+			require.Len(t, updated, 1)
+			assert.Equal(t, second.ID, updated[0].ID)
+		}
+	})
+}
+
+func testDeleteAndRestore(t *testing.T, repository application.Repository) {
+	ctx := context.Background()
+
+	alive := newApplication(primitive.NewObjectID().Hex(), application.StatusOpen, base, time.Time{})
+	app := newApplication(alive.UserID, application.StatusOpen, base.Add(time.Hour), time.Time{})
+	require.NoError(t, repository.Create(ctx, alive))
+	require.NoError(t, repository.Create(ctx, app))
+
+	byUser := &application.GetByFilterParams{UserID: &app.UserID}
+
+	t.Run("delete", func(t *testing.T) {
+		before := time.Now().UTC().Truncate(time.Millisecond)
+
+		deleted, err := repository.Delete(ctx, app.ID)
+		require.NoError(t, err)
+		require.NotNil(t, deleted.DeletedAt)
+		assert.False(t, deleted.DeletedAt.Before(before), "deleted at %s is before %s", deleted.DeletedAt, before)
+		assert.Equal(t, app.Version+1, deleted.Version)
+		assert.Equal(t, app.Status, deleted.Status)
+
+		found, err := repository.FindByID(ctx, app.ID)
+		require.NoError(t, err)
+		assert.Equal(t, deleted, found)
+
+		apps, err := repository.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{alive.ID}, ids(apps))
+
+		apps, err = repository.FindByFilters(ctx, byUser)
+		require.NoError(t, err)
+		assert.Equal(t, []string{alive.ID}, ids(apps))
+
+		apps, err = repository.FindByFilters(ctx, &application.GetByFilterParams{UserID: &app.UserID, IncludeDeleted: true})
+		require.NoError(t, err)
+		assert.Equal(t, ids([]application.Application{*alive, *app}), ids(apps))
+	})
+
+	t.Run("failed_deleted", func(t *testing.T) {
+		_, err := repository.Delete(ctx, app.ID)
+		assert.True(t, errors.Is(err, application.ErrApplicationDeleted), "unexpected error: %v", err)
+
+		_, err = repository.Update(ctx, &application.UpdateParams{ID: app.ID, Status: application.StatusClosed})
+		assert.True(t, errors.Is(err, application.ErrApplicationDeleted), "unexpected error: %v", err)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		restored, err := repository.Restore(ctx, app.ID)
+		require.NoError(t, err)
+		assert.Nil(t, restored.DeletedAt)
+		assert.Equal(t, app.Version+2, restored.Version)
+
+		apps, err := repository.FindByFilters(ctx, byUser)
+		require.NoError(t, err)
+		assert.Equal(t, ids([]application.Application{*alive, *app}), ids(apps))
+
+		_, err = repository.Restore(ctx, app.ID)
+		assert.True(t, errors.Is(err, application.ErrPreconditionFailed), "unexpected error: %v", err)
+	})
+
+	t.Run("failed_not_found", func(t *testing.T) {
+		_, err := repository.Delete(ctx, primitive.NewObjectID().Hex())
+		assert.True(t, errors.Is(err, application.ErrApplicationNotFound), "unexpected error: %v", err)
+
+		_, err = repository.Restore(ctx, primitive.NewObjectID().Hex())
+		assert.True(t, errors.Is(err, application.ErrApplicationNotFound), "unexpected error: %v", err)
+	})
+}
+
+func testPurge(t *testing.T, repository application.Repository) {
+	ctx := context.Background()
+
+	var apps []*application.Application
+	for i := 0; i < 3; i++ {
+		app := newApplication(primitive.NewObjectID().Hex(), application.StatusOpen, base, time.Time{})
+		require.NoError(t, repository.Create(ctx, app))
+		apps = append(apps, app)
+	}
+	_, err := repository.Delete(ctx, apps[0].ID)
+	require.NoError(t, err)
+	_, err = repository.Delete(ctx, apps[1].ID)
+	require.NoError(t, err)
+
+	n, err := repository.Purge(ctx, time.Now().UTC().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	n, err = repository.Purge(ctx, time.Now().UTC().Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	for _, app := range apps[:2] {
+		_, err := repository.FindByID(ctx, app.ID)
+		assert.True(t, errors.Is(err, application.ErrApplicationNotFound), "unexpected error: %v", err)
+	}
+	_, err = repository.FindByID(ctx, apps[2].ID)
+	assert.NoError(t, err)
+}
+
+func testCountByUser(t *testing.T, repository application.Repository) {
+	ctx := context.Background()
+
+	var userID = primitive.NewObjectID().Hex()
+	var apps = []*application.Application{
+		newApplication(userID, application.StatusOpen, base.Add(-48*time.Hour), time.Time{}),
+		newApplication(userID, application.StatusInProgress, base, time.Time{}),
+		newApplication(userID, application.StatusClosed, base, time.Time{}),
+		newApplication(userID, application.StatusOpen, base.Add(time.Hour), time.Time{}),
+		newApplication(primitive.NewObjectID().Hex(), application.StatusOpen, base, time.Time{}),
+	}
+	for _, app := range apps {
+		require.NoError(t, repository.Create(ctx, app))
+	}
+	// deleted application is counted as created only
+	_, err := repository.Delete(ctx, apps[3].ID)
+	require.NoError(t, err)
+
+	require.NoError(t, repository.LockUser(ctx, userID))
+
+	counts, err := repository.CountByUser(ctx, userID, base)
+	require.NoError(t, err)
+	assert.Equal(t, &application.UserCounts{Active: 2, Created: 3}, counts)
+
+	counts, err = repository.CountByUser(ctx, primitive.NewObjectID().Hex(), base)
+	require.NoError(t, err)
+	assert.Equal(t, &application.UserCounts{}, counts)
+}
+
+func testAssign(t *testing.T, repository application.Repository) {
+	ctx := context.Background()
+
+	var (
+		assigneeID = primitive.NewObjectID().Hex()
+		assigned   = newApplication(primitive.NewObjectID().Hex(), application.StatusOpen, base, time.Time{})
+		unassigned = newApplication(primitive.NewObjectID().Hex(), application.StatusOpen, base, time.Time{})
+	)
+	require.NoError(t, repository.Create(ctx, assigned))
+	require.NoError(t, repository.Create(ctx, unassigned))
+
+	t.Run("assign", func(t *testing.T) {
+		updated, err := repository.Assign(ctx, assigned.ID, assigneeID)
+		require.NoError(t, err)
+		assert.Equal(t, assigneeID, updated.AssigneeID)
+		assert.Equal(t, assigned.Version+1, updated.Version)
+
+		found, err := repository.FindByID(ctx, assigned.ID)
+		require.NoError(t, err)
+		assert.Equal(t, updated, found)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		found, err := repository.FindByFilters(ctx, &application.GetByFilterParams{AssigneeID: &assigneeID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{assigned.ID}, ids(found))
+
+		found, err = repository.FindByFilters(ctx, &application.GetByFilterParams{Unassigned: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{unassigned.ID}, ids(found))
+	})
+
+	t.Run("require_assignee", func(t *testing.T) {
+		_, err := repository.Update(ctx, &application.UpdateParams{
+			ID:              unassigned.ID,
+			Status:          application.StatusInProgress,
+			RequireAssignee: true,
+		})
+		assert.True(t, errors.Is(err, application.ErrAssigneeRequired), "unexpected error: %v", err)
+
+		updated, err := repository.Update(ctx, &application.UpdateParams{
+			ID:              assigned.ID,
+			Status:          application.StatusInProgress,
+			RequireAssignee: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, application.StatusInProgress, updated.Status)
+	})
+
+	t.Run("unassign", func(t *testing.T) {
+		updated, err := repository.Assign(ctx, assigned.ID, "")
+		require.NoError(t, err)
+		assert.Empty(t, updated.AssigneeID)
+
+		found, err := repository.FindByFilters(ctx, &application.GetByFilterParams{Unassigned: true})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{assigned.ID, unassigned.ID}, ids(found))
+	})
+
+	t.Run("failed_deleted", func(t *testing.T) {
+		_, err := repository.Delete(ctx, unassigned.ID)
+		require.NoError(t, err)
+
+		_, err = repository.Assign(ctx, unassigned.ID, assigneeID)
+		assert.True(t, errors.Is(err, application.ErrApplicationDeleted), "unexpected error: %v", err)
+	})
+
+	t.Run("failed_not_found", func(t *testing.T) {
+		_, err := repository.Assign(ctx, primitive.NewObjectID().Hex(), assigneeID)
+		assert.True(t, errors.Is(err, application.ErrApplicationNotFound), "unexpected error: %v", err)
+	})
+}
+
+func testSLA(t *testing.T, repository application.Repository) {
+	ctx := context.Background()
+
+	var (
+		now      = time.Now().UTC().Truncate(time.Millisecond)
+		past     = now.Add(-time.Hour)
+		future   = now.Add(time.Hour)
+		overdue  = newApplication(primitive.NewObjectID().Hex(), application.StatusOpen, base, time.Time{})
+		pending  = newApplication(primitive.NewObjectID().Hex(), application.StatusOpen, base, time.Time{})
+		noSLA    = newApplication(primitive.NewObjectID().Hex(), application.StatusClosed, base, time.Time{})
+		deleted  = newApplication(primitive.NewObjectID().Hex(), application.StatusOpen, base, time.Time{})
+		byPolicy = application.Deadlines{application.PriorityHigh: 2 * time.Hour}
+	)
+	overdue.Priority, overdue.DueAt = application.PriorityHigh, &past
+	pending.Priority, pending.DueAt = application.PriorityHigh, &future
+	deleted.Priority, deleted.DueAt = application.PriorityHigh, &past
+	for _, app := range []*application.Application{overdue, pending, noSLA, deleted} {
+		require.NoError(t, repository.Create(ctx, app))
+	}
+	_, err := repository.Delete(ctx, deleted.ID)
+	require.NoError(t, err)
+
+	t.Run("filters", func(t *testing.T) {
+		found, err := repository.FindByFilters(ctx, &application.GetByFilterParams{Overdue: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{overdue.ID}, ids(found))
+
+		dueBefore := future.Add(time.Millisecond)
+		found, err = repository.FindByFilters(ctx, &application.GetByFilterParams{DueBefore: &dueBefore})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{overdue.ID, pending.ID}, ids(found))
+
+		found, err = repository.FindByFilters(ctx, &application.GetByFilterParams{DueBefore: &dueBefore, Overdue: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{overdue.ID}, ids(found))
+	})
+
+	t.Run("mark_breached", func(t *testing.T) {
+		found, err := repository.FindOverdue(ctx, now, 10)
+		require.NoError(t, err)
+		require.Equal(t, []string{overdue.ID}, ids(found))
+		assert.Equal(t, application.PriorityHigh, found[0].Priority)
+
+		_, err = repository.MarkBreached(ctx, overdue.ID, future)
+		assert.True(t, errors.Is(err, application.ErrPreconditionFailed), "unexpected error: %v", err)
+
+		breached, err := repository.MarkBreached(ctx, overdue.ID, past)
+		require.NoError(t, err)
+		assert.True(t, breached.Breached)
+		assert.Equal(t, overdue.Version+1, breached.Version)
+
+		_, err = repository.MarkBreached(ctx, overdue.ID, past)
+		assert.True(t, errors.Is(err, application.ErrPreconditionFailed), "unexpected error: %v", err)
+
+		found, err = repository.FindOverdue(ctx, now, 10)
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("deadline_of_new_status", func(t *testing.T) {
+		updated, err := repository.Update(ctx, &application.UpdateParams{
+			ID:        overdue.ID,
+			Status:    application.StatusInProgress,
+			Deadlines: byPolicy,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, updated.DueAt)
+		assert.Equal(t, updated.UpdatedAt.Add(2*time.Hour), *updated.DueAt)
+		assert.False(t, updated.Breached)
+
+		// deadline isn't moved without change of status
+		same, err := repository.Update(ctx, &application.UpdateParams{
+			ID:        overdue.ID,
+			Status:    application.StatusInProgress,
+			Deadlines: byPolicy,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, updated.DueAt, same.DueAt)
+
+		closed, err := repository.UpdateStatusMany(ctx, []string{overdue.ID}, application.StatusClosed, application.Reason{Code: "resolved"}, nil)
+		require.NoError(t, err)
+		require.Len(t, closed, 1)
+		assert.Nil(t, closed[0].DueAt)
+	})
+}
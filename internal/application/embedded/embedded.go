@@ -0,0 +1,793 @@
+package application_embedded
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	"github.com/PxyUp/backend_tech_task/internal/external"
+
+	"github.com/tidwall/buntdb"
+)
+
+// InMemory is path for repository without persistence
+const InMemory = ":memory:"
+
+const (
+	keyPrefix = "application:"
+	// tombstonePrefix is prefix of versions of applications deleted by Save
+	tombstonePrefix = "tombstone:"
+	// tombstoneTTL is time of keeping version of deleted application, it should be longer than concurrent writes
+	tombstoneTTL = time.Minute
+)
+
+// Repository stores applications in buntdb, which is persisted to file on disk
+// or is kept in memory only, it doesn't need any external services
+type Repository struct {
+	db *buntdb.DB
+}
+
+func NewRepository(path string) (*Repository, error) {
+	if path == "" {
+		path = InMemory
+	}
+
+	db, err := buntdb.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := createIndexes(db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Repository{db: db}, nil
+}
+
+func createIndexes(db *buntdb.DB) error {
+	var pattern = keyPrefix + "*"
+	if err := db.CreateIndex("status", pattern, buntdb.IndexJSON("Status")); err != nil {
+		return err
+	}
+	if err := db.CreateIndex("user_id", pattern, buntdb.IndexJSON("UserID")); err != nil {
+		return err
+	}
+	if err := db.CreateIndex("created_at", pattern, buntdb.IndexJSON("CreatedAt")); err != nil {
+		return err
+	}
+	if err := db.CreateIndex("updated_at", pattern, buntdb.IndexJSON("UpdatedAt")); err != nil {
+		return err
+	}
+	if err := db.CreateIndex("reason", pattern, buntdb.IndexJSON("Reason.Code")); err != nil {
+		return err
+	}
+	if err := db.CreateIndex("assignee_id", pattern, buntdb.IndexJSON("AssigneeID")); err != nil {
+		return err
+	}
+	if err := db.CreateIndex("due_at", pattern, buntdb.IndexJSON("DueAt")); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *Repository) Close() error {
+	return r.db.Close()
+}
+
+func key(id string) string {
+	return keyPrefix + id
+}
+
+func tombstoneKey(id string) string {
+	return tombstonePrefix + id
+}
+
+func (r *Repository) Create(_ context.Context, app *application.Application) error {
+	value, err := NewApplicationModel(app).Value()
+	if err != nil {
+		return err
+	}
+
+	return r.db.Update(func(tx *buntdb.Tx) error {
+		if _, err := tx.Get(key(app.ID)); err == nil {
+			return application.ErrApplicationAlreadyExists
+		} else if !errors.Is(err, buntdb.ErrNotFound) {
+			return err
+		}
+
+		_, _, err := tx.Set(key(app.ID), value, nil)
+		return err
+	})
+}
+
+func (r *Repository) CreateMany(_ context.Context, apps []*application.Application) ([]error, error) {
+	var errs = make([]error, len(apps))
+
+	err := r.db.Update(func(tx *buntdb.Tx) error {
+		for i, app := range apps {
+			if _, err := tx.Get(key(app.ID)); err == nil {
+				errs[i] = application.ErrApplicationAlreadyExists
+				continue
+			} else if !errors.Is(err, buntdb.ErrNotFound) {
+				return err
+			}
+
+			value, err := NewApplicationModel(app).Value()
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			if _, _, err := tx.Set(key(app.ID), value, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return errs, nil
+}
+
+func (r *Repository) Update(ctx context.Context, params *application.UpdateParams) (*application.Application, error) {
+	return r.modify(ctx, params.ID, func(app *application.Application) error {
+		if app.Deleted() {
+			return application.ErrApplicationDeleted
+		}
+		if params.ExpectedStatus != nil && app.Status != *params.ExpectedStatus {
+			return application.ErrPreconditionFailed
+		}
+		if params.ExpectedVersion != nil && app.Version != *params.ExpectedVersion {
+			return application.ErrPreconditionFailed
+		}
+		if params.RequireAssignee && app.AssigneeID == "" {
+			return application.ErrAssigneeRequired
+		}
+
+		setStatus(app, params.Status, params.Reason, params.Deadlines)
+		return nil
+	})
+}
+
+// setStatus changes status of application, deadline is calculated for new status only
+func setStatus(app *application.Application, status application.Status, reason application.Reason, deadlines application.Deadlines) {
+	if app.Status != status {
+		app.DueAt = deadlines.DueAt(app.Priority, app.UpdatedAt)
+		app.Breached = false
+	}
+	app.PreviousStatus = app.Status
+	app.Status = status
+	app.Reason = reason
+}
+
+func (r *Repository) MarkBreached(ctx context.Context, id string, dueAt time.Time) (*application.Application, error) {
+	return r.modify(ctx, id, func(app *application.Application) error {
+		if app.Deleted() {
+			return application.ErrApplicationDeleted
+		}
+		if app.Breached || app.DueAt == nil || !app.DueAt.Equal(dueAt) {
+			return application.ErrPreconditionFailed
+		}
+		app.Breached = true
+		return nil
+	})
+}
+
+func (r *Repository) Assign(ctx context.Context, id string, assigneeID string) (*application.Application, error) {
+	return r.modify(ctx, id, func(app *application.Application) error {
+		if app.Deleted() {
+			return application.ErrApplicationDeleted
+		}
+		app.AssigneeID = assigneeID
+		return nil
+	})
+}
+
+func (r *Repository) Delete(ctx context.Context, id string) (*application.Application, error) {
+	return r.modify(ctx, id, func(app *application.Application) error {
+		if app.Deleted() {
+			return application.ErrApplicationDeleted
+		}
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		app.DeletedAt = &now
+		return nil
+	})
+}
+
+func (r *Repository) Restore(ctx context.Context, id string) (*application.Application, error) {
+	return r.modify(ctx, id, func(app *application.Application) error {
+		if !app.Deleted() {
+			return application.ErrPreconditionFailed
+		}
+		app.DeletedAt = nil
+		return nil
+	})
+}
+
+// modify changes application by fn in transaction, it updates time and version of application
+func (r *Repository) modify(
+	_ context.Context,
+	id string,
+	fn func(app *application.Application) error,
+) (*application.Application, error) {
+	var app *application.Application
+
+	if err := r.db.Update(func(tx *buntdb.Tx) (err error) {
+		app, err = modifyTx(tx, id, time.Now().UTC(), fn)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
+
+func modifyTx(
+	tx *buntdb.Tx,
+	id string,
+	now time.Time,
+	fn func(app *application.Application) error,
+) (*application.Application, error) {
+	v, err := tx.Get(key(id))
+	if err != nil {
+		if errors.Is(err, buntdb.ErrNotFound) {
+			return nil, application.ErrApplicationNotFound
+		}
+		return nil, err
+	}
+
+	m, err := ParseApplicationModelValue(v)
+	if err != nil {
+		return nil, err
+	}
+	app := m.Parse()
+
+	// fn can use time of update
+	app.UpdatedAt = now.Truncate(time.Millisecond)
+	if err := fn(app); err != nil {
+		return nil, err
+	}
+	app.Version++
+
+	value, err := NewApplicationModel(app).Value()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err := tx.Set(key(app.ID), value, nil); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+var errSkip = errors.New("skip application")
+
+func (r *Repository) UpdateStatusMany(
+	_ context.Context,
+	ids []string,
+	status application.Status,
+	reason application.Reason,
+	deadlines application.Deadlines,
+) ([]application.Application, error) {
+	var (
+		apps []application.Application
+		now  = time.Now().UTC()
+	)
+
+	err := r.db.Update(func(tx *buntdb.Tx) error {
+		for _, id := range ids {
+			app, err := modifyTx(tx, id, now, func(app *application.Application) error {
+				if app.Deleted() || app.Status == status {
+					return errSkip
+				}
+				setStatus(app, status, reason, deadlines)
+				return nil
+			})
+			if err != nil {
+				if errors.Is(err, errSkip) || errors.Is(err, application.ErrApplicationNotFound) {
+					continue
+				}
+				return err
+			}
+			apps = append(apps, *app)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
+
+func (r *Repository) FindOverdue(_ context.Context, now time.Time, limit int) ([]application.Application, error) {
+	var apps []application.Application
+
+	if err := r.db.View(func(tx *buntdb.Tx) error {
+		var parseErr error
+		// applications without deadline have null DueAt, which is less than any number
+		if err := tx.AscendRange(
+			"due_at",
+			`{"DueAt": 0}`,
+			fmt.Sprintf(`{"DueAt": %d}`, NewTimestamp(now)),
+			func(key, value string) bool {
+				m, err := ParseApplicationModelValue(value)
+				if err != nil {
+					parseErr = err
+					return false
+				}
+				app := m.Parse()
+				if !app.Deleted() && !app.Breached {
+					apps = append(apps, *app)
+				}
+				return limit <= 0 || len(apps) < limit
+			},
+		); err != nil {
+			return err
+		}
+		return parseErr
+	}); err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
+
+func (r *Repository) Purge(_ context.Context, before time.Time) (int64, error) {
+	var n int64
+
+	err := r.db.Update(func(tx *buntdb.Tx) error {
+		var keys []string
+		if err := tx.AscendKeys(keyPrefix+"*", func(key, value string) bool {
+			m, err := ParseApplicationModelValue(value)
+			if err == nil && m.DeletedAt != nil && *m.DeletedAt < NewTimestamp(before) {
+				keys = append(keys, key)
+			}
+			return true
+		}); err != nil {
+			return err
+		}
+
+		// buntdb doesn't allow to delete items while iterating over them
+		for _, k := range keys {
+			if _, err := tx.Delete(k); err != nil {
+				return err
+			}
+			n++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (r *Repository) FindByID(_ context.Context, id string) (*application.Application, error) {
+	var app *application.Application
+
+	if err := r.db.View(func(tx *buntdb.Tx) error {
+		v, err := tx.Get(key(id))
+		if err != nil {
+			if errors.Is(err, buntdb.ErrNotFound) {
+				return application.ErrApplicationNotFound
+			}
+			return err
+		}
+
+		m, err := ParseApplicationModelValue(v)
+		if err != nil {
+			return err
+		}
+
+		app = m.Parse()
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
+
+func (r *Repository) FindAll(_ context.Context) ([]application.Application, error) {
+	var apps []application.Application
+
+	if err := r.db.View(func(tx *buntdb.Tx) error {
+		var parseErr error
+		if err := tx.AscendKeys(keyPrefix+"*", func(key, value string) bool {
+			m, err := ParseApplicationModelValue(value)
+			if err != nil {
+				parseErr = err
+				return false
+			}
+			if m.DeletedAt == nil {
+				apps = append(apps, *m.Parse())
+			}
+			return true
+		}); err != nil {
+			return err
+		}
+		return parseErr
+	}); err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
+
+func (r *Repository) FindByFilters(
+	_ context.Context,
+	filter *application.GetByFilterParams,
+) ([]application.Application, error) {
+	var apps []application.Application
+
+	err := r.db.View(func(tx *buntdb.Tx) error {
+		var (
+			// storing results of search of whole query
+			results map[string]string
+			// storing results of filter search
+			tmp = make(map[string]string)
+		)
+
+		merge := func() func(key, value string) bool {
+
+			// if it's first filter search, save apps to results
+			if results == nil {
+				results = make(map[string]string)
+				return func(key, value string) bool {
+					tmp[key] = value
+					return true
+				}
+			}
+
+			// other searches will be merged with results
+			// and stored result of merging to tmp
+			return func(key, value string) bool {
+				if _, ok := results[key]; ok {
+					tmp[key] = value
+				}
+				return true
+			}
+		}
+
+		// after filter search, need to cleanup tmp
+		// and store tmp to results
+		swap := func() {
+			results = tmp
+			tmp = make(map[string]string)
+		}
+
+		if filter.Status != nil {
+			if err := tx.AscendEqual(
+				"status",
+				fmt.Sprintf(`{"Status": %d}`, filter.Status.Int32()),
+				merge(),
+			); err != nil {
+				return err
+			}
+			swap()
+		}
+
+		if filter.UserID != nil {
+			userID, err := json.Marshal(*filter.UserID)
+			if err != nil {
+				return err
+			}
+			if err := tx.AscendEqual(
+				"user_id",
+				fmt.Sprintf(`{"UserID": %s}`, userID),
+				merge(),
+			); err != nil {
+				return err
+			}
+			swap()
+		}
+
+		if filter.Reason != nil {
+			code, err := json.Marshal(*filter.Reason)
+			if err != nil {
+				return err
+			}
+			if err := tx.AscendEqual(
+				"reason",
+				fmt.Sprintf(`{"Reason": {"Code": %s}}`, code),
+				merge(),
+			); err != nil {
+				return err
+			}
+			swap()
+		}
+
+		if filter.AssigneeID != nil {
+			assigneeID, err := json.Marshal(*filter.AssigneeID)
+			if err != nil {
+				return err
+			}
+			if err := tx.AscendEqual(
+				"assignee_id",
+				fmt.Sprintf(`{"AssigneeID": %s}`, assigneeID),
+				merge(),
+			); err != nil {
+				return err
+			}
+			swap()
+		}
+
+		if filter.Unassigned {
+			// ids of operators are hex, so missing and empty assignees are less than "0"
+			if err := tx.AscendLessThan(
+				"assignee_id",
+				`{"AssigneeID": "0"}`,
+				merge(),
+			); err != nil {
+				return err
+			}
+			swap()
+		}
+
+		if dueBefore := filter.DueBound(time.Now().UTC()); dueBefore != nil {
+			if err := tx.AscendRange(
+				"due_at",
+				`{"DueAt": 0}`,
+				fmt.Sprintf(`{"DueAt": %d}`, NewTimestamp(*dueBefore)),
+				merge(),
+			); err != nil {
+				return err
+			}
+			swap()
+		}
+
+		if filter.CreatedAt != nil {
+			if err := tx.AscendRange(
+				"created_at",
+				fmt.Sprintf(`{"CreatedAt": %d}`, NewTimestamp(filter.CreatedAt.Start)),
+				fmt.Sprintf(`{"CreatedAt": %d}`, NewTimestamp(filter.CreatedAt.End)),
+				merge(),
+			); err != nil {
+				return err
+			}
+			swap()
+		}
+
+		if filter.UpdatedAt != nil {
+			if err := tx.AscendRange(
+				"updated_at",
+				fmt.Sprintf(`{"UpdatedAt": %d}`, NewTimestamp(filter.UpdatedAt.Start)),
+				fmt.Sprintf(`{"UpdatedAt": %d}`, NewTimestamp(filter.UpdatedAt.End)),
+				merge(),
+			); err != nil {
+				return err
+			}
+			swap()
+		}
+
+		// without filters all applications are matched
+		if results == nil {
+			if err := tx.AscendKeys(keyPrefix+"*", merge()); err != nil {
+				return err
+			}
+			swap()
+		}
+
+		for _, value := range results {
+			m, err := ParseApplicationModelValue(value)
+			if err != nil {
+				return err
+			}
+			if m.DeletedAt != nil && !filter.IncludeDeleted {
+				continue
+			}
+			if filter.AfterID != nil && m.ID <= *filter.AfterID {
+				continue
+			}
+			apps = append(apps, *m.Parse())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// ids are hex of object ids, so they are ordered as in mongo
+	sort.Slice(apps, func(i, j int) bool { return apps[i].ID < apps[j].ID })
+	if filter.Limit > 0 && len(apps) > filter.Limit {
+		apps = apps[:filter.Limit]
+	}
+	return apps, nil
+}
+
+func (r *Repository) CountByUser(_ context.Context, userID string, createdSince time.Time) (*application.UserCounts, error) {
+	pivot, err := json.Marshal(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var counts = new(application.UserCounts)
+	if err := r.db.View(func(tx *buntdb.Tx) error {
+		var parseErr error
+		if err := tx.AscendEqual("user_id", fmt.Sprintf(`{"UserID": %s}`, pivot), func(key, value string) bool {
+			m, err := ParseApplicationModelValue(value)
+			if err != nil {
+				parseErr = err
+				return false
+			}
+			app := m.Parse()
+			if !app.CreatedAt.Before(createdSince) {
+				counts.Created++
+			}
+			if !app.Deleted() && (app.Status == application.StatusOpen || app.Status == application.StatusInProgress) {
+				counts.Active++
+			}
+			return true
+		}); err != nil {
+			return err
+		}
+		return parseErr
+	}); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// LockUser does nothing, buntdb doesn't have transactions shared with service
+func (r *Repository) LockUser(context.Context, string) error {
+	return nil
+}
+
+// Save stores applications as is in one transaction, deleted applications are removed,
+// it's used for keeping applications of another repository. Application is skipped if stored
+// or recently deleted one has the same or newer version, so writes reordered by callers don't restore old state.
+func (r *Repository) Save(apps ...application.Application) error {
+	return r.db.Update(func(tx *buntdb.Tx) error {
+		for i := range apps {
+			app := &apps[i]
+			version, ok, err := storedVersion(tx, app.ID)
+			if err != nil {
+				return err
+			}
+			if ok && version >= app.Version {
+				continue
+			}
+
+			if app.Deleted() {
+				if _, err := tx.Delete(key(app.ID)); err != nil && !errors.Is(err, buntdb.ErrNotFound) {
+					return err
+				}
+				if _, _, err := tx.Set(
+					tombstoneKey(app.ID),
+					strconv.FormatInt(app.Version, 10),
+					&buntdb.SetOptions{Expires: true, TTL: tombstoneTTL},
+				); err != nil {
+					return err
+				}
+				continue
+			}
+
+			value, err := NewApplicationModel(app).Value()
+			if err != nil {
+				return err
+			}
+			if _, _, err := tx.Set(key(app.ID), value, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// storedVersion returns version of stored application or tombstone of deleted one
+func storedVersion(tx *buntdb.Tx, id string) (int64, bool, error) {
+	value, err := tx.Get(key(id))
+	if err == nil {
+		m, err := ParseApplicationModelValue(value)
+		if err != nil {
+			return 0, false, err
+		}
+		return m.Version, true, nil
+	}
+	if !errors.Is(err, buntdb.ErrNotFound) {
+		return 0, false, err
+	}
+
+	value, err = tx.Get(tombstoneKey(id))
+	if err != nil {
+		if errors.Is(err, buntdb.ErrNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return version, true, nil
+}
+
+type ApplicationModel struct {
+	application.Application
+	Status         int32
+	CreatedAt      int64
+	UpdatedAt      int64
+	ExternalStatus int32
+	PreviousStatus int32
+	DeletedAt      *int64
+	DueAt          *int64
+	Priority       int32
+}
+
+func (m ApplicationModel) Parse() *application.Application {
+	m.Application.CreatedAt = ParseTimestamp(m.CreatedAt)
+	m.Application.UpdatedAt = ParseTimestamp(m.UpdatedAt)
+	m.Application.Status = application.NewStatus(m.Status)
+	m.Application.ExternalStatus = external.NewStatus(m.ExternalStatus)
+	m.Application.PreviousStatus = application.NewStatus(m.PreviousStatus)
+	m.Application.DeletedAt = nil
+	if m.DeletedAt != nil {
+		deletedAt := ParseTimestamp(*m.DeletedAt)
+		m.Application.DeletedAt = &deletedAt
+	}
+	m.Application.Priority = application.NewPriority(m.Priority)
+	m.Application.DueAt = nil
+	if m.DueAt != nil {
+		dueAt := ParseTimestamp(*m.DueAt)
+		m.Application.DueAt = &dueAt
+	}
+	return &m.Application
+}
+
+func (m ApplicationModel) Value() (string, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func NewApplicationModel(app *application.Application) *ApplicationModel {
+	var deletedAt, dueAt *int64
+	if app.DeletedAt != nil {
+		ts := NewTimestamp(*app.DeletedAt)
+		deletedAt = &ts
+	}
+	if app.DueAt != nil {
+		ts := NewTimestamp(*app.DueAt)
+		dueAt = &ts
+	}
+	return &ApplicationModel{
+		Application:    *app,
+		Status:         app.Status.Int32(),
+		CreatedAt:      NewTimestamp(app.CreatedAt),
+		UpdatedAt:      NewTimestamp(app.UpdatedAt),
+		ExternalStatus: app.ExternalStatus.Int32(),
+		PreviousStatus: app.PreviousStatus.Int32(),
+		DeletedAt:      deletedAt,
+		DueAt:          dueAt,
+		Priority:       app.Priority.Int32(),
+	}
+}
+
+func ParseApplicationModelValue(v string) (*ApplicationModel, error) {
+	var m = new(ApplicationModel)
+	if err := json.Unmarshal([]byte(v), m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NewTimestamp converts time to unix milliseconds, the same precision as mongo has
+func NewTimestamp(t time.Time) int64 {
+	return t.Unix()*1e3 + int64(t.Nanosecond())/1e6
+}
+
+func ParseTimestamp(ts int64) time.Time {
+	return time.Unix(ts/1e3, ts%1e3*1e6).UTC()
+}
@@ -0,0 +1,35 @@
+package application_embedded_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	application_conformance "github.com/PxyUp/backend_tech_task/internal/application/conformance"
+	application_embedded "github.com/PxyUp/backend_tech_task/internal/application/embedded"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_InMemory(t *testing.T) {
+	application_conformance.RunRepositoryTests(t, func() application.Repository {
+		r, err := application_embedded.NewRepository(application_embedded.InMemory)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = r.Close() })
+		return r
+	})
+}
+
+func TestRepository_File(t *testing.T) {
+	var dir = t.TempDir()
+	var n int
+
+	application_conformance.RunRepositoryTests(t, func() application.Repository {
+		n++
+		r, err := application_embedded.NewRepository(filepath.Join(dir, fmt.Sprintf("applications_%d.db", n)))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = r.Close() })
+		return r
+	})
+}
@@ -0,0 +1,75 @@
+package application
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/outbox"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	EventApplicationCreated       = "ApplicationCreated"
+	EventApplicationStatusChanged = "ApplicationStatusChanged"
+	EventApplicationDeleted       = "ApplicationDeleted"
+	EventApplicationRestored      = "ApplicationRestored"
+	EventApplicationAssigned      = "ApplicationAssigned"
+	EventApplicationUnassigned    = "ApplicationUnassigned"
+	EventApplicationSLABreached   = "ApplicationSLABreached"
+)
+
+// EventPayload is snapshot of application after change, it's written to outbox as json
+type EventPayload struct {
+	ID             string     `json:"id"`
+	UserID         string     `json:"user_id"`
+	Status         string     `json:"status"`
+	PreviousStatus string     `json:"previous_status,omitempty"`
+	ExternalStatus string     `json:"external_status"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	Version        int64      `json:"version"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+	Reason         string     `json:"reason,omitempty"`
+	ReasonComment  string     `json:"reason_comment,omitempty"`
+	AssigneeID     string     `json:"assignee_id,omitempty"`
+	Priority       string     `json:"priority"`
+	DueAt          *time.Time `json:"due_at,omitempty"`
+	Breached       bool       `json:"breached,omitempty"`
+}
+
+func NewEvent(eventType string, app *Application) (outbox.Event, error) {
+	var payload = EventPayload{
+		ID:             app.ID,
+		UserID:         app.UserID,
+		Status:         app.Status.String(),
+		ExternalStatus: app.ExternalStatus.String(),
+		CreatedAt:      app.CreatedAt,
+		UpdatedAt:      app.UpdatedAt,
+		Version:        app.Version,
+		DeletedAt:      app.DeletedAt,
+		Reason:         app.Reason.Code,
+		ReasonComment:  app.Reason.Comment,
+		AssigneeID:     app.AssigneeID,
+		Priority:       app.Priority.String(),
+		DueAt:          app.DueAt,
+		Breached:       app.Breached,
+	}
+	if eventType == EventApplicationStatusChanged {
+		payload.PreviousStatus = app.PreviousStatus.String()
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return outbox.Event{}, err
+	}
+
+	return outbox.Event{
+		ID:               primitive.NewObjectID().Hex(),
+		AggregateID:      app.ID,
+		AggregateVersion: app.Version,
+		Type:             eventType,
+		Payload:          data,
+		CreatedAt:        time.Now().UTC(),
+	}, nil
+}
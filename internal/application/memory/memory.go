@@ -2,47 +2,33 @@ package application_memory
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/PxyUp/backend_tech_task/internal/application"
+	application_embedded "github.com/PxyUp/backend_tech_task/internal/application/embedded"
 
-	"github.com/tidwall/buntdb"
 	"golang.org/x/sync/singleflight"
 )
 
 type Repository struct {
 	application.Repository
-	db *buntdb.DB
+	cache *application_embedded.Repository
 
-	// using for avoiding multiple changing in one moment
+	// sg merges concurrent loads of the same missing application, writes are never merged
 	sg singleflight.Group
 }
 
 func NewRepository(repository application.Repository) (*Repository, error) {
-	db, err := buntdb.Open(":memory:")
+	cache, err := application_embedded.NewRepository(application_embedded.InMemory)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := db.CreateIndex("status", "*", buntdb.IndexJSON("Status")); err != nil {
-		return nil, err
-	}
-	if err := db.CreateIndex("user_id", "*", buntdb.IndexJSON("UserID")); err != nil {
-		return nil, err
-	}
-	if err := db.CreateIndex("created_at", "*", buntdb.IndexJSON("CreatedAt")); err != nil {
-		return nil, err
-	}
-	if err := db.CreateIndex("updated_at", "*", buntdb.IndexJSON("UpdatedAt")); err != nil {
-		return nil, err
-	}
-
 	r := &Repository{
 		Repository: repository,
-		db:         db,
+		cache:      cache,
 	}
 
 	if err := r.warmCache(context.TODO()); err != nil {
@@ -57,243 +43,172 @@ func (r *Repository) warmCache(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("couldn't warm cache: %w", err)
 	}
-	return r.SetMultiple(apps...)
+	return r.SetMultiple(ctx, apps...)
 }
 
 func (r *Repository) Update(ctx context.Context, params *application.UpdateParams) (*application.Application, error) {
-	v, err, _ := r.sg.Do(params.ID, func() (interface{}, error) {
-		app, err := r.Repository.Update(ctx, params)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := r.Set(app); err != nil {
-			return nil, err
-		}
-		return app, nil
-	})
+	app, err := r.Repository.Update(ctx, params)
 	if err != nil {
 		return nil, err
 	}
-
-	app, ok := v.(*application.Application)
-	if !ok {
-		return nil, fmt.Errorf("update couldn't return app")
-	}
-
-	return app, nil
+	return app, r.Set(ctx, app)
 }
 
 func (r *Repository) Create(ctx context.Context, app *application.Application) error {
-	_, err, _ := r.sg.Do(app.ID, func() (interface{}, error) {
-		if err := r.Repository.Create(ctx, app); err != nil {
-			return nil, err
-		}
-
-		if err := r.Set(app); err != nil {
-			return nil, err
-		}
+	if err := r.Repository.Create(ctx, app); err != nil {
+		return err
+	}
+	return r.Set(ctx, app)
+}
 
-		return nil, nil
+func (r *Repository) Assign(ctx context.Context, id string, assigneeID string) (*application.Application, error) {
+	return r.change(ctx, id, func(ctx context.Context, id string) (*application.Application, error) {
+		return r.Repository.Assign(ctx, id, assigneeID)
 	})
+}
 
-	return err
+func (r *Repository) MarkBreached(ctx context.Context, id string, dueAt time.Time) (*application.Application, error) {
+	return r.change(ctx, id, func(ctx context.Context, id string) (*application.Application, error) {
+		return r.Repository.MarkBreached(ctx, id, dueAt)
+	})
 }
 
-func (r *Repository) FindByID(ctx context.Context, id string) (*application.Application, error) {
-	var m = new(ApplicationModel)
+func (r *Repository) Delete(ctx context.Context, id string) (*application.Application, error) {
+	return r.change(ctx, id, r.Repository.Delete)
+}
 
-	if err := r.db.View(func(tx *buntdb.Tx) error {
-		v, err := tx.Get(id)
-		if err != nil {
-			return err
-		}
+func (r *Repository) Restore(ctx context.Context, id string) (*application.Application, error) {
+	return r.change(ctx, id, r.Repository.Restore)
+}
 
-		return json.Unmarshal([]byte(v), m)
-	}); err != nil {
-		if !errors.Is(err, buntdb.ErrNotFound) {
-			app, err := r.Repository.FindByID(ctx, id)
-			if err != nil {
-				return nil, err
-			}
+func (r *Repository) change(
+	ctx context.Context,
+	id string,
+	fn func(ctx context.Context, id string) (*application.Application, error),
+) (*application.Application, error) {
+	app, err := fn(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return app, r.Set(ctx, app)
+}
 
-			return app, r.Set(app)
-		}
+// CreateMany caches created applications in one transaction of cache
+func (r *Repository) CreateMany(ctx context.Context, apps []*application.Application) ([]error, error) {
+	errs, err := r.Repository.CreateMany(ctx, apps)
+	if err != nil {
 		return nil, err
 	}
 
-	return m.Parse(), nil
+	var created = make([]application.Application, 0, len(apps))
+	for i, app := range apps {
+		if errs[i] == nil {
+			created = append(created, *app)
+		}
+	}
+	return errs, r.SetMultiple(ctx, created...)
 }
 
-func (r *Repository) FindByFilters(
+// UpdateStatusMany caches changed applications after commit of transaction
+func (r *Repository) UpdateStatusMany(
 	ctx context.Context,
-	filter *application.GetByFilterParams,
+	ids []string,
+	status application.Status,
+	reason application.Reason,
+	deadlines application.Deadlines,
 ) ([]application.Application, error) {
-	var apps []application.Application
-
-	err := r.db.View(func(tx *buntdb.Tx) error {
-		var (
-			// storing results of search of whole query
-			results map[string]string
-			// storing results of filter search
-			tmp = make(map[string]string)
-		)
-
-		merge := func() func(key, value string) bool {
-
-			// if it's first filter search, save apps to results
-			if results == nil {
-				results = make(map[string]string)
-				return func(key, value string) bool {
-					tmp[key] = value
-					return true
-				}
-			}
-
-			// other searches will be merged with results
-			// and stored result of merging to tmp
-			return func(key, value string) bool {
-				if _, ok := results[key]; ok {
-					tmp[key] = value
-				}
-				return true
-			}
-		}
+	apps, err := r.Repository.UpdateStatusMany(ctx, ids, status, reason, deadlines)
+	if err != nil {
+		return nil, err
+	}
 
-		// after filter search, need to cleanup tmp
-		// and store tmp to results
-		swap := func() {
-			results = tmp
-			tmp = make(map[string]string)
-		}
+	return apps, r.SetMultiple(ctx, apps...)
+}
 
-		if filter.Status != nil {
-			if err := tx.AscendEqual(
-				"status",
-				fmt.Sprintf(`{"Status": %d}`, filter.Status.Int32()),
-				merge(),
-			); err != nil {
-				return err
-			}
-			swap()
+func (r *Repository) FindByID(ctx context.Context, id string) (*application.Application, error) {
+	// application could be changed in current transaction and not be cached yet
+	if p := pendingFromContext(ctx); p != nil {
+		if app, ok := p.get(id); ok {
+			return app, nil
 		}
+	}
 
-		if filter.UserID != nil {
-			if err := tx.AscendEqual(
-				"user_id",
-				fmt.Sprintf(`{"UserID": "%s"}`, *filter.UserID),
-				merge(),
-			); err != nil {
-				return err
-			}
-			swap()
+	app, err := r.cache.FindByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, application.ErrApplicationNotFound) {
+			return nil, err
 		}
 
-		if filter.CreatedAt != nil {
-			if err := tx.AscendRange(
-				"created_at",
-				fmt.Sprintf(`{"CreatedAt": %d}`, filter.CreatedAt.Start.Unix()),
-				fmt.Sprintf(`{"CreatedAt": %d}`, filter.CreatedAt.End.Unix()),
-				merge(),
-			); err != nil {
-				return err
-			}
-			swap()
-		}
+		return r.load(ctx, id)
+	}
 
-		if filter.UpdatedAt != nil {
-			if err := tx.AscendRange(
-				"updated_at",
-				fmt.Sprintf(`{"UpdatedAt": %d}`, filter.UpdatedAt.Start.Unix()),
-				fmt.Sprintf(`{"UpdatedAt": %d}`, filter.UpdatedAt.End.Unix()),
-				merge(),
-			); err != nil {
-				return err
-			}
-			swap()
-		}
+	return app, nil
+}
 
-		if results == nil {
-			return nil
+// load reads application missing from cache, concurrent loads outside of transactions are merged
+func (r *Repository) load(ctx context.Context, id string) (*application.Application, error) {
+	// transaction could see own uncommitted changes, so its result isn't shared
+	if pendingFromContext(ctx) != nil {
+		app, err := r.Repository.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
 		}
+		return app, r.Set(ctx, app)
+	}
 
-		var m ApplicationModel
-		for _, value := range results {
-			if err := json.Unmarshal([]byte(value), &m); err != nil {
-				return err
-			}
-			apps = append(apps, *m.Parse())
+	v, err, _ := r.sg.Do(id, func() (interface{}, error) {
+		app, err := r.Repository.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
 		}
-
-		return nil
+		return app, r.Set(ctx, app)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// callers get own copies of shared result
+	app := *v.(*application.Application)
+	return &app, nil
+}
+
+func (r *Repository) FindByFilters(
+	ctx context.Context,
+	filter *application.GetByFilterParams,
+) ([]application.Application, error) {
+	// cache doesn't keep deleted applications
+	if filter.IncludeDeleted {
+		return r.Repository.FindByFilters(ctx, filter)
+	}
+
+	apps, err := r.cache.FindByFilters(ctx, filter)
 	if err != nil || len(apps) == 0 {
 		apps, err := r.Repository.FindByFilters(ctx, filter)
 		if err != nil {
 			return nil, err
 		}
 
-		return apps, r.SetMultiple(apps...)
+		return apps, r.SetMultiple(ctx, apps...)
 	}
 
 	return apps, nil
 }
 
-func (r *Repository) Set(app *application.Application) error {
-	b, err := json.Marshal(NewApplicationModel(app))
-	if err != nil {
-		return err
-	}
-
-	return r.db.Update(func(tx *buntdb.Tx) error {
-		_, _, err := tx.Set(app.ID, string(b), nil)
-		return err
-	})
+func (r *Repository) Set(ctx context.Context, app *application.Application) error {
+	return r.SetMultiple(ctx, *app)
 }
 
-func (r *Repository) SetMultiple(apps ...application.Application) error {
-	return r.db.Update(func(tx *buntdb.Tx) error {
-		for _, app := range apps {
-			b, err := json.Marshal(NewApplicationModel(&app))
-			if err != nil {
-				return err
-			}
-
-			if _, _, err := tx.Set(app.ID, string(b), nil); err != nil {
-				return err
-			}
-		}
+// SetMultiple caches applications, inside of transaction they are cached after commit,
+// deleted applications are evicted from cache, older versions than cached ones are skipped
+func (r *Repository) SetMultiple(ctx context.Context, apps ...application.Application) error {
+	if p := pendingFromContext(ctx); p != nil {
+		p.set(apps...)
 		return nil
-	})
-}
-
-type ApplicationModel struct {
-	application.Application
-	Status    int32
-	CreatedAt int64
-	UpdatedAt int64
-}
-
-func (m ApplicationModel) Parse() *application.Application {
-	m.Application.CreatedAt = time.Unix(m.CreatedAt, 0)
-	m.Application.UpdatedAt = time.Unix(m.UpdatedAt, 0)
-	m.Application.Status = application.NewStatus(m.Status)
-	return &m.Application
-}
-
-func (m ApplicationModel) Value() (string, error) {
-	b, err := json.Marshal(m)
-	if err != nil {
-		return "", err
 	}
-	return string(b), nil
+	return r.store(apps...)
 }
 
-func NewApplicationModel(app *application.Application) *ApplicationModel {
-	return &ApplicationModel{
-		Application: *app,
-		Status:      app.Status.Int32(),
-		CreatedAt:   app.CreatedAt.Unix(),
-		UpdatedAt:   app.UpdatedAt.Unix(),
-	}
+// store skips applications older than cached ones, e.g. load which has read application before concurrent update
+func (r *Repository) store(apps ...application.Application) error {
+	return r.cache.Save(apps...)
 }
@@ -0,0 +1,130 @@
+package application_memory_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	application_conformance "github.com/PxyUp/backend_tech_task/internal/application/conformance"
+	application_embedded "github.com/PxyUp/backend_tech_task/internal/application/embedded"
+	application_memory "github.com/PxyUp/backend_tech_task/internal/application/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRepository(t *testing.T) {
+	application_conformance.RunRepositoryTests(t, func() application.Repository {
+		source, err := application_embedded.NewRepository(application_embedded.InMemory)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = source.Close() })
+
+		r, err := application_memory.NewRepository(source)
+		require.NoError(t, err)
+		return r
+	})
+}
+
+// slowRepository makes concurrent updates overlap
+type slowRepository struct {
+	application.Repository
+}
+
+func (r slowRepository) Update(ctx context.Context, params *application.UpdateParams) (*application.Application, error) {
+	time.Sleep(20 * time.Millisecond)
+	return r.Repository.Update(ctx, params)
+}
+
+func TestRepository_ConcurrentUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	source, err := application_embedded.NewRepository(application_embedded.InMemory)
+	require.NoError(t, err)
+	defer source.Close()
+
+	r, err := application_memory.NewRepository(slowRepository{Repository: source})
+	require.NoError(t, err)
+
+	app := &application.Application{
+		ID:        primitive.NewObjectID().Hex(),
+		UserID:    primitive.NewObjectID().Hex(),
+		Status:    application.StatusOpen,
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+		UpdatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	require.NoError(t, r.Create(ctx, app))
+
+	var (
+		statuses = []application.Status{application.StatusInProgress, application.StatusClosed}
+		results  = make([]*application.Application, len(statuses))
+		errs     = make([]error, len(statuses))
+		wg       sync.WaitGroup
+	)
+	for i, status := range statuses {
+		wg.Add(1)
+		go func(i int, status application.Status) {
+			defer wg.Done()
+			version := app.Version
+			results[i], errs[i] = r.Update(ctx, &application.UpdateParams{ID: app.ID, Status: status, ExpectedVersion: &version})
+		}(i, status)
+	}
+	wg.Wait()
+
+	var succeeded int
+	for i, err := range errs {
+		if err != nil {
+			assert.True(t, errors.Is(err, application.ErrPreconditionFailed), "unexpected error: %v", err)
+			continue
+		}
+		succeeded++
+		assert.Equal(t, statuses[i], results[i].Status)
+	}
+	assert.Equal(t, 1, succeeded, "errors: %v", errs)
+}
+
+func TestRepository_SetOlderVersion(t *testing.T) {
+	ctx := context.Background()
+
+	source, err := application_embedded.NewRepository(application_embedded.InMemory)
+	require.NoError(t, err)
+	defer source.Close()
+
+	r, err := application_memory.NewRepository(source)
+	require.NoError(t, err)
+
+	var (
+		v1 = application.Application{
+			ID:        primitive.NewObjectID().Hex(),
+			UserID:    primitive.NewObjectID().Hex(),
+			Status:    application.StatusOpen,
+			CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+			Version:   1,
+		}
+		v2 = v1
+	)
+	v2.Status, v2.Version = application.StatusInProgress, 2
+
+	// load which has read v1 stores it after update has stored v2
+	require.NoError(t, r.SetMultiple(ctx, v2))
+	require.NoError(t, r.SetMultiple(ctx, v1))
+
+	found, err := r.FindByID(ctx, v1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, v2, *found)
+
+	// deleted application isn't restored by older write
+	deletedAt := time.Now().UTC().Truncate(time.Millisecond)
+	v3 := v2
+	v3.DeletedAt, v3.Version = &deletedAt, 3
+	require.NoError(t, r.SetMultiple(ctx, v3))
+	require.NoError(t, r.SetMultiple(ctx, v2))
+
+	status := application.StatusInProgress
+	apps, err := r.FindByFilters(ctx, &application.GetByFilterParams{Status: &status, UserID: &v1.UserID})
+	require.NoError(t, err)
+	assert.Empty(t, apps)
+}
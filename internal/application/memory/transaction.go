@@ -0,0 +1,79 @@
+package application_memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+)
+
+type pendingKey struct{}
+
+// pending keeps applications changed in transaction,
+// they are put to cache only after commit, so cache never has rolled back changes
+type pending struct {
+	mu   sync.Mutex
+	apps map[string]application.Application
+}
+
+func pendingFromContext(ctx context.Context) *pending {
+	p, _ := ctx.Value(pendingKey{}).(*pending)
+	return p
+}
+
+func (p *pending) set(apps ...application.Application) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, app := range apps {
+		p.apps[app.ID] = app
+	}
+}
+
+func (p *pending) get(id string) (*application.Application, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	app, ok := p.apps[id]
+	return &app, ok
+}
+
+func (p *pending) list() []application.Application {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var apps = make([]application.Application, 0, len(p.apps))
+	for _, app := range p.apps {
+		apps = append(apps, app)
+	}
+	return apps
+}
+
+type transactor struct {
+	cache      *Repository
+	transactor application.Transactor
+}
+
+// NewTransactor wraps transactor of cached repository,
+// changes made in transaction are cached after successful commit only
+func NewTransactor(cache *Repository, t application.Transactor) application.Transactor {
+	return &transactor{
+		cache:      cache,
+		transactor: t,
+	}
+}
+
+func (t transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// nested call, changes will be cached by the outer one
+	if pendingFromContext(ctx) != nil {
+		return t.transactor.WithinTransaction(ctx, fn)
+	}
+
+	var p *pending
+	if err := t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// transaction could be retried, so changes of previous attempt are dropped
+		p = &pending{apps: make(map[string]application.Application)}
+		return fn(context.WithValue(ctx, pendingKey{}, p))
+	}); err != nil {
+		return err
+	}
+
+	return t.cache.store(p.list()...)
+}
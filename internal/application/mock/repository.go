@@ -7,6 +7,7 @@ package application_mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	application "github.com/PxyUp/backend_tech_task/internal/application"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,36 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Assign mocks base method.
+func (m *MockRepository) Assign(arg0 context.Context, arg1, arg2 string) (*application.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*application.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockRepositoryMockRecorder) Assign(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockRepository)(nil).Assign), arg0, arg1, arg2)
+}
+
+// CountByUser mocks base method.
+func (m *MockRepository) CountByUser(arg0 context.Context, arg1 string, arg2 time.Time) (*application.UserCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*application.UserCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUser indicates an expected call of CountByUser.
+func (mr *MockRepositoryMockRecorder) CountByUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUser", reflect.TypeOf((*MockRepository)(nil).CountByUser), arg0, arg1, arg2)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(arg0 context.Context, arg1 *application.Application) error {
 	m.ctrl.T.Helper()
@@ -49,6 +80,36 @@ func (mr *MockRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), arg0, arg1)
 }
 
+// CreateMany mocks base method.
+func (m *MockRepository) CreateMany(arg0 context.Context, arg1 []*application.Application) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", arg0, arg1)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockRepositoryMockRecorder) CreateMany(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockRepository)(nil).CreateMany), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(arg0 context.Context, arg1 string) (*application.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(*application.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), arg0, arg1)
+}
+
 // FindAll mocks base method.
 func (m *MockRepository) FindAll(arg0 context.Context) ([]application.Application, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +155,80 @@ func (mr *MockRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepository)(nil).FindByID), arg0, arg1)
 }
 
+// FindOverdue mocks base method.
+func (m *MockRepository) FindOverdue(arg0 context.Context, arg1 time.Time, arg2 int) ([]application.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOverdue", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]application.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOverdue indicates an expected call of FindOverdue.
+func (mr *MockRepositoryMockRecorder) FindOverdue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOverdue", reflect.TypeOf((*MockRepository)(nil).FindOverdue), arg0, arg1, arg2)
+}
+
+// LockUser mocks base method.
+func (m *MockRepository) LockUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUser indicates an expected call of LockUser.
+func (mr *MockRepositoryMockRecorder) LockUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUser", reflect.TypeOf((*MockRepository)(nil).LockUser), arg0, arg1)
+}
+
+// MarkBreached mocks base method.
+func (m *MockRepository) MarkBreached(arg0 context.Context, arg1 string, arg2 time.Time) (*application.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkBreached", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*application.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkBreached indicates an expected call of MarkBreached.
+func (mr *MockRepositoryMockRecorder) MarkBreached(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBreached", reflect.TypeOf((*MockRepository)(nil).MarkBreached), arg0, arg1, arg2)
+}
+
+// Purge mocks base method.
+func (m *MockRepository) Purge(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockRepositoryMockRecorder) Purge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockRepository)(nil).Purge), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockRepository) Restore(arg0 context.Context, arg1 string) (*application.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(*application.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockRepositoryMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockRepository)(nil).Restore), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(arg0 context.Context, arg1 *application.UpdateParams) (*application.Application, error) {
 	m.ctrl.T.Helper()
@@ -108,3 +243,18 @@ func (mr *MockRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), arg0, arg1)
 }
+
+// UpdateStatusMany mocks base method.
+func (m *MockRepository) UpdateStatusMany(arg0 context.Context, arg1 []string, arg2 application.Status, arg3 application.Reason, arg4 application.Deadlines) ([]application.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusMany", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]application.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatusMany indicates an expected call of UpdateStatusMany.
+func (mr *MockRepositoryMockRecorder) UpdateStatusMany(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusMany", reflect.TypeOf((*MockRepository)(nil).UpdateStatusMany), arg0, arg1, arg2, arg3, arg4)
+}
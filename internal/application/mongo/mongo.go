@@ -10,19 +10,90 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/PxyUp/backend_tech_task/internal/application"
 	"github.com/PxyUp/backend_tech_task/internal/external"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil"
 )
 
 type Repository struct {
 	coll *mongo.Collection
+	// usersColl keeps counter document of every user, it's changed by LockUser
+	usersColl *mongo.Collection
 }
 
-const collectionName = "applications"
+const (
+	collectionName      = "applications"
+	usersCollectionName = "application_users"
+)
+
+// Indexes are declared indexes of applications collection,
+// they cover search by filters and cache warming
+var Indexes = []mongoutil.Index{
+	{
+		Name: "user_id_status_created_at",
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+			{Key: "status", Value: 1},
+			{Key: "created_at", Value: 1},
+		},
+	},
+	{
+		Name: "status_updated_at",
+		Keys: bson.D{
+			{Key: "status", Value: 1},
+			{Key: "updated_at", Value: 1},
+		},
+	},
+	{
+		Name: "created_at",
+		Keys: bson.D{{Key: "created_at", Value: 1}},
+	},
+	{
+		Name: "updated_at",
+		Keys: bson.D{{Key: "updated_at", Value: 1}},
+	},
+	{
+		Name: "deleted_at",
+		Keys: bson.D{{Key: "deleted_at", Value: 1}},
+	},
+	{
+		Name: "assignee_id_status",
+		Keys: bson.D{
+			{Key: "assignee_id", Value: 1},
+			{Key: "status", Value: 1},
+		},
+	},
+	{
+		Name: "due_at",
+		Keys: bson.D{{Key: "due_at", Value: 1}},
+	},
+	{
+		Name: "reason_updated_at",
+		Keys: bson.D{
+			{Key: "reason", Value: 1},
+			{Key: "updated_at", Value: 1},
+		},
+	},
+}
+
+// notDeleted matches applications without tombstone, missing field is matched as well
+var notDeleted = bson.E{Key: "deleted_at", Value: nil}
 
 func NewRepository(db *mongo.Database) *Repository {
-	return &Repository{coll: db.Collection(collectionName)}
+	return &Repository{
+		coll:      db.Collection(collectionName),
+		usersColl: db.Collection(usersCollectionName),
+	}
+}
+
+func (r Repository) EnsureIndexes(ctx context.Context, mode mongoutil.IndexMode) error {
+	if _, err := mongoutil.EnsureIndexes(ctx, r.coll, Indexes, mode); err != nil {
+		return err
+	}
+	// collections can't be created implicitly in transactions before mongo 4.4
+	return mongoutil.EnsureCollection(ctx, r.usersColl)
 }
 
 func parseInsertedID(insertedID interface{}) (primitive.ObjectID, error) {
@@ -40,6 +111,9 @@ func (r Repository) Create(ctx context.Context, app *application.Application) er
 
 	res, err := r.coll.InsertOne(ctx, m)
 	if err != nil {
+		if mongoutil.IsDuplicateKeyError(err) {
+			return application.ErrApplicationAlreadyExists
+		}
 		return err
 	}
 
@@ -52,59 +126,133 @@ func (r Repository) Create(ctx context.Context, app *application.Application) er
 	return nil
 }
 
-func (r Repository) FindByID(ctx context.Context, id string) (*application.Application, error) {
-	mID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return nil, err
+func (r Repository) CreateMany(ctx context.Context, apps []*application.Application) ([]error, error) {
+	var (
+		errs = make([]error, len(apps))
+		docs = make([]interface{}, 0, len(apps))
+		// indexes are positions of docs in apps
+		indexes = make([]int, 0, len(apps))
+	)
+	for i, app := range apps {
+		m, err := NewApplicationModel(app)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		docs = append(docs, m)
+		indexes = append(indexes, i)
 	}
-
-	var m = new(ApplicationModel)
-	if err := r.coll.FindOne(ctx, bson.D{{Key: "_id", Value: mID}}).Decode(m); err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, application.ErrApplicationNotFound
+	// failed write aborts transaction, so existing applications are skipped before insert,
+	// concurrent insert of the same application fails transaction with write conflict
+	if mongo.SessionFromContext(ctx) != nil {
+		var err error
+		if docs, indexes, err = r.skipExisting(ctx, docs, indexes, errs); err != nil {
+			return nil, err
 		}
-		return nil, err
+	}
+	if len(docs) == 0 {
+		return errs, nil
 	}
 
-	return ParseApplicationModel(m)
+	// unordered insert keeps going after failed documents
+	_, err := r.coll.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err == nil {
+		return errs, nil
+	}
+
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil || len(bulkErr.WriteErrors) == 0 {
+		return nil, err
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		var itemErr error = writeErr
+		if writeErr.Code == mongoutil.DuplicateKeyCode {
+			itemErr = application.ErrApplicationAlreadyExists
+		}
+		errs[indexes[writeErr.Index]] = itemErr
+	}
+	return errs, nil
 }
 
-func (r Repository) FindAll(ctx context.Context) ([]application.Application, error) {
-	cur, err := r.coll.Find(ctx, bson.D{})
+// skipExisting removes documents of existing applications and sets their errors, indexes are positions of docs in errs
+func (r Repository) skipExisting(ctx context.Context, docs []interface{}, indexes []int, errs []error) ([]interface{}, []int, error) {
+	var ids = make(bson.A, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.(*ApplicationModel).ID
+	}
+
+	cur, err := r.coll.Find(
+		ctx,
+		bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}},
+		options.Find().SetProjection(bson.D{{Key: "_id", Value: 1}}),
+	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer func() {
 		if err := cur.Close(ctx); err != nil {
-			log.Err(err).Msg("couldn't close cursor after find all applications")
+			log.Err(err).Msg("couldn't close cursor after find existing applications")
 		}
 	}()
 
-	var apps []application.Application
+	var existing = make(map[primitive.ObjectID]bool, len(docs))
 	for cur.Next(ctx) {
-		var m ApplicationModel
-		err := cur.Decode(&m)
-		if err != nil {
-			return nil, err
+		var m struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cur.Decode(&m); err != nil {
+			return nil, nil, err
 		}
+		existing[m.ID] = true
+	}
+	if err := cur.Err(); err != nil {
+		return nil, nil, err
+	}
 
-		app, err := ParseApplicationModel(&m)
-		if err != nil {
-			return nil, err
+	var (
+		kept        = docs[:0]
+		keptIndexes = indexes[:0]
+	)
+	for i, doc := range docs {
+		id := doc.(*ApplicationModel).ID
+		if existing[id] {
+			errs[indexes[i]] = application.ErrApplicationAlreadyExists
+			continue
 		}
+		// the same application could be repeated in batch
+		existing[id] = true
+		kept = append(kept, doc)
+		keptIndexes = append(keptIndexes, indexes[i])
+	}
+	return kept, keptIndexes, nil
+}
 
-		apps = append(apps, *app)
+func (r Repository) FindByID(ctx context.Context, id string) (*application.Application, error) {
+	mID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := cur.Err(); err != nil {
+	var m = new(ApplicationModel)
+	if err := r.coll.FindOne(ctx, bson.D{{Key: "_id", Value: mID}}).Decode(m); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, application.ErrApplicationNotFound
+		}
 		return nil, err
 	}
 
-	return apps, nil
+	return ParseApplicationModel(m)
+}
+
+func (r Repository) FindAll(ctx context.Context) ([]application.Application, error) {
+	return r.find(ctx, bson.D{notDeleted})
 }
 
 func (r Repository) FindByFilters(ctx context.Context, params *application.GetByFilterParams) ([]application.Application, error) {
 	var filter bson.D
+	if !params.IncludeDeleted {
+		filter = append(filter, notDeleted)
+	}
 	if params.Status != nil {
 		filter = append(filter, bson.E{Key: "status", Value: params.Status.Int32()})
 	}
@@ -116,6 +264,23 @@ func (r Repository) FindByFilters(ctx context.Context, params *application.GetBy
 
 		filter = append(filter, bson.E{Key: "user_id", Value: mUserID})
 	}
+	if params.Reason != nil {
+		filter = append(filter, bson.E{Key: "reason", Value: *params.Reason})
+	}
+	if params.AssigneeID != nil {
+		mAssigneeID, err := primitive.ObjectIDFromHex(*params.AssigneeID)
+		if err != nil {
+			return nil, err
+		}
+
+		filter = append(filter, bson.E{Key: "assignee_id", Value: mAssigneeID})
+	}
+	if params.Unassigned {
+		filter = append(filter, bson.E{Key: "assignee_id", Value: nil})
+	}
+	if dueBefore := params.DueBound(time.Now().UTC()); dueBefore != nil {
+		filter = append(filter, bson.E{Key: "due_at", Value: bson.D{{Key: "$lt", Value: NewDateTime(*dueBefore)}}})
+	}
 	if params.UpdatedAt != nil {
 		filter = append(filter, bson.E{
 			Key: "updated_at",
@@ -134,14 +299,30 @@ func (r Repository) FindByFilters(ctx context.Context, params *application.GetBy
 			},
 		})
 	}
+	if params.AfterID != nil {
+		mAfterID, err := primitive.ObjectIDFromHex(*params.AfterID)
+		if err != nil {
+			return nil, err
+		}
 
-	cur, err := r.coll.Find(ctx, filter)
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$gt", Value: mAfterID}}})
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	if params.Limit > 0 {
+		opts.SetLimit(int64(params.Limit))
+	}
+	return r.find(ctx, filter, opts)
+}
+
+func (r Repository) find(ctx context.Context, filter bson.D, opts ...*options.FindOptions) ([]application.Application, error) {
+	cur, err := r.coll.Find(ctx, filter, opts...)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
 		if err := cur.Close(ctx); err != nil {
-			log.Err(err).Msg("couldn't close cursor after find applications by filter")
+			log.Err(err).Msg("couldn't close cursor after find applications")
 		}
 	}()
 
@@ -169,27 +350,346 @@ func (r Repository) FindByFilters(ctx context.Context, params *application.GetBy
 }
 
 func (r Repository) Update(ctx context.Context, params *application.UpdateParams) (*application.Application, error) {
-
 	mID, err := primitive.ObjectIDFromHex(params.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = r.coll.UpdateOne(
+	var filter = bson.D{{Key: "_id", Value: mID}, notDeleted}
+	if params.ExpectedStatus != nil {
+		filter = append(filter, bson.E{Key: "status", Value: params.ExpectedStatus.Int32()})
+	}
+	if params.ExpectedVersion != nil {
+		filter = append(filter, bson.E{Key: "version", Value: *params.ExpectedVersion})
+	}
+	if params.RequireAssignee {
+		filter = append(filter, bson.E{Key: "assignee_id", Value: bson.D{{Key: "$ne", Value: nil}}})
+	}
+
+	var m = new(ApplicationModel)
+	if err := r.coll.FindOneAndUpdate(
+		ctx,
+		filter,
+		setStatusPipeline(params.Status, params.Reason, params.Deadlines, time.Now().UTC()),
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(m); err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		return nil, r.checkPrecondition(ctx, mID, params)
+	}
+
+	return ParseApplicationModel(m)
+}
+
+// UpdateStatusMany changes applications by one UpdateMany, changed applications are tagged
+// by unique id of the operation to be found after update, the tag is removed after search
+func (r Repository) UpdateStatusMany(
+	ctx context.Context,
+	ids []string,
+	status application.Status,
+	reason application.Reason,
+	deadlines application.Deadlines,
+) ([]application.Application, error) {
+	var mIDs = make([]primitive.ObjectID, len(ids))
+	for i, id := range ids {
+		mID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, err
+		}
+		mIDs[i] = mID
+	}
+
+	var (
+		pipeline = setStatusPipeline(status, reason, deadlines, time.Now().UTC())
+		opID     = primitive.NewObjectID()
+	)
+	pipeline = append(pipeline, bson.D{{Key: "$set", Value: bson.D{{Key: "bulk_op_id", Value: opID}}}})
+	res, err := r.coll.UpdateMany(
 		ctx,
 		bson.D{
-			bson.E{Key: "_id", Value: mID}},
+			{Key: "_id", Value: bson.D{{Key: "$in", Value: mIDs}}},
+			{Key: "status", Value: bson.D{{Key: "$ne", Value: status.Int32()}}},
+			notDeleted,
+		},
+		pipeline,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if res.ModifiedCount == 0 {
+		return nil, nil
+	}
+
+	var tagged = bson.D{
+		{Key: "_id", Value: bson.D{{Key: "$in", Value: mIDs}}},
+		{Key: "bulk_op_id", Value: opID},
+	}
+	apps, err := r.find(ctx, tagged)
+	if err != nil {
+		return nil, err
+	}
+
+	// id of operation is needed for the search only
+	if _, err := r.coll.UpdateMany(ctx, tagged, bson.D{{Key: "$unset", Value: bson.D{{Key: "bulk_op_id", Value: ""}}}}); err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
+
+// setStatusPipeline keeps previous status in the same atomic update,
+// deadline is calculated by priority of application if status is changed
+func setStatusPipeline(status application.Status, reason application.Reason, deadlines application.Deadlines, now time.Time) bson.A {
+	var (
+		sameStatus = bson.D{{Key: "$eq", Value: bson.A{"$status", status.Int32()}}}
+		branches   bson.A
+	)
+	for _, priority := range []application.Priority{
+		application.PriorityUnspecified,
+		application.PriorityLow,
+		application.PriorityNormal,
+		application.PriorityHigh,
+		application.PriorityUrgent,
+	} {
+		var dueAt interface{}
+		if v := deadlines.DueAt(priority, now); v != nil {
+			dueAt = NewDateTime(*v)
+		}
+		branches = append(branches, bson.D{
+			{Key: "case", Value: bson.D{{Key: "$eq", Value: bson.A{
+				bson.D{{Key: "$ifNull", Value: bson.A{"$priority", application.PriorityUnspecified.Int32()}}},
+				priority.Int32(),
+			}}}},
+			{Key: "then", Value: dueAt},
+		})
+	}
+
+	return bson.A{
+		bson.D{{Key: "$set", Value: bson.D{
+			bson.E{Key: "previous_status", Value: "$status"},
+			bson.E{Key: "status", Value: status.Int32()},
+			bson.E{Key: "reason", Value: literal(reason.Code)},
+			bson.E{Key: "reason_comment", Value: literal(reason.Comment)},
+			bson.E{Key: "due_at", Value: bson.D{{Key: "$cond", Value: bson.A{
+				sameStatus,
+				"$due_at",
+				bson.D{{Key: "$switch", Value: bson.D{
+					{Key: "branches", Value: branches},
+					{Key: "default", Value: nil},
+				}}},
+			}}}},
+			bson.E{Key: "breached", Value: bson.D{{Key: "$cond", Value: bson.A{sameStatus, "$breached", false}}}},
+			bson.E{Key: "updated_at", Value: NewDateTime(now)},
+			bson.E{Key: "version", Value: bson.D{{Key: "$add", Value: bson.A{"$version", 1}}}},
+		}}},
+	}
+}
+
+// literal stops evaluation of value by pipeline, e.g. string "$user_id" of client would be path of field
+func literal(v interface{}) bson.D {
+	return bson.D{{Key: "$literal", Value: v}}
+}
+
+func (r Repository) Delete(ctx context.Context, id string) (*application.Application, error) {
+	var now = NewDateTime(time.Now().UTC())
+	return r.setDeletedAt(ctx, id, notDeleted, &now)
+}
+
+func (r Repository) Restore(ctx context.Context, id string) (*application.Application, error) {
+	return r.setDeletedAt(ctx, id, bson.E{Key: "deleted_at", Value: bson.D{{Key: "$ne", Value: nil}}}, nil)
+}
+
+func (r Repository) setDeletedAt(ctx context.Context, id string, condition bson.E, deletedAt *primitive.DateTime) (*application.Application, error) {
+	mID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var m = new(ApplicationModel)
+	if err := r.coll.FindOneAndUpdate(
+		ctx,
+		bson.D{{Key: "_id", Value: mID}, condition},
 		bson.D{
 			{Key: "$set", Value: bson.D{
-				bson.E{Key: "status", Value: params.Status},
+				bson.E{Key: "deleted_at", Value: deletedAt},
 				bson.E{Key: "updated_at", Value: NewDateTime(time.Now().UTC())},
 			}},
-		})
+			{Key: "$inc", Value: bson.D{
+				bson.E{Key: "version", Value: 1},
+			}},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(m); err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		return nil, r.checkPrecondition(ctx, mID, nil)
+	}
+
+	return ParseApplicationModel(m)
+}
+
+// Assign sets assignee of application, empty assignee is stored as null
+func (r Repository) Assign(ctx context.Context, id string, assigneeID string) (*application.Application, error) {
+	mID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var mAssigneeID *primitive.ObjectID
+	if assigneeID != "" {
+		v, err := primitive.ObjectIDFromHex(assigneeID)
+		if err != nil {
+			return nil, err
+		}
+		mAssigneeID = &v
+	}
+
+	var m = new(ApplicationModel)
+	if err := r.coll.FindOneAndUpdate(
+		ctx,
+		bson.D{{Key: "_id", Value: mID}, notDeleted},
+		bson.D{
+			{Key: "$set", Value: bson.D{
+				bson.E{Key: "assignee_id", Value: mAssigneeID},
+				bson.E{Key: "updated_at", Value: NewDateTime(time.Now().UTC())},
+			}},
+			{Key: "$inc", Value: bson.D{
+				bson.E{Key: "version", Value: 1},
+			}},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(m); err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		return nil, r.checkPrecondition(ctx, mID, nil)
+	}
+
+	return ParseApplicationModel(m)
+}
+
+func (r Repository) FindOverdue(ctx context.Context, now time.Time, limit int) ([]application.Application, error) {
+	return r.find(
+		ctx,
+		bson.D{
+			{Key: "due_at", Value: bson.D{{Key: "$lt", Value: NewDateTime(now)}}},
+			{Key: "breached", Value: bson.D{{Key: "$ne", Value: true}}},
+			notDeleted,
+		},
+		options.Find().SetSort(bson.D{{Key: "due_at", Value: 1}}).SetLimit(int64(limit)),
+	)
+}
+
+func (r Repository) MarkBreached(ctx context.Context, id string, dueAt time.Time) (*application.Application, error) {
+	mID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var m = new(ApplicationModel)
+	if err := r.coll.FindOneAndUpdate(
+		ctx,
+		bson.D{
+			{Key: "_id", Value: mID},
+			{Key: "due_at", Value: NewDateTime(dueAt)},
+			{Key: "breached", Value: bson.D{{Key: "$ne", Value: true}}},
+			notDeleted,
+		},
+		bson.D{
+			{Key: "$set", Value: bson.D{
+				bson.E{Key: "breached", Value: true},
+				bson.E{Key: "updated_at", Value: NewDateTime(time.Now().UTC())},
+			}},
+			{Key: "$inc", Value: bson.D{
+				bson.E{Key: "version", Value: 1},
+			}},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(m); err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		return nil, r.checkPrecondition(ctx, mID, nil)
+	}
+
+	return ParseApplicationModel(m)
+}
+
+func (r Repository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.coll.DeleteMany(ctx, bson.D{{Key: "deleted_at", Value: bson.D{
+		{Key: "$ne", Value: nil},
+		{Key: "$lt", Value: NewDateTime(before)},
+	}}})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+func (r Repository) CountByUser(ctx context.Context, userID string, createdSince time.Time) (*application.UserCounts, error) {
+	mUserID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := r.coll.CountDocuments(ctx, bson.D{
+		{Key: "user_id", Value: mUserID},
+		{Key: "status", Value: bson.D{{Key: "$in", Value: bson.A{
+			application.StatusOpen.Int32(),
+			application.StatusInProgress.Int32(),
+		}}}},
+		notDeleted,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.coll.CountDocuments(ctx, bson.D{
+		{Key: "user_id", Value: mUserID},
+		{Key: "created_at", Value: bson.D{{Key: "$gte", Value: NewDateTime(createdSince)}}},
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return r.FindByID(ctx, params.ID)
+	return &application.UserCounts{Active: int(active), Created: int(created)}, nil
+}
+
+// LockUser increments counter document of user, concurrent transaction changing the same document
+// fails with write conflict and is retried after commit of the first one
+func (r Repository) LockUser(ctx context.Context, userID string) error {
+	_, err := r.usersColl.UpdateOne(
+		ctx,
+		bson.D{{Key: "_id", Value: userID}},
+		bson.D{
+			{Key: "$inc", Value: bson.D{{Key: "locks", Value: 1}}},
+			{Key: "$set", Value: bson.D{{Key: "locked_at", Value: NewDateTime(time.Now().UTC())}}},
+		},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// checkPrecondition explains why conditional update hasn't matched application,
+// params are nil for updates without predicates
+func (r Repository) checkPrecondition(ctx context.Context, id primitive.ObjectID, params *application.UpdateParams) error {
+	var m = new(ApplicationModel)
+	if err := r.coll.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(m); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return application.ErrApplicationNotFound
+		}
+		return err
+	}
+	if m.DeletedAt != nil {
+		return application.ErrApplicationDeleted
+	}
+	if params != nil && params.RequireAssignee && m.AssigneeID == nil &&
+		(params.ExpectedStatus == nil || params.ExpectedStatus.Int32() == m.Status) &&
+		(params.ExpectedVersion == nil || *params.ExpectedVersion == m.Version) {
+		return application.ErrAssigneeRequired
+	}
+	return application.ErrPreconditionFailed
 }
 
 type ApplicationModel struct {
@@ -199,14 +699,24 @@ type ApplicationModel struct {
 	CreatedAt      primitive.DateTime `bson:"created_at"`
 	UpdatedAt      primitive.DateTime `bson:"updated_at"`
 	ExternalStatus int32              `bson:"external_status"`
+	Version        int64              `bson:"version"`
+	PreviousStatus int32              `bson:"previous_status"`
+	// DeletedAt is tombstone of soft deleted application
+	DeletedAt     *primitive.DateTime `bson:"deleted_at"`
+	Reason        string              `bson:"reason,omitempty"`
+	ReasonComment string              `bson:"reason_comment,omitempty"`
+	AssigneeID    *primitive.ObjectID `bson:"assignee_id,omitempty"`
+	Priority      int32               `bson:"priority"`
+	DueAt         *primitive.DateTime `bson:"due_at,omitempty"`
+	Breached      bool                `bson:"breached,omitempty"`
 }
 
 func NewDateTime(t time.Time) primitive.DateTime {
-	return primitive.DateTime(t.Unix())
+	return primitive.NewDateTimeFromTime(t)
 }
 
 func ParseDateTime(t primitive.DateTime) time.Time {
-	return time.Unix(int64(t), 0).UTC()
+	return t.Time().UTC()
 }
 
 func ParseApplicationStatus(v int32) (application.Status, error) {
@@ -226,9 +736,30 @@ func NewApplicationModel(app *application.Application) (*ApplicationModel, error
 			CreatedAt:      NewDateTime(app.CreatedAt),
 			UpdatedAt:      NewDateTime(app.UpdatedAt),
 			ExternalStatus: app.ExternalStatus.Int32(),
+			Version:        app.Version,
+			PreviousStatus: app.PreviousStatus.Int32(),
+			Reason:         app.Reason.Code,
+			ReasonComment:  app.Reason.Comment,
+			Priority:       app.Priority.Int32(),
+			Breached:       app.Breached,
 		}
 		err error
 	)
+	if app.DeletedAt != nil {
+		deletedAt := NewDateTime(*app.DeletedAt)
+		m.DeletedAt = &deletedAt
+	}
+	if app.DueAt != nil {
+		dueAt := NewDateTime(*app.DueAt)
+		m.DueAt = &dueAt
+	}
+	if app.AssigneeID != "" {
+		assigneeID, err := primitive.ObjectIDFromHex(app.AssigneeID)
+		if err != nil {
+			return nil, err
+		}
+		m.AssigneeID = &assigneeID
+	}
 	m.ID, err = primitive.ObjectIDFromHex(app.ID)
 	if err != nil {
 		return nil, err
@@ -245,13 +776,32 @@ func NewApplicationModel(app *application.Application) (*ApplicationModel, error
 func ParseApplicationModel(m *ApplicationModel) (*application.Application, error) {
 	var (
 		a = &application.Application{
-			ID:        m.ID.Hex(),
-			UserID:    m.UserID.Hex(),
-			CreatedAt: ParseDateTime(m.CreatedAt),
-			UpdatedAt: ParseDateTime(m.UpdatedAt),
+			ID:             m.ID.Hex(),
+			UserID:         m.UserID.Hex(),
+			CreatedAt:      ParseDateTime(m.CreatedAt),
+			UpdatedAt:      ParseDateTime(m.UpdatedAt),
+			Version:        m.Version,
+			PreviousStatus: application.NewStatus(m.PreviousStatus),
+			Reason: application.Reason{
+				Code:    m.Reason,
+				Comment: m.ReasonComment,
+			},
+			Priority: application.NewPriority(m.Priority),
+			Breached: m.Breached,
 		}
 		err error
 	)
+	if m.DeletedAt != nil {
+		deletedAt := ParseDateTime(*m.DeletedAt)
+		a.DeletedAt = &deletedAt
+	}
+	if m.AssigneeID != nil {
+		a.AssigneeID = m.AssigneeID.Hex()
+	}
+	if m.DueAt != nil {
+		dueAt := ParseDateTime(*m.DueAt)
+		a.DueAt = &dueAt
+	}
 	a.Status, err = ParseApplicationStatus(m.Status)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,212 @@
+package application_mongo_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	application_conformance "github.com/PxyUp/backend_tech_task/internal/application/conformance"
+	application_mongo "github.com/PxyUp/backend_tech_task/internal/application/mongo"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil/mongotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRepository(t *testing.T) {
+	var (
+		client = mongotest.NewClient(t)
+		n      int
+	)
+
+	application_conformance.RunRepositoryTests(t, func() application.Repository {
+		n++
+		db := mongotest.NewDB(t, client, fmt.Sprintf("test_applications_%d", n))
+
+		r := application_mongo.NewRepository(db)
+		require.NoError(t, r.EnsureIndexes(context.Background(), mongoutil.IndexModeSync))
+		return r
+	})
+}
+
+// TestRepository_WithinTransaction runs every call of repository in transaction,
+// it's skipped by standalone mongo
+func TestRepository_WithinTransaction(t *testing.T) {
+	var (
+		client = mongotest.NewClient(t)
+		n      int
+	)
+	mongotest.SkipWithoutTransactions(t, client)
+
+	application_conformance.RunRepositoryTests(t, func() application.Repository {
+		n++
+		db := mongotest.NewDB(t, client, fmt.Sprintf("test_applications_tx_%d", n))
+
+		r := application_mongo.NewRepository(db)
+		require.NoError(t, r.EnsureIndexes(context.Background(), mongoutil.IndexModeSync))
+		return &transactionalRepository{repository: r, transactor: application_mongo.NewTransactor(db)}
+	})
+}
+
+// transactionalRepository runs every call of repository in own transaction
+type transactionalRepository struct {
+	repository application.Repository
+	transactor application.Transactor
+}
+
+func (r *transactionalRepository) Create(ctx context.Context, app *application.Application) error {
+	return r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return r.repository.Create(ctx, app)
+	})
+}
+
+func (r *transactionalRepository) CreateMany(ctx context.Context, apps []*application.Application) (errs []error, err error) {
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		errs, err = r.repository.CreateMany(ctx, apps)
+		return err
+	})
+	return errs, err
+}
+
+func (r *transactionalRepository) Update(ctx context.Context, params *application.UpdateParams) (app *application.Application, err error) {
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		app, err = r.repository.Update(ctx, params)
+		return err
+	})
+	return app, err
+}
+
+func (r *transactionalRepository) UpdateStatusMany(
+	ctx context.Context,
+	ids []string,
+	status application.Status,
+	reason application.Reason,
+	deadlines application.Deadlines,
+) (apps []application.Application, err error) {
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		apps, err = r.repository.UpdateStatusMany(ctx, ids, status, reason, deadlines)
+		return err
+	})
+	return apps, err
+}
+
+func (r *transactionalRepository) Assign(ctx context.Context, id string, assigneeID string) (*application.Application, error) {
+	return r.change(ctx, func(ctx context.Context) (*application.Application, error) {
+		return r.repository.Assign(ctx, id, assigneeID)
+	})
+}
+
+func (r *transactionalRepository) Delete(ctx context.Context, id string) (*application.Application, error) {
+	return r.change(ctx, func(ctx context.Context) (*application.Application, error) {
+		return r.repository.Delete(ctx, id)
+	})
+}
+
+func (r *transactionalRepository) Restore(ctx context.Context, id string) (*application.Application, error) {
+	return r.change(ctx, func(ctx context.Context) (*application.Application, error) {
+		return r.repository.Restore(ctx, id)
+	})
+}
+
+func (r *transactionalRepository) MarkBreached(ctx context.Context, id string, dueAt time.Time) (*application.Application, error) {
+	return r.change(ctx, func(ctx context.Context) (*application.Application, error) {
+		return r.repository.MarkBreached(ctx, id, dueAt)
+	})
+}
+
+func (r *transactionalRepository) change(
+	ctx context.Context,
+	fn func(ctx context.Context) (*application.Application, error),
+) (app *application.Application, err error) {
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		app, err = fn(ctx)
+		return err
+	})
+	return app, err
+}
+
+func (r *transactionalRepository) FindOverdue(ctx context.Context, now time.Time, limit int) (apps []application.Application, err error) {
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		apps, err = r.repository.FindOverdue(ctx, now, limit)
+		return err
+	})
+	return apps, err
+}
+
+func (r *transactionalRepository) Purge(ctx context.Context, before time.Time) (n int64, err error) {
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		n, err = r.repository.Purge(ctx, before)
+		return err
+	})
+	return n, err
+}
+
+func (r *transactionalRepository) FindByID(ctx context.Context, id string) (*application.Application, error) {
+	return r.change(ctx, func(ctx context.Context) (*application.Application, error) {
+		return r.repository.FindByID(ctx, id)
+	})
+}
+
+func (r *transactionalRepository) FindByFilters(
+	ctx context.Context,
+	filter *application.GetByFilterParams,
+) (apps []application.Application, err error) {
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		apps, err = r.repository.FindByFilters(ctx, filter)
+		return err
+	})
+	return apps, err
+}
+
+func (r *transactionalRepository) FindAll(ctx context.Context) (apps []application.Application, err error) {
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		apps, err = r.repository.FindAll(ctx)
+		return err
+	})
+	return apps, err
+}
+
+func (r *transactionalRepository) CountByUser(
+	ctx context.Context,
+	userID string,
+	createdSince time.Time,
+) (counts *application.UserCounts, err error) {
+	err = r.transactor.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		counts, err = r.repository.CountByUser(ctx, userID, createdSince)
+		return err
+	})
+	return counts, err
+}
+
+func (r *transactionalRepository) LockUser(ctx context.Context, userID string) error {
+	return r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return r.repository.LockUser(ctx, userID)
+	})
+}
+
+func TestRepository_UpdateStatusManyDoesNotKeepTag(t *testing.T) {
+	var (
+		ctx = context.Background()
+		db  = mongotest.NewDB(t, mongotest.NewClient(t), "test_applications_bulk")
+		r   = application_mongo.NewRepository(db)
+		app = &application.Application{
+			ID:     primitive.NewObjectID().Hex(),
+			UserID: primitive.NewObjectID().Hex(),
+			Status: application.StatusOpen,
+		}
+	)
+	require.NoError(t, r.Create(ctx, app))
+
+	updated, err := r.UpdateStatusMany(ctx, []string{app.ID}, application.StatusClosed, application.Reason{Code: "duplicate"}, nil)
+	require.NoError(t, err)
+	require.Len(t, updated, 1)
+
+	tagged, err := db.Collection("applications").CountDocuments(ctx, bson.D{{Key: "bulk_op_id", Value: bson.D{{Key: "$exists", Value: true}}}})
+	require.NoError(t, err)
+	assert.Zero(t, tagged)
+}
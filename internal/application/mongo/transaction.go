@@ -0,0 +1,36 @@
+package application_mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Transactor runs callbacks in mongo transactions, repositories join them through ctx.
+// Transactions are supported by replica sets and sharded clusters only.
+type Transactor struct {
+	client *mongo.Client
+}
+
+func NewTransactor(db *mongo.Database) *Transactor {
+	return &Transactor{client: db.Client()}
+}
+
+func (t Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// ctx already has session, so it's nested call
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(context.Background())
+
+	// fn could be retried by driver on transient transaction errors
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+	"time"
+)
+
+type PurgeConfig struct {
+	// Retention is how long deleted applications are kept before they are removed
+	Retention time.Duration `envconfig:"retention"`
+	Interval  time.Duration `envconfig:"interval"`
+}
+
+const (
+	defaultPurgeRetention = 30 * 24 * time.Hour
+	defaultPurgeInterval  = time.Hour
+)
+
+// Purger periodically removes applications deleted earlier than retention
+type Purger struct {
+	cfg        PurgeConfig
+	repository Repository
+}
+
+func NewPurger(cfg PurgeConfig, repository Repository) *Purger {
+	if cfg.Retention == 0 {
+		cfg.Retention = defaultPurgeRetention
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = defaultPurgeInterval
+	}
+	return &Purger{cfg: cfg, repository: repository}
+}
+
+// Run purges applications until ctx is done
+func (p *Purger) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := p.PurgeOnce(ctx); err != nil {
+				logger(ctx).Err(err).Msg("couldn't purge deleted applications")
+			}
+		}
+	}
+}
+
+func (p *Purger) PurgeOnce(ctx context.Context) (int64, error) {
+	n, err := p.repository.Purge(ctx, time.Now().UTC().Add(-p.cfg.Retention))
+	if err != nil {
+		return 0, err
+	}
+	if n != 0 {
+		logger(ctx).Info().Int64("count", n).Msg("deleted applications have been purged")
+	}
+	return n, nil
+}
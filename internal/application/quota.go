@@ -0,0 +1,152 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrQuotaExceeded = fmt.Errorf("quota of applications is exceeded")
+
+const (
+	// QuotaActive limits open and in progress applications of user
+	QuotaActive = "active"
+	// QuotaCreated limits applications created by user within window
+	QuotaCreated = "created"
+
+	defaultQuotaWindow = 24 * time.Hour
+)
+
+// QuotaConfig limits applications of every user, zero limit means no limit
+type QuotaConfig struct {
+	MaxActive  int           `envconfig:"max_active"`
+	MaxCreated int           `envconfig:"max_created"`
+	Window     time.Duration `envconfig:"window"`
+}
+
+// Enabled reports whether any limit is set
+func (cfg QuotaConfig) Enabled() bool {
+	return cfg.MaxActive > 0 || cfg.MaxCreated > 0
+}
+
+// QuotaExceededError describes violated quota of user
+type QuotaExceededError struct {
+	UserID string
+	Quota  string
+	Limit  int
+	Usage  int
+	// Window is set for QuotaCreated only
+	Window time.Duration
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s: %s applications of user %s: limit %d, usage %d", ErrQuotaExceeded, e.Quota, e.UserID, e.Limit, e.Usage)
+}
+
+func (e *QuotaExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// WithQuota limits applications created by every user
+func WithQuota(cfg QuotaConfig) ServiceOption {
+	return func(svc *service) {
+		if cfg.Window == 0 {
+			cfg.Window = defaultQuotaWindow
+		}
+		svc.quota = cfg
+	}
+}
+
+// userLocks serializes creation of applications of the same user within process,
+// repository lock does the same across replicas
+type userLocks [64]sync.Mutex
+
+// lock locks every user and returns function releasing them
+func (l *userLocks) lock(userIDs ...string) (unlock func()) {
+	var (
+		seen    = make(map[int]bool, len(userIDs))
+		indexes []int
+	)
+	for _, userID := range userIDs {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(userID))
+		i := int(h.Sum32() % uint32(len(l)))
+		if !seen[i] {
+			seen[i] = true
+			indexes = append(indexes, i)
+		}
+	}
+	// the same order of locking prevents deadlocks
+	sort.Ints(indexes)
+	for _, i := range indexes {
+		l[i].Lock()
+	}
+	return func() {
+		for _, i := range indexes {
+			l[i].Unlock()
+		}
+	}
+}
+
+// checkQuota returns error for every application which exceeds quota of its user,
+// it should be called in transaction which creates applications
+func (svc service) checkQuota(ctx context.Context, apps []*Application, now time.Time) ([]error, error) {
+	var errs = make([]error, len(apps))
+	if !svc.quota.Enabled() {
+		return errs, nil
+	}
+
+	var counts = make(map[string]*UserCounts)
+	for i, app := range apps {
+		userCounts, ok := counts[app.UserID]
+		if !ok {
+			if err := svc.repository.LockUser(ctx, app.UserID); err != nil {
+				return nil, err
+			}
+			var err error
+			if userCounts, err = svc.repository.CountByUser(ctx, app.UserID, now.Add(-svc.quota.Window)); err != nil {
+				return nil, err
+			}
+			counts[app.UserID] = userCounts
+		}
+
+		if svc.quota.MaxActive > 0 && userCounts.Active >= svc.quota.MaxActive {
+			errs[i] = &QuotaExceededError{UserID: app.UserID, Quota: QuotaActive, Limit: svc.quota.MaxActive, Usage: userCounts.Active}
+			continue
+		}
+		if svc.quota.MaxCreated > 0 && userCounts.Created >= svc.quota.MaxCreated {
+			errs[i] = &QuotaExceededError{
+				UserID: app.UserID,
+				Quota:  QuotaCreated,
+				Limit:  svc.quota.MaxCreated,
+				Usage:  userCounts.Created,
+				Window: svc.quota.Window,
+			}
+			continue
+		}
+		// next applications of batch see this one
+		userCounts.Active++
+		userCounts.Created++
+	}
+	return errs, nil
+}
+
+// lockUsers takes process lock of users of applications if quota is enabled
+func (svc service) lockUsers(apps ...*Application) (unlock func()) {
+	if !svc.quota.Enabled() {
+		return func() {}
+	}
+	var userIDs = make([]string, len(apps))
+	for i, app := range apps {
+		userIDs[i] = app.UserID
+	}
+	return svc.userLocks.lock(userIDs...)
+}
+
+func isQuotaError(err error) bool {
+	return errors.Is(err, ErrQuotaExceeded)
+}
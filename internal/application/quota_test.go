@@ -0,0 +1,128 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	application_embedded "github.com/PxyUp/backend_tech_task/internal/application/embedded"
+	"github.com/PxyUp/backend_tech_task/internal/external"
+	external_mock "github.com/PxyUp/backend_tech_task/internal/external/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Quota(t *testing.T) {
+	var (
+		userID = "603bd5e5967f2dba00c8e325"
+		other  = "603bd5e5967f2dba00c8e326"
+	)
+
+	var cases = map[string]struct {
+		Quota application.QuotaConfig
+		// Existing are statuses of applications of user created before
+		Existing []application.Status
+
+		ExpQuota string
+		ExpUsage int
+	}{
+		"success": {
+			Quota:    application.QuotaConfig{MaxActive: 2, MaxCreated: 3},
+			Existing: []application.Status{application.StatusOpen},
+		},
+		"success_closed_are_not_active": {
+			Quota:    application.QuotaConfig{MaxActive: 1},
+			Existing: []application.Status{application.StatusClosed, application.StatusClosed},
+		},
+		"failed_active": {
+			Quota:    application.QuotaConfig{MaxActive: 2},
+			Existing: []application.Status{application.StatusOpen, application.StatusInProgress},
+			ExpQuota: application.QuotaActive,
+			ExpUsage: 2,
+		},
+		"failed_created": {
+			Quota:    application.QuotaConfig{MaxCreated: 2, Window: time.Hour},
+			Existing: []application.Status{application.StatusClosed, application.StatusClosed},
+			ExpQuota: application.QuotaCreated,
+			ExpUsage: 2,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+
+			repository, err := application_embedded.NewRepository(application_embedded.InMemory)
+			require.NoError(t, err)
+			defer repository.Close()
+
+			for i, status := range c.Existing {
+				require.NoError(t, repository.Create(ctx, &application.Application{
+					ID:        fmt.Sprintf("603bd5e5967f2dba00c8e4%02d", i),
+					UserID:    userID,
+					Status:    status,
+					CreatedAt: time.Now().UTC().Add(-time.Minute),
+				}))
+			}
+
+			externalClient := external_mock.NewMockClient(ctrl)
+			externalClient.EXPECT().GetExternalStatus(gomock.Any(), gomock.Any()).Return(external.StatusProcessed, nil).AnyTimes()
+
+			svc := application.NewService(repository, externalClient, application.WithQuota(c.Quota))
+
+			_, err = svc.Create(ctx, &application.CreateParams{UserID: userID})
+			if c.ExpQuota == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var quotaErr *application.QuotaExceededError
+			require.True(t, errors.As(err, &quotaErr), "unexpected error: %v", err)
+			assert.Equal(t, c.ExpQuota, quotaErr.Quota)
+			assert.Equal(t, c.ExpUsage, quotaErr.Usage)
+
+			// quota of another user isn't affected
+			_, err = svc.Create(ctx, &application.CreateParams{UserID: other})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_CreateMany_Quota(t *testing.T) {
+	var userID = "603bd5e5967f2dba00c8e325"
+
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repository, err := application_embedded.NewRepository(application_embedded.InMemory)
+	require.NoError(t, err)
+	defer repository.Close()
+
+	externalClient := external_mock.NewMockClient(ctrl)
+	externalClient.EXPECT().GetExternalStatus(gomock.Any(), gomock.Any()).Return(external.StatusProcessed, nil).AnyTimes()
+
+	svc := application.NewService(repository, externalClient, application.WithQuota(application.QuotaConfig{MaxActive: 2}))
+
+	results, err := svc.CreateMany(ctx, &application.CreateManyParams{
+		UserIDs: []string{userID, userID, userID},
+		Mode:    application.BatchModeBestEffort,
+	})
+	require.NoError(t, err)
+	assert.NoError(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+	assert.True(t, errors.Is(results[2].Err, application.ErrQuotaExceeded), "unexpected error: %v", results[2].Err)
+
+	results, err = svc.CreateMany(ctx, &application.CreateManyParams{UserIDs: []string{userID}})
+	require.NoError(t, err)
+	assert.True(t, errors.Is(results[0].Err, application.ErrQuotaExceeded), "unexpected error: %v", results[0].Err)
+
+	apps, err := repository.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, apps, 2)
+}
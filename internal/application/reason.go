@@ -0,0 +1,79 @@
+package application
+
+import (
+	"fmt"
+)
+
+const maxReasonCommentLength = 1000
+
+var (
+	defaultCloseReasons  = []string{"resolved", "rejected", "duplicate", "abandoned"}
+	defaultReopenReasons = []string{"new_information", "customer_request", "closed_by_mistake"}
+)
+
+// ReasonConfig lists allowed codes of reasons of status updates
+type ReasonConfig struct {
+	// Close are reasons of closing, one of them is required for moving application to closed status
+	Close []string `envconfig:"close"`
+	// Reopen are optional reasons of moving application to open or in progress status
+	Reopen []string `envconfig:"reopen"`
+}
+
+func (cfg ReasonConfig) withDefaults() ReasonConfig {
+	if len(cfg.Close) == 0 {
+		cfg.Close = defaultCloseReasons
+	}
+	if len(cfg.Reopen) == 0 {
+		cfg.Reopen = defaultReopenReasons
+	}
+	// reason of automatic closing is allowed with any config
+	if !contains(cfg.Close, ReasonAutoClosedStale) {
+		cfg.Close = append(cfg.Close[:len(cfg.Close):len(cfg.Close)], ReasonAutoClosedStale)
+	}
+	return cfg
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// WithReasons sets allowed reasons of status updates, empty lists are replaced by default ones
+func WithReasons(cfg ReasonConfig) ServiceOption {
+	return func(svc *service) {
+		svc.reasons = cfg.withDefaults()
+	}
+}
+
+func (r Reason) Validate() error {
+	if r.Code == "" && r.Comment != "" {
+		return fmt.Errorf("%w: comment requires reason", ErrInvalidArgument)
+	}
+	if len(r.Comment) > maxReasonCommentLength {
+		return fmt.Errorf("%w: comment should contain at most %d characters", ErrInvalidArgument, maxReasonCommentLength)
+	}
+	return nil
+}
+
+// check validates reason of moving application to status
+func (cfg ReasonConfig) check(status Status, reason Reason) error {
+	var allowed = cfg.Reopen
+	if status == StatusClosed {
+		if reason.Code == "" {
+			return fmt.Errorf("%w: reason is required for closing", ErrInvalidArgument)
+		}
+		allowed = cfg.Close
+	}
+	if reason.Code == "" {
+		return nil
+	}
+
+	if contains(allowed, reason.Code) {
+		return nil
+	}
+	return fmt.Errorf("%w: unknown reason %q for %s status, allowed reasons: %v", ErrInvalidArgument, reason.Code, status, allowed)
+}
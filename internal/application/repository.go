@@ -2,13 +2,47 @@ package application
 
 import (
 	"context"
+	"time"
 )
 
 //go:generate mockgen -destination=mock/repository.go -package=application_mock "github.com/PxyUp/backend_tech_task/internal/application" Repository
 type Repository interface {
 	Create(ctx context.Context, application *Application) error
+	// CreateMany inserts every application it can, it returns error of every item in order of applications,
+	// error is returned if nothing has been inserted because of failure of repository
+	CreateMany(ctx context.Context, applications []*Application) ([]error, error)
 	Update(ctx context.Context, params *UpdateParams) (*Application, error)
+	// UpdateStatusMany sets status, reason and deadline of applications by ids and returns changed ones,
+	// deleted applications and applications which already have status are skipped
+	UpdateStatusMany(ctx context.Context, ids []string, status Status, reason Reason, deadlines Deadlines) ([]Application, error)
+	// Assign sets assignee of application, empty assignee unassigns it
+	Assign(ctx context.Context, id string, assigneeID string) (*Application, error)
+	// Delete sets tombstone of application, deleted application can't be updated
+	Delete(ctx context.Context, id string) (*Application, error)
+	Restore(ctx context.Context, id string) (*Application, error)
+	// FindOverdue returns at most limit alive applications which have passed deadline before now
+	// and haven't been marked as breached yet, applications with the earliest deadline are returned first
+	FindOverdue(ctx context.Context, now time.Time, limit int) ([]Application, error)
+	// MarkBreached sets breached flag of application, it fails with ErrPreconditionFailed
+	// if deadline of application has been changed or application has been already marked
+	MarkBreached(ctx context.Context, id string, dueAt time.Time) (*Application, error)
+	// Purge removes applications deleted before time and returns their count
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	// FindByID returns deleted applications as well
 	FindByID(ctx context.Context, id string) (*Application, error)
 	FindByFilters(ctx context.Context, filter *GetByFilterParams) ([]Application, error)
+	// FindAll returns all applications except deleted ones
 	FindAll(ctx context.Context) ([]Application, error)
+	// CountByUser counts active applications of user and applications created since time,
+	// deleted applications are counted as created only
+	CountByUser(ctx context.Context, userID string, createdSince time.Time) (*UserCounts, error)
+	// LockUser serializes transactions creating applications of the same user,
+	// lock is held until end of transaction from ctx
+	LockUser(ctx context.Context, userID string) error
+}
+
+type UserCounts struct {
+	// Active is number of open and in progress applications
+	Active  int
+	Created int
 }
@@ -0,0 +1,164 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/PxyUp/backend_tech_task/internal/external"
+)
+
+// Rule moves application matching all conditions of When to status of Then, e.g.
+// {"name": "close_processed", "when": {"external_status": "processed", "status": "in_progress"},
+// "then": {"status": "closed", "reason": "resolved"}}
+type Rule struct {
+	Name string        `json:"name"`
+	When RuleCondition `json:"when"`
+	Then RuleAction    `json:"then"`
+}
+
+// RuleCondition matches application, empty fields match any value
+type RuleCondition struct {
+	Status         string `json:"status"`
+	ExternalStatus string `json:"external_status"`
+	Priority       string `json:"priority"`
+}
+
+type RuleAction struct {
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+	Comment string `json:"comment"`
+}
+
+// Rules are decoded by envconfig from json array, they are evaluated in order
+type Rules []Rule
+
+func (r *Rules) Decode(value string) error {
+	return json.Unmarshal([]byte(value), (*[]Rule)(r))
+}
+
+// Validate checks rules against reasons of status updates
+func (r Rules) Validate(reasons ReasonConfig) error {
+	_, err := r.compile(reasons.withDefaults())
+	return err
+}
+
+// rule is parsed Rule, unspecified values match any value
+type rule struct {
+	name           string
+	status         Status
+	externalStatus external.Status
+	priority       Priority
+	target         Status
+	reason         Reason
+}
+
+func (r Rules) compile(reasons ReasonConfig) ([]rule, error) {
+	var (
+		res   = make([]rule, len(r))
+		names = make(map[string]bool, len(r))
+	)
+	for i, raw := range r {
+		if raw.Name == "" {
+			return nil, fmt.Errorf("rule %d doesn't have name", i)
+		}
+		if names[raw.Name] {
+			return nil, fmt.Errorf("rule %q is duplicated", raw.Name)
+		}
+		names[raw.Name] = true
+
+		compiled, err := raw.compile(reasons)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %q: %w", raw.Name, err)
+		}
+		res[i] = compiled
+	}
+	return res, nil
+}
+
+func (r Rule) compile(reasons ReasonConfig) (rule, error) {
+	var (
+		res = rule{name: r.Name, reason: Reason{Code: r.Then.Reason, Comment: r.Then.Comment}}
+		err error
+	)
+	if r.When == (RuleCondition{}) {
+		return rule{}, fmt.Errorf("rule should have at least one condition")
+	}
+	if r.When.Status != "" {
+		if res.status, err = parseStatus(r.When.Status); err != nil {
+			return rule{}, err
+		}
+	}
+	if r.When.ExternalStatus != "" {
+		if res.externalStatus, err = external.NewStatusString(r.When.ExternalStatus); err != nil {
+			return rule{}, err
+		}
+	}
+	if r.When.Priority != "" {
+		if res.priority, err = ParsePriority(r.When.Priority); err != nil {
+			return rule{}, err
+		}
+	}
+
+	if res.target, err = parseStatus(r.Then.Status); err != nil {
+		return rule{}, err
+	}
+	if res.target == res.status {
+		return rule{}, fmt.Errorf("rule should change status")
+	}
+	if err := res.reason.Validate(); err != nil {
+		return rule{}, err
+	}
+	if err := reasons.check(res.target, res.reason); err != nil {
+		return rule{}, err
+	}
+	return res, nil
+}
+
+func (r rule) match(app *Application) bool {
+	return app.Status != r.target &&
+		(r.status == StatusUnspecified || app.Status == r.status) &&
+		(r.externalStatus == external.StatusUnspecified || app.ExternalStatus == r.externalStatus) &&
+		(r.priority == PriorityUnspecified || app.Priority == r.priority)
+}
+
+// WithRules sets rules of automatic transitions, rules should be validated before,
+// invalid rules are dropped
+func WithRules(rules Rules) ServiceOption {
+	return func(svc *service) {
+		svc.rawRules = rules
+	}
+}
+
+// applyRules moves application by the first matching rule in transaction of ctx,
+// it returns nil if no rule has fired, at most one rule fires per change
+func (svc service) applyRules(ctx context.Context, app *Application) (*Application, error) {
+	for _, r := range svc.rules {
+		if !r.match(app) {
+			continue
+		}
+
+		version := app.Version
+		updated, err := svc.repository.Update(ctx, svc.withStatusPolicies(&UpdateParams{
+			ID:              app.ID,
+			Status:          r.target,
+			Reason:          r.reason,
+			ExpectedVersion: &version,
+		}))
+		if err != nil {
+			if errors.Is(err, ErrAssigneeRequired) || errors.Is(err, ErrPreconditionFailed) {
+				logger(ctx).Warn().Err(err).Str("rule", r.name).Str("id", app.ID).Msg("rule couldn't be applied")
+				return nil, nil
+			}
+			return nil, err
+		}
+		if err := svc.appendEvent(ctx, EventApplicationStatusChanged, updated); err != nil {
+			return nil, err
+		}
+
+		logger(ctx).Info().Str("rule", r.name).Str("id", app.ID).Msgf("rule has moved application to %s status", r.target)
+		return updated, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,173 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	application_embedded "github.com/PxyUp/backend_tech_task/internal/application/embedded"
+	"github.com/PxyUp/backend_tech_task/internal/external"
+	external_mock "github.com/PxyUp/backend_tech_task/internal/external/mock"
+	outbox_memory "github.com/PxyUp/backend_tech_task/internal/outbox/memory"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules_Decode(t *testing.T) {
+	var rules application.Rules
+	require.NoError(t, rules.Decode(`[{"name": "close_processed", "when": {"external_status": "processed", "status": "in_progress"}, "then": {"status": "closed", "reason": "resolved"}}]`))
+	assert.Equal(t, application.Rules{{
+		Name: "close_processed",
+		When: application.RuleCondition{ExternalStatus: "processed", Status: "in_progress"},
+		Then: application.RuleAction{Status: "closed", Reason: "resolved"},
+	}}, rules)
+
+	assert.Error(t, rules.Decode(`{"name": "close_processed"}`))
+}
+
+func TestRules_Validate(t *testing.T) {
+	var (
+		closeProcessed = application.Rule{
+			Name: "close_processed",
+			When: application.RuleCondition{ExternalStatus: "processed", Status: "in_progress"},
+			Then: application.RuleAction{Status: "closed", Reason: "resolved"},
+		}
+		startUrgent = application.Rule{
+			Name: "start_urgent",
+			When: application.RuleCondition{Priority: "urgent", Status: "open"},
+			Then: application.RuleAction{Status: "in_progress"},
+		}
+	)
+
+	var cases = map[string]struct {
+		Rules    application.Rules
+		ExpError bool
+	}{
+		"success_empty": {},
+		"success": {
+			Rules: application.Rules{closeProcessed, startUrgent},
+		},
+		"failed_name": {
+			Rules:    application.Rules{{When: closeProcessed.When, Then: closeProcessed.Then}},
+			ExpError: true,
+		},
+		"failed_duplicated_name": {
+			Rules:    application.Rules{closeProcessed, closeProcessed},
+			ExpError: true,
+		},
+		"failed_without_conditions": {
+			Rules:    application.Rules{{Name: "close", Then: closeProcessed.Then}},
+			ExpError: true,
+		},
+		"failed_external_status": {
+			Rules: application.Rules{{
+				Name: "close_done",
+				When: application.RuleCondition{ExternalStatus: "done"},
+				Then: closeProcessed.Then,
+			}},
+			ExpError: true,
+		},
+		"failed_status": {
+			Rules: application.Rules{{
+				Name: "close_processed",
+				When: closeProcessed.When,
+				Then: application.RuleAction{Status: "done"},
+			}},
+			ExpError: true,
+		},
+		"failed_same_status": {
+			Rules: application.Rules{{
+				Name: "start_processed",
+				When: closeProcessed.When,
+				Then: application.RuleAction{Status: "in_progress"},
+			}},
+			ExpError: true,
+		},
+		"failed_reason": {
+			Rules: application.Rules{{
+				Name: "close_processed",
+				When: closeProcessed.When,
+				Then: application.RuleAction{Status: "closed", Reason: "unknown"},
+			}},
+			ExpError: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := c.Rules.Validate(application.ReasonConfig{})
+			if c.ExpError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_Rules(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		userID = "603bd5e5967f2dba00c8e325"
+		rules  = application.Rules{
+			{
+				Name: "start_skipped",
+				When: application.RuleCondition{ExternalStatus: "skipped", Status: "open"},
+				Then: application.RuleAction{Status: "in_progress"},
+			},
+			{
+				Name: "close_processed",
+				When: application.RuleCondition{ExternalStatus: "processed", Status: "in_progress"},
+				Then: application.RuleAction{Status: "closed", Reason: "resolved", Comment: "processed by external service"},
+			},
+		}
+	)
+
+	ctrl := gomock.NewController(t)
+
+	repository, err := application_embedded.NewRepository(application_embedded.InMemory)
+	require.NoError(t, err)
+	defer repository.Close()
+
+	externalClient := external_mock.NewMockClient(ctrl)
+	externalClient.EXPECT().GetExternalStatus(gomock.Any(), gomock.Any()).Return(external.StatusSkipped, nil)
+	externalClient.EXPECT().GetExternalStatus(gomock.Any(), gomock.Any()).Return(external.StatusProcessed, nil)
+
+	outboxStore := outbox_memory.NewStore()
+	svc := application.NewService(repository, externalClient, application.WithOutbox(outboxStore), application.WithRules(rules))
+
+	// at most one rule fires per change
+	skipped, err := svc.Create(ctx, &application.CreateParams{UserID: userID})
+	require.NoError(t, err)
+	assert.Equal(t, application.StatusInProgress, skipped.Status)
+	assert.Equal(t, application.StatusOpen, skipped.PreviousStatus)
+
+	processed, err := svc.Create(ctx, &application.CreateParams{UserID: userID})
+	require.NoError(t, err)
+	assert.Equal(t, application.StatusOpen, processed.Status)
+
+	updated, err := svc.Update(ctx, &application.UpdateParams{ID: processed.ID, Status: application.StatusInProgress})
+	require.NoError(t, err)
+	assert.Equal(t, application.StatusClosed, updated.Status)
+	assert.Equal(t, application.Reason{Code: "resolved", Comment: "processed by external service"}, updated.Reason)
+
+	found, err := repository.FindByID(ctx, processed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, updated, found)
+
+	events, err := outboxStore.Pending(ctx, 0)
+	require.NoError(t, err)
+	var types []string
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	assert.ElementsMatch(t, []string{
+		application.EventApplicationCreated,
+		application.EventApplicationStatusChanged,
+		application.EventApplicationCreated,
+		application.EventApplicationStatusChanged,
+		application.EventApplicationStatusChanged,
+	}, types)
+}
@@ -7,9 +7,13 @@ import (
 	"time"
 
 	"github.com/PxyUp/backend_tech_task/internal/external"
+	"github.com/PxyUp/backend_tech_task/internal/idempotency"
+	"github.com/PxyUp/backend_tech_task/internal/outbox"
+	"github.com/PxyUp/backend_tech_task/internal/util/logutil"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	validator "github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
@@ -20,29 +24,127 @@ var (
 	ErrExternalService     = fmt.Errorf("undefined error from external service")
 	ErrRepository          = fmt.Errorf("undefined repository error")
 	ErrApplicationNotFound = fmt.Errorf("application is not found")
+	// ErrApplicationAlreadyExists is returned by repository on creating application with existing id
+	ErrApplicationAlreadyExists = fmt.Errorf("application already exists")
+	ErrPreconditionFailed       = fmt.Errorf("application doesn't match expected state")
+	ErrApplicationDeleted       = fmt.Errorf("application is deleted")
+	// ErrIdempotencyKeyReused is returned when key is repeated with another payload
+	ErrIdempotencyKeyReused = fmt.Errorf("idempotency key is already used by another request")
+	// ErrRequestInProgress is returned when first request with idempotency key hasn't finished yet
+	ErrRequestInProgress = fmt.Errorf("request with idempotency key is in progress")
+	// ErrAssigneeRequired is returned when unassigned application is moved to in progress status
+	ErrAssigneeRequired = fmt.Errorf("application should be assigned")
 )
 
 type Service interface {
-	Create(ctx context.Context, userID string) (*Application, error)
-	GetByID(ctx context.Context, id string) (*Application, error)
+	// Create returns application created by previous request if idempotency key is repeated
+	Create(ctx context.Context, params *CreateParams) (*Application, error)
+	// CreateMany creates batch of applications and returns result of every item in order of params
+	CreateMany(ctx context.Context, params *CreateManyParams) ([]CreateResult, error)
+	GetByID(ctx context.Context, params *GetByIDParams) (*Application, error)
 	GetByFilters(ctx context.Context, params *GetByFilterParams) ([]Application, error)
 	Update(ctx context.Context, params *UpdateParams) (*Application, error)
+	// BulkUpdateStatus sets status of every application matched by filter
+	BulkUpdateStatus(ctx context.Context, params *BulkUpdateStatusParams) (*BulkUpdateStatusResult, error)
+	// Assign sets operator handling application, Unassign removes it
+	Assign(ctx context.Context, params *AssignParams) (*Application, error)
+	Unassign(ctx context.Context, id string) (*Application, error)
+	// Delete sets tombstone of application, it's hidden from search until it's restored or purged
+	Delete(ctx context.Context, id string) (*Application, error)
+	Restore(ctx context.Context, id string) (*Application, error)
+	// FlagBreached marks at most limit applications which have passed deadline till now,
+	// it returns number of flagged applications
+	FlagBreached(ctx context.Context, now time.Time, limit int) (int, error)
+
+	// WithinTransaction runs fn atomically, calls of service and repositories
+	// made with ctx passed to fn are committed or rolled back together
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+const (
+	maxIdempotencyKeyLength = 255
+
+	defaultIdempotencyTTL = 24 * time.Hour
+	// idempotencyLease is expiration of key while application is being created, it's extended to ttl after creation,
+	// so key of crashed request is released by lease, it should be longer than external request and insert
+	idempotencyLease = time.Minute
+	// idempotencyTimeout limits releasing and extending of key, they don't depend on context of request
+	idempotencyTimeout = 5 * time.Second
+)
+
+type CreateParams struct {
+	UserID string
+	// IdempotencyKey is optional, requests with the same key create single application
+	IdempotencyKey string
+	// Priority is optional, unspecified priority is normal
+	Priority Priority
+}
+
+func (p CreateParams) Validate() error {
+	if err := validateObjectID(p.UserID, "user_id"); err != nil {
+		return err
+	}
+	if err := validatePriority(p.Priority); err != nil {
+		return err
+	}
+	if len(p.IdempotencyKey) > maxIdempotencyKeyLength {
+		return fmt.Errorf("%w: idempotency key should contain at most %d characters", ErrInvalidArgument, maxIdempotencyKeyLength)
+	}
+	return nil
+}
+
+type GetByIDParams struct {
+	ID string
+	// IncludeDeleted allows to get soft deleted application
+	IncludeDeleted bool
 }
 
 type GetByFilterParams struct {
-	Status    *Status    `validate:"required_without_all=UserID CreatedAt UpdatedAt"`
-	UserID    *string    `validate:"required_without_all=Status CreatedAt UpdatedAt"`
-	CreatedAt *TimeRange `validate:"required_without_all=UserID Status UpdatedAt"`
-	UpdatedAt *TimeRange `validate:"required_without_all=UserID Status CreatedAt"`
+	Status    *Status    `validate:"required_without_all=UserID CreatedAt UpdatedAt Reason AssigneeID Unassigned Overdue DueBefore"`
+	UserID    *string    `validate:"required_without_all=Status CreatedAt UpdatedAt Reason AssigneeID Unassigned Overdue DueBefore"`
+	CreatedAt *TimeRange `validate:"required_without_all=UserID Status UpdatedAt Reason AssigneeID Unassigned Overdue DueBefore"`
+	UpdatedAt *TimeRange `validate:"required_without_all=UserID Status CreatedAt Reason AssigneeID Unassigned Overdue DueBefore"`
+	// Reason is code of reason of the last status update
+	Reason     *string `validate:"required_without_all=UserID Status CreatedAt UpdatedAt AssigneeID Unassigned Overdue DueBefore"`
+	AssigneeID *string `validate:"required_without_all=UserID Status CreatedAt UpdatedAt Reason Unassigned Overdue DueBefore"`
+	// Unassigned matches applications without assignee, it can't be combined with AssigneeID
+	Unassigned bool `validate:"required_without_all=UserID Status CreatedAt UpdatedAt Reason AssigneeID Overdue DueBefore"`
+	// Overdue matches applications which have passed deadline, DueBefore matches deadlines before time
+	Overdue   bool       `validate:"required_without_all=UserID Status CreatedAt UpdatedAt Reason AssigneeID Unassigned DueBefore"`
+	DueBefore *time.Time `validate:"required_without_all=UserID Status CreatedAt UpdatedAt Reason AssigneeID Unassigned Overdue"`
+	// IncludeDeleted adds soft deleted applications to result
+	IncludeDeleted bool
+	// Limit limits number of applications, 0 means no limit,
+	// applications are ordered by id and AfterID skips applications up to the id inclusive
+	Limit   int `validate:"gte=0"`
+	AfterID *string
 }
 
 func (p GetByFilterParams) Validate() error {
 	if err := validate.Struct(p); err != nil {
 		return fmt.Errorf("%w: %s", ErrInvalidArgument, err.Error())
 	}
+	if p.AssigneeID != nil && p.Unassigned {
+		return fmt.Errorf("%w: assignee_id and unassigned can't be used together", ErrInvalidArgument)
+	}
+	if p.AfterID != nil {
+		return validateObjectID(*p.AfterID, "page_token")
+	}
 	return nil
 }
 
+// DueBound returns the earliest upper bound of deadline set by Overdue and DueBefore, it's nil without them
+func (p GetByFilterParams) DueBound(now time.Time) *time.Time {
+	var bound *time.Time
+	if p.Overdue {
+		bound = &now
+	}
+	if p.DueBefore != nil && (bound == nil || p.DueBefore.Before(*bound)) {
+		bound = p.DueBefore
+	}
+	return bound
+}
+
 type TimeRange struct {
 	Start time.Time `validate:"ltfield=End"`
 	End   time.Time `validate:"gtfield=Start"`
@@ -58,6 +160,29 @@ func (tr TimeRange) Validate() error {
 type UpdateParams struct {
 	ID     string
 	Status Status
+	// Reason is required for closing, codes are checked by service against configured reasons
+	Reason Reason
+
+	// ExpectedStatus and ExpectedVersion are optional predicates,
+	// application is updated only if it matches all of them
+	ExpectedStatus  *Status
+	ExpectedVersion *int64
+	// RequireAssignee makes update fail for unassigned application, it's set by service
+	RequireAssignee bool
+	// Deadlines of new status are used for calculating DueAt if status is changed, they are set by service
+	Deadlines Deadlines
+}
+
+type AssignParams struct {
+	ID         string
+	AssigneeID string
+}
+
+func (p AssignParams) Validate() error {
+	if err := validateObjectID(p.ID, "id"); err != nil {
+		return err
+	}
+	return validateObjectID(p.AssigneeID, "assignee_id")
 }
 
 func (p UpdateParams) Validate() error {
@@ -67,22 +192,103 @@ func (p UpdateParams) Validate() error {
 	if err := p.Status.Validate(); err != nil {
 		return fmt.Errorf("%w: %s", ErrInvalidArgument, err.Error())
 	}
-	return nil
+	if p.ExpectedStatus != nil {
+		if err := p.ExpectedStatus.Validate(); err != nil {
+			return fmt.Errorf("%w: expected status: %s", ErrInvalidArgument, err.Error())
+		}
+	}
+	if p.ExpectedVersion != nil && *p.ExpectedVersion < 0 {
+		return fmt.Errorf("%w: expected version cannot be negative", ErrInvalidArgument)
+	}
+	return p.Reason.Validate()
 }
 
 type service struct {
 	repository     Repository
 	externalClient external.Client
+	transactor     Transactor
+	outbox         outbox.Writer
+	idempotency    idempotency.Store
+	idempotencyTTL time.Duration
+	quota          QuotaConfig
+	reasons        ReasonConfig
+	// requireAssignee makes assignee required for moving application to in progress status
+	requireAssignee bool
+	deadlines       map[Status]Deadlines
+	// rules are evaluated on creation and on change of status of application
+	rules     []rule
+	rawRules  Rules
+	userLocks *userLocks
+	// batchConcurrency limits concurrent requests to external service
+	batchConcurrency int
+}
+
+type ServiceOption func(svc *service)
+
+// WithTransactor sets transactor of repository, without it writes aren't atomic
+func WithTransactor(transactor Transactor) ServiceOption {
+	return func(svc *service) {
+		svc.transactor = transactor
+	}
+}
+
+// WithOutbox sets writer of application events, events are appended in transaction of change
+func WithOutbox(writer outbox.Writer) ServiceOption {
+	return func(svc *service) {
+		svc.outbox = writer
+	}
+}
+
+// WithIdempotency sets store of idempotency keys of creation, keys are kept for ttl
+func WithIdempotency(store idempotency.Store, ttl time.Duration) ServiceOption {
+	return func(svc *service) {
+		svc.idempotency = store
+		if ttl > 0 {
+			svc.idempotencyTTL = ttl
+		}
+	}
+}
+
+// WithRequiredAssignee makes assignee required for moving application to in progress status
+func WithRequiredAssignee(required bool) ServiceOption {
+	return func(svc *service) {
+		svc.requireAssignee = required
+	}
 }
 
 func NewService(
 	repository Repository,
 	externalClient external.Client,
+	opts ...ServiceOption,
 ) Service {
-	return &service{
+	svc := &service{
 		repository:     repository,
 		externalClient: externalClient,
+		transactor:     NopTransactor{},
+		outbox:         outbox.NopWriter{},
+		idempotency:    idempotency.NopStore{},
+		idempotencyTTL: defaultIdempotencyTTL,
+		userLocks:      new(userLocks),
+		reasons:        ReasonConfig{}.withDefaults(),
+		deadlines:      defaultSLAPolicies,
+
+		batchConcurrency: defaultBatchConcurrency,
 	}
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	// rules depend on reasons, so they are compiled after all options
+	rules, err := svc.rawRules.compile(svc.reasons)
+	if err != nil {
+		log.Err(err).Msg("rules are invalid, automatic transitions are disabled")
+	}
+	svc.rules = rules
+	return svc
+}
+
+func (svc service) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return svc.transactor.WithinTransaction(ctx, fn)
 }
 
 func validateObjectID(id string, fieldName string) error {
@@ -92,97 +298,334 @@ func validateObjectID(id string, fieldName string) error {
 	return nil
 }
 
-func (svc service) Create(ctx context.Context, userID string) (*Application, error) {
-	log.Info().Str("user_id", userID).Msg("try to create application")
-	if err := validateObjectID(userID, "user_id"); err != nil {
-		log.Err(err).Msg("couldn't validate user id ")
-		return nil, err
+// validatePriority allows unspecified priority, it's replaced by normal one
+func validatePriority(priority Priority) error {
+	if priority == PriorityUnspecified {
+		return nil
 	}
+	if err := priority.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidArgument, err.Error())
+	}
+	return nil
+}
 
-	var app = &Application{
+// newApplication returns open application with deadline of open status
+func (svc service) newApplication(userID string, priority Priority, now time.Time) *Application {
+	if priority == PriorityUnspecified {
+		priority = PriorityNormal
+	}
+	return &Application{
 		ID:        primitive.NewObjectID().Hex(),
 		Status:    StatusOpen,
 		UserID:    userID,
-		CreatedAt: time.Now().UTC(),
+		CreatedAt: now,
+		Priority:  priority,
+		DueAt:     svc.deadlines[StatusOpen].DueAt(priority, now),
+	}
+}
+
+func (svc service) Create(ctx context.Context, params *CreateParams) (*Application, error) {
+	logger(ctx).Info().Str("user_id", params.UserID).Msg("try to create application")
+	if err := params.Validate(); err != nil {
+		logger(ctx).Err(err).Msg("couldn't validate params")
+		return nil, err
+	}
+
+	var app = svc.newApplication(params.UserID, params.Priority, time.Now().UTC())
+	if params.IdempotencyKey == "" {
+		return svc.create(ctx, app)
+	}
+
+	var record = &idempotency.Record{
+		Key:         params.IdempotencyKey,
+		Fingerprint: idempotency.Fingerprint(params.UserID, app.Priority.String()),
+		ResourceID:  app.ID,
+		CreatedAt:   app.CreatedAt,
+		ExpiresAt:   app.CreatedAt.Add(idempotencyLease),
+	}
+	previous, err := svc.reserveIdempotencyKey(ctx, record)
+	if err != nil || previous != nil {
+		return previous, err
+	}
+
+	created, err := svc.create(ctx, app)
+
+	// key is released and extended even if request has been canceled, e.g. by timeout of client
+	storeCtx, cancel := context.WithTimeout(context.Background(), idempotencyTimeout)
+	defer cancel()
+	if err != nil {
+		// failed request can be retried with the same key
+		if releaseErr := svc.idempotency.Release(storeCtx, record); releaseErr != nil {
+			logger(ctx).Err(releaseErr).Msg("couldn't release idempotency key")
+		}
+		return nil, err
+	}
+
+	record.ExpiresAt = app.CreatedAt.Add(svc.idempotencyTTL)
+	if err := svc.idempotency.Extend(storeCtx, record); err != nil {
+		// application is created, key is kept for lease only
+		logger(ctx).Err(err).Msg("couldn't extend idempotency key")
+	}
+	return created, nil
+}
+
+// reserveIdempotencyKey binds key to new application for lease,
+// it returns application created by previous request with the key
+func (svc service) reserveIdempotencyKey(ctx context.Context, record *idempotency.Record) (*Application, error) {
+	existing, err := svc.idempotency.Reserve(ctx, record)
+	if err != nil {
+		logger(ctx).Err(err).Msg("couldn't reserve idempotency key")
+		return nil, ErrRepository
+	}
+	if existing == nil {
+		return nil, nil
+	}
+	if existing.Fingerprint != record.Fingerprint {
+		return nil, ErrIdempotencyKeyReused
+	}
+
+	logger(ctx).Info().Str("id", existing.ResourceID).Msg("idempotency key is repeated, try to find application")
+	previous, err := svc.repository.FindByID(ctx, existing.ResourceID)
+	if err != nil {
+		if errors.Is(err, ErrApplicationNotFound) {
+			return nil, ErrRequestInProgress
+		}
+		logger(ctx).Err(err).Msg("couldn't find application of idempotency key")
+		return nil, ErrRepository
 	}
+	return previous, nil
+}
 
-	log.Info().Msg("try to get external status")
+func (svc service) create(ctx context.Context, app *Application) (*Application, error) {
+	logger(ctx).Info().Msg("try to get external status")
 	externalStatus, err := svc.externalClient.GetExternalStatus(ctx, app.ID)
 	if err != nil {
-		log.Err(err).Msg("couldn't get external status")
+		logger(ctx).Err(err).Msg("couldn't get external status")
 		return nil, fmt.Errorf("%w: %s", ErrExternalService, err.Error())
 	}
 	app.ExternalStatus = externalStatus
 
-	log.Info().Msgf("got external status: %s, try to save application", app.ExternalStatus.String())
-	if err := svc.repository.Create(ctx, app); err != nil {
-		log.Err(err).Msgf("couldn't save application")
+	logger(ctx).Info().Msgf("got external status: %s, try to save application", app.ExternalStatus.String())
+	unlock := svc.lockUsers(app)
+	defer unlock()
+
+	var created *Application
+	if err := svc.WithinTransaction(ctx, func(ctx context.Context) error {
+		quotaErrs, err := svc.checkQuota(ctx, []*Application{app}, app.CreatedAt)
+		if err != nil {
+			return err
+		}
+		if quotaErrs[0] != nil {
+			return quotaErrs[0]
+		}
+		if err := svc.repository.Create(ctx, app); err != nil {
+			return err
+		}
+		if err := svc.appendEvent(ctx, EventApplicationCreated, app); err != nil {
+			return err
+		}
+
+		// app isn't changed, transaction could be retried
+		ruled, err := svc.applyRules(ctx, app)
+		if err != nil {
+			return err
+		}
+		created = app
+		if ruled != nil {
+			created = ruled
+		}
+		return nil
+	}); err != nil {
+		logger(ctx).Err(err).Msgf("couldn't save application")
+		if isQuotaError(err) {
+			return nil, err
+		}
 		return nil, ErrRepository
 	}
 
-	log.Info().Msgf("application %s has been created", app.ID)
-	return app, nil
+	logger(ctx).Info().Msgf("application %s has been created", app.ID)
+	return created, nil
 }
 
-func (svc service) GetByID(ctx context.Context, id string) (*Application, error) {
-	log.Info().Str("id", id).Msg("try to find application")
-	if err := validateObjectID(id, "id"); err != nil {
+func (svc service) GetByID(ctx context.Context, params *GetByIDParams) (*Application, error) {
+	logger(ctx).Info().Str("id", params.ID).Msg("try to find application")
+	if err := validateObjectID(params.ID, "id"); err != nil {
 		return nil, err
 	}
 
-	log.Info().Msg("try to search application in db")
-	app, err := svc.repository.FindByID(ctx, id)
+	logger(ctx).Info().Msg("try to search application in db")
+	app, err := svc.repository.FindByID(ctx, params.ID)
 	if err != nil {
-		log.Err(err).Msgf("couldn't find application")
+		logger(ctx).Err(err).Msgf("couldn't find application")
 		if !errors.Is(err, ErrApplicationNotFound) {
 			return nil, ErrRepository
 		}
 		return nil, err
 	}
+	if app.Deleted() && !params.IncludeDeleted {
+		logger(ctx).Info().Msg("application is deleted")
+		return nil, ErrApplicationNotFound
+	}
 
-	log.Err(err).Msgf("application has been found")
+	logger(ctx).Debug().Msg("application has been found")
 	return app, nil
 }
 
 func (svc service) GetByFilters(ctx context.Context, params *GetByFilterParams) ([]Application, error) {
-	log.Info().Interface("params", params).Msg("try to find applications by filter")
+	logger(ctx).Info().Interface("params", params).Msg("try to find applications by filter")
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
 
-	log.Info().Msg("try to search application by filter in db")
+	logger(ctx).Info().Msg("try to search application by filter in db")
 	apps, err := svc.repository.FindByFilters(ctx, params)
 	if err != nil {
-		log.Err(err).Msgf("couldn't find applications")
+		logger(ctx).Err(err).Msgf("couldn't find applications")
 		return nil, ErrRepository
 	}
 
-	log.Info().Msgf("applications has been found")
+	logger(ctx).Info().Msgf("applications has been found")
 	return apps, nil
 }
 
 func (svc service) Update(ctx context.Context, params *UpdateParams) (*Application, error) {
-	log.Info().Interface("params", params).Msg("try to update application")
+	logger(ctx).Info().Interface("params", params).Msg("try to update application")
 	if err := params.Validate(); err != nil {
 		return nil, err
 	}
+	if err := svc.reasons.check(params.Status, params.Reason); err != nil {
+		return nil, err
+	}
+	params = svc.withStatusPolicies(params)
 
-	log.Info().Msg("try to find application")
-	_, err := svc.GetByID(ctx, params.ID)
-	if err != nil {
+	var app *Application
+	if err := svc.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		if app, err = svc.repository.Update(ctx, params); err != nil {
+			return err
+		}
+		if app.PreviousStatus == app.Status {
+			return nil
+		}
+		if err := svc.appendEvent(ctx, EventApplicationStatusChanged, app); err != nil {
+			return err
+		}
+
+		ruled, err := svc.applyRules(ctx, app)
+		if err != nil {
+			return err
+		}
+		if ruled != nil {
+			app = ruled
+		}
+		return nil
+	}); err != nil {
+		logger(ctx).Err(err).Msgf("couldn't update application")
+		return nil, updateError(err)
+	}
+
+	logger(ctx).Info().Msg("application has been updated")
+	return app, nil
+}
+
+// withStatusPolicies returns copy of params with requirements and deadlines of new status
+func (svc service) withStatusPolicies(params *UpdateParams) *UpdateParams {
+	var res = *params
+	res.RequireAssignee = svc.assigneeRequired(params.Status)
+	res.Deadlines = svc.deadlines[params.Status]
+	return &res
+}
+
+func (svc service) assigneeRequired(status Status) bool {
+	return svc.requireAssignee && status == StatusInProgress
+}
+
+func (svc service) Assign(ctx context.Context, params *AssignParams) (*Application, error) {
+	logger(ctx).Info().Interface("params", params).Msg("try to assign application")
+	if err := params.Validate(); err != nil {
 		return nil, err
 	}
+	return svc.setAssignee(ctx, params.ID, params.AssigneeID, EventApplicationAssigned)
+}
 
-	log.Info().Msg("application has been found, try to update")
-	app, err := svc.repository.Update(ctx, params)
-	if err != nil {
-		log.Err(err).Msgf("couldn't update application")
-		if !errors.Is(err, ErrApplicationNotFound) {
-			return nil, ErrRepository
+func (svc service) Unassign(ctx context.Context, id string) (*Application, error) {
+	logger(ctx).Info().Str("id", id).Msg("try to unassign application")
+	if err := validateObjectID(id, "id"); err != nil {
+		return nil, err
+	}
+	return svc.setAssignee(ctx, id, "", EventApplicationUnassigned)
+}
+
+func (svc service) setAssignee(ctx context.Context, id string, assigneeID string, eventType string) (*Application, error) {
+	var app *Application
+	if err := svc.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		if app, err = svc.repository.Assign(ctx, id, assigneeID); err != nil {
+			return err
 		}
+		return svc.appendEvent(ctx, eventType, app)
+	}); err != nil {
+		logger(ctx).Err(err).Msg("couldn't change assignee of application")
+		return nil, updateError(err)
+	}
+
+	logger(ctx).Info().Msg("assignee of application has been changed")
+	return app, nil
+}
+
+func (svc service) Delete(ctx context.Context, id string) (*Application, error) {
+	logger(ctx).Info().Str("id", id).Msg("try to delete application")
+	return svc.setDeleted(ctx, id, svc.repository.Delete, EventApplicationDeleted)
+}
+
+func (svc service) Restore(ctx context.Context, id string) (*Application, error) {
+	logger(ctx).Info().Str("id", id).Msg("try to restore application")
+	return svc.setDeleted(ctx, id, svc.repository.Restore, EventApplicationRestored)
+}
+
+func (svc service) setDeleted(
+	ctx context.Context,
+	id string,
+	change func(ctx context.Context, id string) (*Application, error),
+	eventType string,
+) (*Application, error) {
+	if err := validateObjectID(id, "id"); err != nil {
 		return nil, err
 	}
 
-	log.Info().Msg("application has been updated")
+	var app *Application
+	if err := svc.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		if app, err = change(ctx, id); err != nil {
+			return err
+		}
+		return svc.appendEvent(ctx, eventType, app)
+	}); err != nil {
+		logger(ctx).Err(err).Msgf("couldn't change tombstone of application")
+		return nil, updateError(err)
+	}
+
+	logger(ctx).Info().Msgf("tombstone of application has been changed")
 	return app, nil
 }
+
+// updateError hides unexpected errors of repository
+func updateError(err error) error {
+	if errors.Is(err, ErrApplicationNotFound) ||
+		errors.Is(err, ErrPreconditionFailed) ||
+		errors.Is(err, ErrApplicationDeleted) ||
+		errors.Is(err, ErrAssigneeRequired) {
+		return err
+	}
+	return ErrRepository
+}
+
+func (svc service) appendEvent(ctx context.Context, eventType string, app *Application) error {
+	event, err := NewEvent(eventType, app)
+	if err != nil {
+		return err
+	}
+	return svc.outbox.Append(ctx, event)
+}
+
+// logger returns logger of request of ctx, e.g. with its id
+func logger(ctx context.Context) *zerolog.Logger {
+	return logutil.FromContext(ctx)
+}
@@ -2,22 +2,30 @@ package application_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/PxyUp/backend_tech_task/internal/application"
+	application_embedded "github.com/PxyUp/backend_tech_task/internal/application/embedded"
 	application_mock "github.com/PxyUp/backend_tech_task/internal/application/mock"
 	"github.com/PxyUp/backend_tech_task/internal/external"
 	external_mock "github.com/PxyUp/backend_tech_task/internal/external/mock"
+	"github.com/PxyUp/backend_tech_task/internal/idempotency"
+	idempotency_memory "github.com/PxyUp/backend_tech_task/internal/idempotency/memory"
+	outbox_memory "github.com/PxyUp/backend_tech_task/internal/outbox/memory"
 
 	"bou.ke/monkey"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func TestService_Create(t *testing.T) {
+	defer monkey.UnpatchAll()
+
 	var objectID = primitive.NewObjectID()
 	monkey.Patch(primitive.NewObjectID, func() primitive.ObjectID {
 		return objectID
@@ -27,9 +35,14 @@ func TestService_Create(t *testing.T) {
 	monkey.Patch(time.Now, func() time.Time {
 		return now
 	})
+	var (
+		normalDueAt = now.UTC().Add(24 * time.Hour)
+		urgentDueAt = now.UTC().Add(time.Hour)
+	)
 
 	var cases = map[string]struct {
-		UserID string
+		UserID   string
+		Priority application.Priority
 
 		ExternalClient_GetExternalStatus_Status external.Status
 		ExternalClient_GetExternalStatus_Error  error
@@ -49,12 +62,34 @@ func TestService_Create(t *testing.T) {
 				UserID:         "603bd5e5967f2dba00c8e325",
 				CreatedAt:      now.UTC(),
 				ExternalStatus: external.StatusSkipped,
+				Priority:       application.PriorityNormal,
+				DueAt:          &normalDueAt,
+			},
+		},
+		"success_urgent": {
+			ExternalClient_GetExternalStatus_Status: external.StatusSkipped,
+
+			UserID:   "603bd5e5967f2dba00c8e325",
+			Priority: application.PriorityUrgent,
+			ExpApplication: &application.Application{
+				ID:             objectID.Hex(),
+				Status:         application.StatusOpen,
+				UserID:         "603bd5e5967f2dba00c8e325",
+				CreatedAt:      now.UTC(),
+				ExternalStatus: external.StatusSkipped,
+				Priority:       application.PriorityUrgent,
+				DueAt:          &urgentDueAt,
 			},
 		},
 		"failed_invalid_argument": {
 			UserID:   "not valid",
 			ExpError: fmt.Errorf("invalid argument: user_id is not valid: encoding/hex: invalid byte: U+006E 'n'"),
 		},
+		"failed_invalid_priority": {
+			UserID:   "603bd5e5967f2dba00c8e325",
+			Priority: 10,
+			ExpError: fmt.Errorf("invalid argument: invalid priority"),
+		},
 	}
 
 	for name, c := range cases {
@@ -80,7 +115,7 @@ func TestService_Create(t *testing.T) {
 
 			svc := application.NewService(applicationRepository, externalClient)
 
-			app, err := svc.Create(context.Background(), c.UserID)
+			app, err := svc.Create(context.Background(), &application.CreateParams{UserID: c.UserID, Priority: c.Priority})
 
 			if c.ExpError == nil {
 				assert.NoError(t, err)
@@ -91,3 +126,316 @@ func TestService_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestService_Update(t *testing.T) {
+	var (
+		id             = "603bd5e5967f2dba00c8e326"
+		expectedStatus = application.StatusOpen
+		resolved       = application.Reason{Code: "resolved"}
+		updated        = &application.Application{
+			ID:             id,
+			Status:         application.StatusClosed,
+			PreviousStatus: application.StatusOpen,
+			UserID:         "603bd5e5967f2dba00c8e325",
+			Version:        2,
+		}
+		unchanged = &application.Application{
+			ID:             id,
+			Status:         application.StatusClosed,
+			PreviousStatus: application.StatusClosed,
+			UserID:         "603bd5e5967f2dba00c8e325",
+			Version:        3,
+		}
+	)
+
+	var cases = map[string]struct {
+		Params *application.UpdateParams
+
+		Repository_Update_Application *application.Application
+		Repository_Update_Error       error
+
+		ExpApplication *application.Application
+		ExpEvents      []string
+		ExpError       error
+	}{
+		"success": {
+			Params: &application.UpdateParams{
+				ID:             id,
+				Status:         application.StatusClosed,
+				Reason:         resolved,
+				ExpectedStatus: &expectedStatus,
+			},
+			Repository_Update_Application: updated,
+			ExpApplication:                updated,
+			ExpEvents:                     []string{application.EventApplicationStatusChanged},
+		},
+		"success_same_status": {
+			Params:                        &application.UpdateParams{ID: id, Status: application.StatusClosed, Reason: resolved},
+			Repository_Update_Application: unchanged,
+			ExpApplication:                unchanged,
+		},
+		"failed_not_found": {
+			Params:                  &application.UpdateParams{ID: id, Status: application.StatusClosed, Reason: resolved},
+			Repository_Update_Error: application.ErrApplicationNotFound,
+			ExpError:                application.ErrApplicationNotFound,
+		},
+		"failed_precondition": {
+			Params:                  &application.UpdateParams{ID: id, Status: application.StatusClosed, Reason: resolved, ExpectedStatus: &expectedStatus},
+			Repository_Update_Error: application.ErrPreconditionFailed,
+			ExpError:                application.ErrPreconditionFailed,
+		},
+		"failed_repository": {
+			Params:                  &application.UpdateParams{ID: id, Status: application.StatusClosed, Reason: resolved},
+			Repository_Update_Error: fmt.Errorf("connection refused"),
+			ExpError:                application.ErrRepository,
+		},
+		"failed_invalid_status": {
+			Params:   &application.UpdateParams{ID: id},
+			ExpError: fmt.Errorf("invalid argument: invalid status"),
+		},
+		"failed_closing_without_reason": {
+			Params:   &application.UpdateParams{ID: id, Status: application.StatusClosed},
+			ExpError: fmt.Errorf("invalid argument: reason is required for closing"),
+		},
+		"failed_unknown_reason": {
+			Params: &application.UpdateParams{ID: id, Status: application.StatusOpen, Reason: resolved},
+			ExpError: fmt.Errorf(
+				"invalid argument: unknown reason \"resolved\" for open status, allowed reasons: [new_information customer_request closed_by_mistake]",
+			),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			applicationRepository := application_mock.NewMockRepository(ctrl)
+			applicationRepository.
+				EXPECT().
+				Update(gomock.Any(), c.Params).
+				Return(c.Repository_Update_Application, c.Repository_Update_Error).
+				AnyTimes()
+
+			outboxStore := outbox_memory.NewStore()
+
+			svc := application.NewService(
+				applicationRepository,
+				external_mock.NewMockClient(ctrl),
+				application.WithOutbox(outboxStore),
+			)
+
+			app, err := svc.Update(context.Background(), c.Params)
+
+			if c.ExpError == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, c.ExpError.Error())
+			}
+			assert.Equal(t, c.ExpApplication, app)
+
+			events, err := outboxStore.Pending(context.Background(), 0)
+			require.NoError(t, err)
+			var eventTypes []string
+			for _, event := range events {
+				assert.Equal(t, id, event.AggregateID)
+				assert.Equal(t, c.ExpApplication.Version, event.AggregateVersion)
+				eventTypes = append(eventTypes, event.Type)
+			}
+			assert.Equal(t, c.ExpEvents, eventTypes)
+		})
+	}
+}
+
+func TestService_GetByID(t *testing.T) {
+	var (
+		deletedAt = time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+		deleted   = &application.Application{
+			ID:        "603bd5e5967f2dba00c8e326",
+			Status:    application.StatusOpen,
+			UserID:    "603bd5e5967f2dba00c8e325",
+			DeletedAt: &deletedAt,
+		}
+	)
+
+	var cases = map[string]struct {
+		Params *application.GetByIDParams
+
+		ExpApplication *application.Application
+		ExpError       error
+	}{
+		"success_include_deleted": {
+			Params:         &application.GetByIDParams{ID: deleted.ID, IncludeDeleted: true},
+			ExpApplication: deleted,
+		},
+		"failed_deleted": {
+			Params:   &application.GetByIDParams{ID: deleted.ID},
+			ExpError: application.ErrApplicationNotFound,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			applicationRepository := application_mock.NewMockRepository(ctrl)
+			applicationRepository.
+				EXPECT().
+				FindByID(gomock.Any(), c.Params.ID).
+				Return(deleted, nil)
+
+			svc := application.NewService(applicationRepository, external_mock.NewMockClient(ctrl))
+
+			app, err := svc.GetByID(context.Background(), c.Params)
+
+			if c.ExpError == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, c.ExpError.Error())
+			}
+			assert.Equal(t, c.ExpApplication, app)
+		})
+	}
+}
+
+func TestService_Create_IdempotencyKey(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		userID = "603bd5e5967f2dba00c8e325"
+		other  = "603bd5e5967f2dba00c8e326"
+		key    = "3f1d9c0e-create"
+	)
+
+	ctrl := gomock.NewController(t)
+
+	repository, err := application_embedded.NewRepository(application_embedded.InMemory)
+	require.NoError(t, err)
+	defer repository.Close()
+
+	externalClient := external_mock.NewMockClient(ctrl)
+	gomock.InOrder(
+		externalClient.EXPECT().GetExternalStatus(gomock.Any(), gomock.Any()).Return(external.Status(0), fmt.Errorf("timeout")),
+		externalClient.EXPECT().GetExternalStatus(gomock.Any(), gomock.Any()).Return(external.StatusProcessed, nil),
+	)
+
+	svc := application.NewService(
+		repository,
+		externalClient,
+		application.WithIdempotency(idempotency_memory.NewStore(), time.Hour),
+	)
+
+	// failed request releases key, so it can be retried
+	_, err = svc.Create(ctx, &application.CreateParams{UserID: userID, IdempotencyKey: key})
+	require.True(t, errors.Is(err, application.ErrExternalService), "unexpected error: %v", err)
+
+	created, err := svc.Create(ctx, &application.CreateParams{UserID: userID, IdempotencyKey: key})
+	require.NoError(t, err)
+
+	repeated, err := svc.Create(ctx, &application.CreateParams{UserID: userID, IdempotencyKey: key})
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, repeated.ID)
+
+	_, err = svc.Create(ctx, &application.CreateParams{UserID: other, IdempotencyKey: key})
+	assert.True(t, errors.Is(err, application.ErrIdempotencyKeyReused), "unexpected error: %v", err)
+
+	apps, err := repository.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, apps, 1)
+}
+
+// contextStore fails on canceled context as stores with network calls do
+type contextStore struct {
+	*idempotency_memory.Store
+}
+
+func (s contextStore) Release(ctx context.Context, record *idempotency.Record) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.Store.Release(ctx, record)
+}
+
+func TestService_Create_IdempotencyKeyAfterTimeout(t *testing.T) {
+	var (
+		userID = "603bd5e5967f2dba00c8e325"
+		key    = "3f1d9c0e-timeout"
+	)
+
+	ctrl := gomock.NewController(t)
+
+	repository, err := application_embedded.NewRepository(application_embedded.InMemory)
+	require.NoError(t, err)
+	defer repository.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	externalClient := external_mock.NewMockClient(ctrl)
+	gomock.InOrder(
+		// client times out while external service is called
+		externalClient.EXPECT().GetExternalStatus(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, id string) (external.Status, error) {
+				cancel()
+				return external.Status(0), ctx.Err()
+			},
+		),
+		externalClient.EXPECT().GetExternalStatus(gomock.Any(), gomock.Any()).Return(external.StatusProcessed, nil),
+	)
+
+	svc := application.NewService(
+		repository,
+		externalClient,
+		application.WithIdempotency(contextStore{idempotency_memory.NewStore()}, time.Hour),
+	)
+
+	_, err = svc.Create(ctx, &application.CreateParams{UserID: userID, IdempotencyKey: key})
+	require.True(t, errors.Is(err, application.ErrExternalService), "unexpected error: %v", err)
+
+	// retry of client isn't rejected as request in progress
+	created, err := svc.Create(context.Background(), &application.CreateParams{UserID: userID, IdempotencyKey: key})
+	require.NoError(t, err)
+
+	repeated, err := svc.Create(context.Background(), &application.CreateParams{UserID: userID, IdempotencyKey: key})
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, repeated.ID)
+}
+
+func TestService_Assign(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		id         = "603bd5e5967f2dba00c8e401"
+		assigneeID = "603bd5e5967f2dba00c8e501"
+	)
+
+	ctrl := gomock.NewController(t)
+
+	repository, err := application_embedded.NewRepository(application_embedded.InMemory)
+	require.NoError(t, err)
+	defer repository.Close()
+	require.NoError(t, repository.Create(ctx, &application.Application{
+		ID:     id,
+		UserID: "603bd5e5967f2dba00c8e325",
+		Status: application.StatusOpen,
+	}))
+
+	svc := application.NewService(
+		repository,
+		external_mock.NewMockClient(ctrl),
+		application.WithRequiredAssignee(true),
+	)
+
+	_, err = svc.Assign(ctx, &application.AssignParams{ID: id, AssigneeID: "invalid"})
+	assert.True(t, errors.Is(err, application.ErrInvalidArgument), "unexpected error: %v", err)
+
+	_, err = svc.Update(ctx, &application.UpdateParams{ID: id, Status: application.StatusInProgress})
+	assert.True(t, errors.Is(err, application.ErrAssigneeRequired), "unexpected error: %v", err)
+
+	app, err := svc.Assign(ctx, &application.AssignParams{ID: id, AssigneeID: assigneeID})
+	require.NoError(t, err)
+	assert.Equal(t, assigneeID, app.AssigneeID)
+
+	app, err = svc.Update(ctx, &application.UpdateParams{ID: id, Status: application.StatusInProgress})
+	require.NoError(t, err)
+	assert.Equal(t, application.StatusInProgress, app.Status)
+
+	app, err = svc.Unassign(ctx, id)
+	require.NoError(t, err)
+	assert.Empty(t, app.AssigneeID)
+}
@@ -0,0 +1,201 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type SLAConfig struct {
+	// Policies are durations of statuses by priority, keys are <priority>_<status>, e.g. urgent_open:1h,
+	// missing keys get default durations, zero duration disables deadline
+	Policies map[string]time.Duration `envconfig:"policies"`
+	// ScanInterval is period of searching applications which have breached deadline
+	ScanInterval time.Duration `envconfig:"scan_interval"`
+	// ScanBatchSize limits applications flagged by one query
+	ScanBatchSize int `envconfig:"scan_batch_size"`
+}
+
+const (
+	defaultSLAScanInterval  = time.Minute
+	defaultSLAScanBatchSize = 100
+)
+
+// defaultSLAPolicies are durations of open and in progress statuses, closed applications don't have deadline
+var defaultSLAPolicies = map[Status]Deadlines{
+	StatusOpen: {
+		PriorityLow:    72 * time.Hour,
+		PriorityNormal: 24 * time.Hour,
+		PriorityHigh:   8 * time.Hour,
+		PriorityUrgent: time.Hour,
+	},
+	StatusInProgress: {
+		PriorityLow:    7 * 24 * time.Hour,
+		PriorityNormal: 72 * time.Hour,
+		PriorityHigh:   24 * time.Hour,
+		PriorityUrgent: 4 * time.Hour,
+	},
+}
+
+// Deadlines are durations of one status by priority
+type Deadlines map[Priority]time.Duration
+
+// DueAt returns deadline of application with priority which has got status at since,
+// unspecified priority is handled as normal one
+func (d Deadlines) DueAt(priority Priority, since time.Time) *time.Time {
+	if priority == PriorityUnspecified {
+		priority = PriorityNormal
+	}
+	duration := d[priority]
+	if duration <= 0 {
+		return nil
+	}
+	dueAt := since.Add(duration)
+	return &dueAt
+}
+
+func (cfg SLAConfig) Validate() error {
+	_, err := cfg.deadlines()
+	return err
+}
+
+// deadlines merges configured policies with default ones
+func (cfg SLAConfig) deadlines() (map[Status]Deadlines, error) {
+	var res = make(map[Status]Deadlines)
+	for status, deadlines := range defaultSLAPolicies {
+		res[status] = make(Deadlines)
+		for priority, duration := range deadlines {
+			res[status][priority] = duration
+		}
+	}
+
+	for key, duration := range cfg.Policies {
+		// status names contain underscore, priority names don't
+		parts := strings.SplitN(key, "_", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid sla policy %q: key should be <priority>_<status>", key)
+		}
+		priority, err := ParsePriority(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid sla policy %q: %w", key, err)
+		}
+		status, err := parseStatus(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid sla policy %q: %w", key, err)
+		}
+		if duration < 0 {
+			return nil, fmt.Errorf("invalid sla policy %q: duration cannot be negative", key)
+		}
+		if res[status] == nil {
+			res[status] = make(Deadlines)
+		}
+		res[status][priority] = duration
+	}
+	return res, nil
+}
+
+func parseStatus(name string) (Status, error) {
+	for _, s := range []Status{StatusOpen, StatusInProgress, StatusClosed} {
+		if s.String() == name {
+			return s, nil
+		}
+	}
+	return StatusUnspecified, fmt.Errorf("%w: %q", ErrInvalidStatus, name)
+}
+
+// WithSLA sets deadlines of statuses, config should be validated before,
+// invalid config leaves default deadlines
+func WithSLA(cfg SLAConfig) ServiceOption {
+	return func(svc *service) {
+		deadlines, err := cfg.deadlines()
+		if err != nil {
+			log.Err(err).Msg("couldn't apply sla config, default one is used")
+			return
+		}
+		svc.deadlines = deadlines
+	}
+}
+
+func (svc service) FlagBreached(ctx context.Context, now time.Time, limit int) (int, error) {
+	apps, err := svc.repository.FindOverdue(ctx, now, limit)
+	if err != nil {
+		logger(ctx).Err(err).Msg("couldn't find overdue applications")
+		return 0, ErrRepository
+	}
+
+	var flagged int
+	for _, app := range apps {
+		if err := svc.WithinTransaction(ctx, func(ctx context.Context) error {
+			breached, err := svc.repository.MarkBreached(ctx, app.ID, *app.DueAt)
+			if err != nil {
+				return err
+			}
+			return svc.appendEvent(ctx, EventApplicationSLABreached, breached)
+		}); err != nil {
+			// application has been changed after search
+			if errors.Is(updateError(err), ErrRepository) {
+				logger(ctx).Err(err).Str("id", app.ID).Msg("couldn't flag breached application")
+				return flagged, ErrRepository
+			}
+			continue
+		}
+		flagged++
+	}
+	return flagged, nil
+}
+
+// SLAScanner periodically flags applications which haven't left status before deadline
+type SLAScanner struct {
+	cfg     SLAConfig
+	service Service
+}
+
+func NewSLAScanner(cfg SLAConfig, service Service) *SLAScanner {
+	if cfg.ScanInterval == 0 {
+		cfg.ScanInterval = defaultSLAScanInterval
+	}
+	if cfg.ScanBatchSize == 0 {
+		cfg.ScanBatchSize = defaultSLAScanBatchSize
+	}
+	return &SLAScanner{cfg: cfg, service: service}
+}
+
+// Run scans applications until ctx is done
+func (s *SLAScanner) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.cfg.ScanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := s.ScanOnce(ctx); err != nil {
+				logger(ctx).Err(err).Msg("couldn't flag breached applications")
+			}
+		}
+	}
+}
+
+// ScanOnce flags batches of breached applications until short batch
+func (s *SLAScanner) ScanOnce(ctx context.Context) (int, error) {
+	var total int
+	for ctx.Err() == nil {
+		n, err := s.service.FlagBreached(ctx, time.Now().UTC(), s.cfg.ScanBatchSize)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < s.cfg.ScanBatchSize {
+			break
+		}
+	}
+	if total != 0 {
+		logger(ctx).Info().Int("count", total).Msg("breached applications have been flagged")
+	}
+	return total, nil
+}
@@ -0,0 +1,99 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	application_embedded "github.com/PxyUp/backend_tech_task/internal/application/embedded"
+	external_mock "github.com/PxyUp/backend_tech_task/internal/external/mock"
+	outbox_memory "github.com/PxyUp/backend_tech_task/internal/outbox/memory"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLAConfig_Validate(t *testing.T) {
+	var cases = map[string]struct {
+		Policies map[string]time.Duration
+		ExpError bool
+	}{
+		"success_default": {},
+		"success": {
+			Policies: map[string]time.Duration{"urgent_in_progress": 2 * time.Hour, "low_closed": 0},
+		},
+		"failed_key": {
+			Policies: map[string]time.Duration{"urgent": time.Hour},
+			ExpError: true,
+		},
+		"failed_priority": {
+			Policies: map[string]time.Duration{"critical_open": time.Hour},
+			ExpError: true,
+		},
+		"failed_status": {
+			Policies: map[string]time.Duration{"high_done": time.Hour},
+			ExpError: true,
+		},
+		"failed_negative": {
+			Policies: map[string]time.Duration{"high_open": -time.Hour},
+			ExpError: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := application.SLAConfig{Policies: c.Policies}.Validate()
+			if c.ExpError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_FlagBreached(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		now    = time.Now().UTC().Truncate(time.Millisecond)
+		past   = now.Add(-time.Minute)
+		future = now.Add(time.Minute)
+	)
+
+	ctrl := gomock.NewController(t)
+
+	repository, err := application_embedded.NewRepository(application_embedded.InMemory)
+	require.NoError(t, err)
+	defer repository.Close()
+	for _, app := range []*application.Application{
+		{ID: "603bd5e5967f2dba00c8e401", Status: application.StatusOpen, Priority: application.PriorityHigh, DueAt: &past},
+		{ID: "603bd5e5967f2dba00c8e402", Status: application.StatusOpen, Priority: application.PriorityHigh, DueAt: &past},
+		{ID: "603bd5e5967f2dba00c8e403", Status: application.StatusOpen, Priority: application.PriorityHigh, DueAt: &future},
+	} {
+		require.NoError(t, repository.Create(ctx, app))
+	}
+
+	outboxStore := outbox_memory.NewStore()
+	svc := application.NewService(repository, external_mock.NewMockClient(ctrl), application.WithOutbox(outboxStore))
+
+	n, err := svc.FlagBreached(ctx, now, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	n, err = application.NewSLAScanner(application.SLAConfig{ScanBatchSize: 1}, svc).ScanOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	found, err := repository.FindOverdue(ctx, now, 0)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	events, err := outboxStore.Pending(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	for _, event := range events {
+		assert.Equal(t, application.EventApplicationSLABreached, event.Type)
+	}
+}
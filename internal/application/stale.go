@@ -0,0 +1,147 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ReasonAutoClosedStale is reason of closing by StaleCloser, it's always allowed
+const ReasonAutoClosedStale = "auto_closed_stale"
+
+type StaleConfig struct {
+	// Enabled turns on closing of stale applications, it's disabled by default
+	Enabled bool `envconfig:"enabled"`
+	// Statuses are names of statuses of applications which can become stale
+	Statuses []string `envconfig:"statuses"`
+	// Threshold is time since the last update after which application is stale
+	Threshold time.Duration `envconfig:"threshold"`
+	Interval  time.Duration `envconfig:"interval"`
+	// Limit is maximum number of applications closed by one run
+	Limit int `envconfig:"limit"`
+	// DryRun only logs applications which would be closed
+	DryRun bool `envconfig:"dry_run"`
+}
+
+const (
+	defaultStaleThreshold = 90 * 24 * time.Hour
+	defaultStaleInterval  = time.Hour
+	defaultStaleLimit     = 100
+)
+
+var defaultStaleStatuses = []string{StatusOpen.String()}
+
+// StaleCloser periodically closes applications which haven't been updated for threshold,
+// applications are closed by service, so they are validated and events are emitted as for manual update
+type StaleCloser struct {
+	cfg      StaleConfig
+	statuses []Status
+	service  Service
+}
+
+func NewStaleCloser(cfg StaleConfig, service Service) (*StaleCloser, error) {
+	if len(cfg.Statuses) == 0 {
+		cfg.Statuses = defaultStaleStatuses
+	}
+	if cfg.Threshold == 0 {
+		cfg.Threshold = defaultStaleThreshold
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = defaultStaleInterval
+	}
+	if cfg.Limit == 0 {
+		cfg.Limit = defaultStaleLimit
+	}
+
+	var statuses = make([]Status, len(cfg.Statuses))
+	for i, name := range cfg.Statuses {
+		status, err := parseStatus(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stale status: %w", err)
+		}
+		if status == StatusClosed {
+			return nil, fmt.Errorf("invalid stale status: closed applications can't be closed again")
+		}
+		statuses[i] = status
+	}
+
+	return &StaleCloser{cfg: cfg, statuses: statuses, service: service}, nil
+}
+
+// Run closes stale applications until ctx is done
+func (c *StaleCloser) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := c.CloseOnce(ctx); err != nil {
+				logger(ctx).Err(err).Msg("couldn't close stale applications")
+			}
+		}
+	}
+}
+
+// CloseOnce closes at most limit stale applications, the earliest created ones first,
+// it returns number of closed applications or number of applications which would be closed in dry run
+func (c *StaleCloser) CloseOnce(ctx context.Context) (int, error) {
+	var (
+		cutoff = time.Now().UTC().Add(-c.cfg.Threshold)
+		stale  []Application
+	)
+	for i := range c.statuses {
+		apps, err := c.service.GetByFilters(ctx, &GetByFilterParams{
+			Status:    &c.statuses[i],
+			UpdatedAt: &TimeRange{Start: time.Unix(0, 0).UTC(), End: cutoff},
+			// applications are ordered by id, which is order of creation
+			Limit: c.cfg.Limit,
+		})
+		if err != nil {
+			return 0, err
+		}
+		stale = append(stale, apps...)
+	}
+
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].ID < stale[j].ID
+	})
+	if len(stale) > c.cfg.Limit {
+		stale = stale[:c.cfg.Limit]
+	}
+
+	if c.cfg.DryRun {
+		for _, app := range stale {
+			logger(ctx).Info().Str("id", app.ID).Time("updated_at", app.UpdatedAt).Msg("stale application would be closed")
+		}
+		return len(stale), nil
+	}
+
+	var closed int
+	for _, app := range stale {
+		status, version := app.Status, app.Version
+		if _, err := c.service.Update(ctx, &UpdateParams{
+			ID:              app.ID,
+			Status:          StatusClosed,
+			Reason:          Reason{Code: ReasonAutoClosedStale},
+			ExpectedStatus:  &status,
+			ExpectedVersion: &version,
+		}); err != nil {
+			// application has been touched after search
+			if errors.Is(err, ErrPreconditionFailed) || errors.Is(err, ErrApplicationDeleted) ||
+				errors.Is(err, ErrApplicationNotFound) {
+				continue
+			}
+			return closed, err
+		}
+		closed++
+	}
+	if closed != 0 {
+		logger(ctx).Info().Int("count", closed).Msg("stale applications have been closed")
+	}
+	return closed, nil
+}
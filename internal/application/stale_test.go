@@ -0,0 +1,88 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	application_embedded "github.com/PxyUp/backend_tech_task/internal/application/embedded"
+	external_mock "github.com/PxyUp/backend_tech_task/internal/external/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleCloser_CloseOnce(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		now       = time.Now().UTC().Truncate(time.Millisecond)
+		userID    = "603bd5e5967f2dba00c8e325"
+		oldest    = &application.Application{ID: "603bd5e5967f2dba00c8e401", UserID: userID, Status: application.StatusOpen, UpdatedAt: now.Add(-200 * 24 * time.Hour)}
+		old       = &application.Application{ID: "603bd5e5967f2dba00c8e402", UserID: userID, Status: application.StatusInProgress, UpdatedAt: now.Add(-100 * 24 * time.Hour)}
+		fresh     = &application.Application{ID: "603bd5e5967f2dba00c8e403", UserID: userID, Status: application.StatusOpen, UpdatedAt: now.Add(-time.Hour)}
+		abandoned = &application.Application{ID: "603bd5e5967f2dba00c8e404", UserID: userID, Status: application.StatusInProgress, UpdatedAt: now.Add(-200 * 24 * time.Hour)}
+	)
+
+	ctrl := gomock.NewController(t)
+
+	repository, err := application_embedded.NewRepository(application_embedded.InMemory)
+	require.NoError(t, err)
+	defer repository.Close()
+	for _, app := range []*application.Application{oldest, old, fresh, abandoned} {
+		require.NoError(t, repository.Create(ctx, app))
+	}
+
+	// config of reasons without automatic reason still allows it
+	svc := application.NewService(
+		repository,
+		external_mock.NewMockClient(ctrl),
+		application.WithReasons(application.ReasonConfig{Close: []string{"resolved"}}),
+	)
+
+	_, err = application.NewStaleCloser(application.StaleConfig{Statuses: []string{"closed"}}, svc)
+	assert.Error(t, err)
+
+	cfg := application.StaleConfig{Statuses: []string{"open", "in_progress"}, Limit: 2, DryRun: true}
+
+	dryRun, err := application.NewStaleCloser(cfg, svc)
+	require.NoError(t, err)
+	n, err := dryRun.CloseOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	closed := application.StatusClosed
+	apps, err := repository.FindByFilters(ctx, &application.GetByFilterParams{Status: &closed})
+	require.NoError(t, err)
+	assert.Empty(t, apps)
+
+	cfg.DryRun = false
+	closer, err := application.NewStaleCloser(cfg, svc)
+	require.NoError(t, err)
+	n, err = closer.CloseOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	// the earliest created stale applications are closed first
+	for _, c := range []struct {
+		App       *application.Application
+		ExpStatus application.Status
+	}{
+		{oldest, application.StatusClosed},
+		{old, application.StatusClosed},
+		{abandoned, application.StatusInProgress},
+		{fresh, application.StatusOpen},
+	} {
+		found, err := repository.FindByID(ctx, c.App.ID)
+		require.NoError(t, err)
+		assert.Equal(t, c.ExpStatus, found.Status, "status of %s", c.App.ID)
+		if c.ExpStatus == application.StatusClosed {
+			assert.Equal(t, application.ReasonAutoClosedStale, found.Reason.Code)
+		}
+	}
+
+	n, err = closer.CloseOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
@@ -0,0 +1,19 @@
+package application
+
+import (
+	"context"
+)
+
+// Transactor runs fn atomically: all repository calls made with ctx passed to fn
+// are committed if fn returns nil and are rolled back otherwise.
+// Nested calls join the outer transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// NopTransactor runs fn as is, it's used with repositories which don't support transactions
+type NopTransactor struct{}
+
+func (NopTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
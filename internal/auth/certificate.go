@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"github.com/PxyUp/backend_tech_task/internal/util/logutil"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// CertificateRoles maps identity of verified client certificate to role, it authenticates service callers without token
+type CertificateRoles map[string]string
+
+// Decode parses comma separated <identity>:<role> pairs, identity can contain colon, e.g. spiffe://cluster/billing:operator
+func (r *CertificateRoles) Decode(value string) error {
+	roles := make(CertificateRoles)
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		i := strings.LastIndex(pair, ":")
+		if i <= 0 || i == len(pair)-1 {
+			return fmt.Errorf("certificate role %q should be <identity>:<role>", pair)
+		}
+		roles[pair[:i]] = pair[i+1:]
+	}
+	*r = roles
+	return nil
+}
+
+// UnaryInterceptor puts principal of client certificate into context, it should be chained before interceptor of Verifier,
+// bearer token takes precedence over certificate
+func (r CertificateRoles) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(r.authenticate(ctx), req)
+}
+
+func (r CertificateRoles) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &serverStream{ServerStream: ss, ctx: r.authenticate(ss.Context())})
+}
+
+func (r CertificateRoles) authenticate(ctx context.Context) context.Context {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	// only chains verified against client CA are trusted
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return ctx
+	}
+
+	for _, identity := range CertificateIdentities(info.State.VerifiedChains[0][0]) {
+		if role, ok := r[identity]; ok {
+			logger := logutil.FromContext(ctx).With().Str("subject", identity).Logger()
+			return WithPrincipal(logutil.WithLogger(ctx, logger), &Principal{Subject: identity, Roles: []string{role}})
+		}
+	}
+	return ctx
+}
+
+// CertificateIdentities returns URI SANs, DNS SANs and common name of certificate in order of preference
+func CertificateIdentities(cert *x509.Certificate) []string {
+	var identities []string
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+	identities = append(identities, cert.DNSNames...)
+	if cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+	return identities
+}
@@ -0,0 +1,80 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+
+	"github.com/PxyUp/backend_tech_task/internal/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func TestCertificateRoles_Decode(t *testing.T) {
+	var roles auth.CertificateRoles
+	require.NoError(t, roles.Decode("spiffe://cluster/billing:operator, reporter:admin"))
+	assert.Equal(t, auth.CertificateRoles{"spiffe://cluster/billing": auth.RoleOperator, "reporter": auth.RoleAdmin}, roles)
+
+	assert.Error(t, roles.Decode("reporter"))
+	assert.Error(t, roles.Decode("reporter:"))
+}
+
+func TestCertificateRoles_UnaryInterceptor(t *testing.T) {
+	roles := auth.CertificateRoles{"spiffe://cluster/billing": auth.RoleOperator, "reporter": auth.RoleAdmin}
+
+	withCertificate := func(cert *x509.Certificate) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}},
+		}})
+	}
+	billing, _ := url.Parse("spiffe://cluster/billing")
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		principal *auth.Principal
+	}{
+		{
+			name:      "uri san",
+			ctx:       withCertificate(&x509.Certificate{URIs: []*url.URL{billing}, Subject: pkix.Name{CommonName: "reporter"}}),
+			principal: &auth.Principal{Subject: "spiffe://cluster/billing", Roles: []string{auth.RoleOperator}},
+		},
+		{
+			name:      "common name",
+			ctx:       withCertificate(&x509.Certificate{DNSNames: []string{"reporter.local"}, Subject: pkix.Name{CommonName: "reporter"}}),
+			principal: &auth.Principal{Subject: "reporter", Roles: []string{auth.RoleAdmin}},
+		},
+		{
+			name: "unknown identity",
+			ctx:  withCertificate(&x509.Certificate{Subject: pkix.Name{CommonName: "unknown"}}),
+		},
+		{
+			name: "unverified certificate",
+			ctx: peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+				State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "reporter"}}}},
+			}}),
+		},
+		{
+			name: "without tls",
+			ctx:  context.Background(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := roles.UnaryInterceptor(tt.ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				principal, ok := auth.PrincipalFromContext(ctx)
+				assert.Equal(t, tt.principal != nil, ok)
+				assert.Equal(t, tt.principal, principal)
+				return nil, nil
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
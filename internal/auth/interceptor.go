@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/PxyUp/backend_tech_task/internal/util/logutil"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AuthorizationMetadata is metadata key of bearer token
+const AuthorizationMetadata = "authorization"
+
+// publicServices don't require token, probes and tools can't pass it
+var publicServices = []string{"/grpc.health.v1.", "/grpc.reflection."}
+
+// UnaryInterceptor puts principal of bearer token into context, call without valid token fails with Unauthenticated
+func (v *Verifier) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := v.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (v *Verifier) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := v.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+func (v *Verifier) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if isPublic(method) {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(AuthorizationMetadata)
+	if len(values) == 0 {
+		// caller is authenticated by client certificate
+		if _, ok := PrincipalFromContext(ctx); ok {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "bearer token is required")
+	}
+	const prefix = "bearer "
+	if len(values[0]) <= len(prefix) || !strings.EqualFold(values[0][:len(prefix)], prefix) {
+		return nil, status.Error(codes.Unauthenticated, "authorization should be bearer token")
+	}
+
+	principal, err := v.Verify(strings.TrimSpace(values[0][len(prefix):]))
+	if err != nil {
+		logutil.FromContext(ctx).Warn().Err(err).Msg("couldn't verify token")
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	logger := logutil.FromContext(ctx).With().Str("subject", principal.Subject).Logger()
+	return WithPrincipal(logutil.WithLogger(ctx, logger), principal), nil
+}
+
+func isPublic(method string) bool {
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// serverStream replaces context of stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
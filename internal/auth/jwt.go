@@ -0,0 +1,279 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	// hashes of supported algorithms
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+type Config struct {
+	// Enabled requires bearer token in every call of api
+	Enabled bool `envconfig:"enabled"`
+	// Issuer and Audience are checked only if they are set
+	Issuer   string `envconfig:"issuer"`
+	Audience string `envconfig:"audience"`
+	// JWKSFile is JSON Web Key Set, keys are selected by kid of token
+	JWKSFile string `envconfig:"jwks_file"`
+	// PublicKeyFiles are PEM encoded public keys or certificates
+	PublicKeyFiles []string `envconfig:"public_key_files"`
+	// RolesClaim is claim of roles of user, it's array of strings or space separated string
+	RolesClaim string `envconfig:"roles_claim"`
+	// ScopesClaim is claim of scopes of token in the same format as roles
+	ScopesClaim string `envconfig:"scopes_claim"`
+	// Leeway is allowed clock skew of checking of exp and nbf
+	Leeway time.Duration `envconfig:"leeway"`
+	// Policy is json object of permissions of methods, it overrides permissions of the same methods of DefaultPolicy
+	Policy Policy `envconfig:"policy"`
+}
+
+const (
+	defaultRolesClaim  = "roles"
+	defaultScopesClaim = "scope"
+	defaultLeeway      = time.Minute
+)
+
+var (
+	ErrInvalidToken = fmt.Errorf("invalid token")
+	ErrTokenExpired = fmt.Errorf("token is expired")
+)
+
+// Verifier checks signature and claims of JWT
+type Verifier struct {
+	cfg  Config
+	keys []key
+	now  func() time.Time
+}
+
+func NewVerifier(cfg Config) (*Verifier, error) {
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = defaultRolesClaim
+	}
+	if cfg.ScopesClaim == "" {
+		cfg.ScopesClaim = defaultScopesClaim
+	}
+	if cfg.Leeway == 0 {
+		cfg.Leeway = defaultLeeway
+	}
+
+	var keys []key
+	if cfg.JWKSFile != "" {
+		jwksKeys, err := loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, jwksKeys...)
+	}
+	for _, path := range cfg.PublicKeyFiles {
+		k, err := loadPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("auth doesn't have keys, jwks file or public key files are required")
+	}
+
+	return &Verifier{cfg: cfg, keys: keys, now: time.Now}, nil
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type claims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *int64   `json:"exp"`
+	NotBefore *int64   `json:"nbf"`
+}
+
+// audience is single string or array of strings
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(a))
+}
+
+// Verify returns principal of token, expiration time is required
+func (v *Verifier) Verify(token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: token should have 3 parts", ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("%w: header: %s", ErrInvalidToken, err.Error())
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %s", ErrInvalidToken, err.Error())
+	}
+	if err := v.verifySignature(h, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var (
+		c   claims
+		raw map[string]json.RawMessage
+	)
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return nil, fmt.Errorf("%w: claims: %s", ErrInvalidToken, err.Error())
+	}
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, fmt.Errorf("%w: claims: %s", ErrInvalidToken, err.Error())
+	}
+	if err := v.checkClaims(c); err != nil {
+		return nil, err
+	}
+
+	roles, err := parseList(raw[v.cfg.RolesClaim])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrInvalidToken, v.cfg.RolesClaim, err.Error())
+	}
+	scopes, err := parseList(raw[v.cfg.ScopesClaim])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrInvalidToken, v.cfg.ScopesClaim, err.Error())
+	}
+	return &Principal{Subject: c.Subject, Roles: roles, Scopes: scopes}, nil
+}
+
+func (v *Verifier) verifySignature(h header, signed string, signature []byte) error {
+	alg, ok := algorithms[h.Alg]
+	if !ok {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, h.Alg)
+	}
+
+	for _, k := range v.keys {
+		if h.Kid != "" && k.kid != "" && h.Kid != k.kid {
+			continue
+		}
+		// key is used only with algorithm of its type, e.g. public rsa key can't be hmac secret
+		if alg.verify(k, signed, signature) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: signature isn't valid", ErrInvalidToken)
+}
+
+func (v *Verifier) checkClaims(c claims) error {
+	now := v.now()
+	if c.Subject == "" {
+		return fmt.Errorf("%w: sub is required", ErrInvalidToken)
+	}
+	if c.ExpiresAt == nil {
+		return fmt.Errorf("%w: exp is required", ErrInvalidToken)
+	}
+	if now.After(time.Unix(*c.ExpiresAt, 0).Add(v.cfg.Leeway)) {
+		return ErrTokenExpired
+	}
+	if c.NotBefore != nil && now.Add(v.cfg.Leeway).Before(time.Unix(*c.NotBefore, 0)) {
+		return fmt.Errorf("%w: token isn't valid yet", ErrInvalidToken)
+	}
+	if v.cfg.Issuer != "" && c.Issuer != v.cfg.Issuer {
+		return fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, c.Issuer)
+	}
+	if v.cfg.Audience != "" && !contains(c.Audience, v.cfg.Audience) {
+		return fmt.Errorf("%w: token isn't issued for %q", ErrInvalidToken, v.cfg.Audience)
+	}
+	return nil
+}
+
+// parseList parses claim of array of strings or space separated string
+func parseList(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list, nil
+	}
+	var joined string
+	if err := json.Unmarshal(raw, &joined); err != nil {
+		return nil, fmt.Errorf("claim should be array or string")
+	}
+	return strings.Fields(joined), nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type algorithm struct {
+	hash crypto.Hash
+	kind string
+}
+
+var algorithms = map[string]algorithm{
+	"RS256": {crypto.SHA256, "rsa"}, "RS384": {crypto.SHA384, "rsa"}, "RS512": {crypto.SHA512, "rsa"},
+	"PS256": {crypto.SHA256, "rsa-pss"}, "PS384": {crypto.SHA384, "rsa-pss"}, "PS512": {crypto.SHA512, "rsa-pss"},
+	"ES256": {crypto.SHA256, "ecdsa"}, "ES384": {crypto.SHA384, "ecdsa"}, "ES512": {crypto.SHA512, "ecdsa"},
+	"HS256": {crypto.SHA256, "hmac"}, "HS384": {crypto.SHA384, "hmac"}, "HS512": {crypto.SHA512, "hmac"},
+}
+
+func (a algorithm) verify(k key, signed string, signature []byte) bool {
+	if a.kind == "hmac" {
+		if k.secret == nil {
+			return false
+		}
+		mac := hmac.New(a.hash.New, k.secret)
+		mac.Write([]byte(signed))
+		return hmac.Equal(signature, mac.Sum(nil))
+	}
+
+	h := a.hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	switch public := k.public.(type) {
+	case *rsa.PublicKey:
+		if a.kind == "rsa" {
+			return rsa.VerifyPKCS1v15(public, a.hash, digest, signature) == nil
+		}
+		if a.kind == "rsa-pss" {
+			return rsa.VerifyPSS(public, a.hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}) == nil
+		}
+	case *ecdsa.PublicKey:
+		// signature is r and s of curve size each
+		size := (public.Curve.Params().BitSize + 7) / 8
+		if a.kind != "ecdsa" || len(signature) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(public, digest, r, s)
+	}
+	return false
+}
@@ -0,0 +1,232 @@
+package auth_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifier_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	secret := []byte("0123456789abcdef0123456789abcdef")
+
+	dir := t.TempDir()
+	jwksFile := filepath.Join(dir, "jwks.json")
+	writeJSON(t, jwksFile, map[string]interface{}{"keys": []map[string]string{
+		{"kty": "EC", "kid": "ec", "use": "sig", "crv": "P-256", "x": encode(ecKey.X.Bytes()), "y": encode(ecKey.Y.Bytes())},
+		{"kty": "oct", "kid": "hmac", "k": encode(secret)},
+		{"kty": "oct", "kid": "enc", "use": "enc", "k": encode([]byte("ignored"))},
+	}})
+	publicKey, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	publicKeyFile := filepath.Join(dir, "public.pem")
+	require.NoError(t, ioutil.WriteFile(publicKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}), 0600))
+
+	verifier, err := auth.NewVerifier(auth.Config{
+		Issuer:         "https://issuer",
+		Audience:       "api",
+		JWKSFile:       jwksFile,
+		PublicKeyFiles: []string{publicKeyFile},
+	})
+	require.NoError(t, err)
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"sub":   "603bd5e5967f2dba00c8e325",
+			"iss":   "https://issuer",
+			"aud":   []string{"other", "api"},
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"roles": []string{auth.RoleOperator},
+		}
+	}
+	with := func(name string, value interface{}) map[string]interface{} {
+		c := valid()
+		if value == nil {
+			delete(c, name)
+		} else {
+			c[name] = value
+		}
+		return c
+	}
+
+	rsaSign := func(signed []byte) []byte {
+		digest := sha256.Sum256(signed)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+		return signature
+	}
+	ecSign := func(signed []byte) []byte {
+		digest := sha256.Sum256(signed)
+		r, s, err := ecdsa.Sign(rand.Reader, ecKey, digest[:])
+		require.NoError(t, err)
+		signature := make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+		return signature
+	}
+	hmacSign := func(secret []byte) func([]byte) []byte {
+		return func(signed []byte) []byte {
+			mac := hmac.New(sha256.New, secret)
+			mac.Write(signed)
+			return mac.Sum(nil)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		header  map[string]string
+		claims  map[string]interface{}
+		sign    func([]byte) []byte
+		roles   []string
+		wantErr error
+	}{
+		{
+			name:   "rsa",
+			header: map[string]string{"alg": "RS256"},
+			claims: valid(),
+			sign:   rsaSign,
+			roles:  []string{auth.RoleOperator},
+		},
+		{
+			name:   "ec of jwks",
+			header: map[string]string{"alg": "ES256", "kid": "ec"},
+			claims: with("roles", "customer admin"),
+			sign:   ecSign,
+			roles:  []string{auth.RoleCustomer, auth.RoleAdmin},
+		},
+		{
+			name:   "hmac of jwks",
+			header: map[string]string{"alg": "HS256", "kid": "hmac"},
+			claims: with("aud", "api"),
+			sign:   hmacSign(secret),
+			roles:  []string{auth.RoleOperator},
+		},
+		{
+			name:    "expired",
+			header:  map[string]string{"alg": "RS256"},
+			claims:  with("exp", time.Now().Add(-time.Hour).Unix()),
+			sign:    rsaSign,
+			wantErr: auth.ErrTokenExpired,
+		},
+		{
+			name:    "without exp",
+			header:  map[string]string{"alg": "RS256"},
+			claims:  with("exp", nil),
+			sign:    rsaSign,
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "not valid yet",
+			header:  map[string]string{"alg": "RS256"},
+			claims:  with("nbf", time.Now().Add(time.Hour).Unix()),
+			sign:    rsaSign,
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "issuer",
+			header:  map[string]string{"alg": "RS256"},
+			claims:  with("iss", "https://another"),
+			sign:    rsaSign,
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "audience",
+			header:  map[string]string{"alg": "RS256"},
+			claims:  with("aud", "another"),
+			sign:    rsaSign,
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "wrong kid",
+			header:  map[string]string{"alg": "HS256", "kid": "ec"},
+			claims:  valid(),
+			sign:    hmacSign(secret),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "wrong signature",
+			header:  map[string]string{"alg": "HS256", "kid": "hmac"},
+			claims:  valid(),
+			sign:    hmacSign([]byte("another secret")),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "public key as hmac secret",
+			header:  map[string]string{"alg": "HS256"},
+			claims:  valid(),
+			sign:    hmacSign(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "none",
+			header:  map[string]string{"alg": "none"},
+			claims:  valid(),
+			sign:    func([]byte) []byte { return nil },
+			wantErr: auth.ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := verifier.Verify(token(t, tt.header, tt.claims, tt.sign))
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "603bd5e5967f2dba00c8e325", principal.Subject)
+			assert.Equal(t, tt.roles, principal.Roles)
+		})
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	_, err := auth.NewVerifier(auth.Config{})
+	assert.Error(t, err)
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	writeJSON(t, jwksFile, map[string]interface{}{"keys": []map[string]string{{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}}})
+	_, err = auth.NewVerifier(auth.Config{JWKSFile: jwksFile})
+	assert.Error(t, err)
+}
+
+func token(t *testing.T, header map[string]string, claims map[string]interface{}, sign func([]byte) []byte) string {
+	t.Helper()
+	h, err := json.Marshal(header)
+	require.NoError(t, err)
+	c, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed := encode(h) + "." + encode(c)
+	return signed + "." + encode(sign([]byte(signed)))
+}
+
+func writeJSON(t *testing.T, path string, v interface{}) {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
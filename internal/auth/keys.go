@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+)
+
+// key is verification key, kid is optional
+type key struct {
+	kid    string
+	public crypto.PublicKey
+	// secret is set for symmetric key
+	secret []byte
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	// symmetric
+	K string `json:"k"`
+}
+
+// loadJWKS reads keys of JSON Web Key Set, keys which aren't used for signatures are skipped
+func loadJWKS(path string) ([]key, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read jwks file: %w", err)
+	}
+
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("couldn't parse jwks file: %w", err)
+	}
+
+	var keys []key
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		parsed, err := k.parse()
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %d of jwks: %w", i, err)
+		}
+		keys = append(keys, parsed)
+	}
+	return keys, nil
+}
+
+func (k jwk) parse() (key, error) {
+	var res = key{kid: k.Kid}
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return key{}, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return key{}, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return key{}, fmt.Errorf("invalid rsa exponent")
+		}
+		res.public = &rsa.PublicKey{N: n, E: int(e.Int64())}
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return key{}, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return key{}, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return key{}, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return key{}, fmt.Errorf("point isn't on curve %s", k.Crv)
+		}
+		res.public = &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil {
+			return key{}, err
+		}
+		if len(secret) == 0 {
+			return key{}, fmt.Errorf("empty symmetric key")
+		}
+		res.secret = secret
+	default:
+		return key{}, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+	return res, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("empty key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// loadPublicKey reads PEM encoded public key or certificate, such key matches tokens with any kid
+func loadPublicKey(path string) (key, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return key{}, fmt.Errorf("couldn't read public key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return key{}, fmt.Errorf("file %s doesn't contain pem block", path)
+	}
+
+	var public crypto.PublicKey
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return key{}, err
+		}
+		public = cert.PublicKey
+	case "RSA PUBLIC KEY":
+		if public, err = x509.ParsePKCS1PublicKey(block.Bytes); err != nil {
+			return key{}, err
+		}
+	default:
+		if public, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return key{}, err
+		}
+	}
+
+	switch public.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return key{public: public}, nil
+	}
+	return key{}, fmt.Errorf("unsupported public key %T in %s", public, path)
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Permission is required by method, caller should have any of roles and all scopes, roles are required,
+// so callers with unknown roles are denied
+type Permission struct {
+	Roles  []string `json:"roles"`
+	Scopes []string `json:"scopes"`
+}
+
+// Policy is permissions by full grpc method name, e.g. /api.ApplicationService/DeleteApplication,
+// methods which aren't in policy are denied
+type Policy map[string]Permission
+
+var ErrPermissionDenied = fmt.Errorf("permission denied")
+
+const (
+	applicationService = "/api.ApplicationService/"
+	webhookService     = "/api.WebhookService/"
+)
+
+// DefaultPolicy lets customers create and view applications, operators list all applications and update them,
+// admins change applications in bulk, delete them and manage webhooks
+func DefaultPolicy() Policy {
+	var (
+		everyone = Permission{Roles: []string{RoleCustomer, RoleOperator, RoleAdmin}}
+		staff    = Permission{Roles: []string{RoleOperator, RoleAdmin}}
+		admin    = Permission{Roles: []string{RoleAdmin}}
+	)
+	return Policy{
+		applicationService + "CreateApplication":            everyone,
+		applicationService + "CreateApplications":           everyone,
+		applicationService + "GetApplicationById":           everyone,
+		applicationService + "GetApplicationsByFilters":     everyone,
+		applicationService + "UpdateApplication":            staff,
+		applicationService + "AssignApplication":            staff,
+		applicationService + "UnassignApplication":          staff,
+		applicationService + "BulkUpdateApplicationsStatus": admin,
+		applicationService + "DeleteApplication":            admin,
+		applicationService + "RestoreApplication":           admin,
+		webhookService + "CreateWebhookSubscription":        admin,
+		webhookService + "GetWebhookSubscription":           admin,
+		webhookService + "ListWebhookSubscriptions":         admin,
+		webhookService + "UpdateWebhookSubscription":        admin,
+		webhookService + "DeleteWebhookSubscription":        admin,
+		webhookService + "ListWebhookDeliveries":            admin,
+		webhookService + "RedeliverWebhookDelivery":         admin,
+	}
+}
+
+func (p *Policy) Decode(value string) error {
+	return json.Unmarshal([]byte(value), (*map[string]Permission)(p))
+}
+
+// Validate checks that every method of policy is registered grpc method
+func (p Policy) Validate() error {
+	for method := range p {
+		if !strings.HasPrefix(method, "/") || strings.Count(method, "/") != 2 {
+			return fmt.Errorf("method %q of policy should be /<service>/<method>", method)
+		}
+		name := strings.Replace(strings.TrimPrefix(method, "/"), "/", ".", 1)
+		d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return fmt.Errorf("method %q of policy is unknown", method)
+		}
+		if _, ok := d.(protoreflect.MethodDescriptor); !ok {
+			return fmt.Errorf("%q of policy isn't method", method)
+		}
+		if len(p[method].Roles) == 0 {
+			return fmt.Errorf("method %q of policy doesn't have roles", method)
+		}
+	}
+	return nil
+}
+
+// WithDefaults returns default policy overridden by methods of policy
+func (p Policy) WithDefaults() Policy {
+	res := DefaultPolicy()
+	for method, permission := range p {
+		res[method] = permission
+	}
+	return res
+}
+
+// Authorize returns ErrPermissionDenied with name of missing permission
+func (p Policy) Authorize(principal *Principal, method string) error {
+	permission, ok := p[method]
+	if !ok {
+		return fmt.Errorf("%w: method %s isn't allowed by policy", ErrPermissionDenied, method)
+	}
+	if missing := permission.missing(principal); missing != "" {
+		return fmt.Errorf("%w: %s is required for %s", ErrPermissionDenied, missing, method)
+	}
+	return nil
+}
+
+// missing returns the first permission which principal doesn't have, e.g. role:admin or scope:applications.write
+func (p Permission) missing(principal *Principal) string {
+	var allowed bool
+	for _, role := range p.Roles {
+		allowed = allowed || principal.HasRole(role)
+	}
+	if !allowed {
+		roles := append([]string(nil), p.Roles...)
+		sort.Strings(roles)
+		return "role:" + strings.Join(roles, "|")
+	}
+	for _, scope := range p.Scopes {
+		if !principal.HasScope(scope) {
+			return "scope:" + scope
+		}
+	}
+	return ""
+}
+
+// UnaryInterceptor authorizes principal of context, it should be chained after interceptor of Verifier
+func (p Policy) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := p.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (p Policy) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := p.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func (p Policy) authorize(ctx context.Context, method string) error {
+	if isPublic(method) {
+		return nil
+	}
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "bearer token is required")
+	}
+
+	if err := p.Authorize(principal, method); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
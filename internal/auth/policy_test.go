@@ -0,0 +1,112 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/PxyUp/backend_tech_task/internal/auth"
+	// descriptors of methods of policy
+	_ "github.com/PxyUp/backend_tech_task/pkg/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPolicy_Authorize(t *testing.T) {
+	var policy auth.Policy
+	require.NoError(t, policy.Decode(`{"/api.ApplicationService/DeleteApplication": {"roles": ["admin"], "scopes": ["applications.delete"]}}`))
+	require.NoError(t, policy.Validate())
+	policy = policy.WithDefaults()
+
+	var (
+		customer = &auth.Principal{Subject: "customer", Roles: []string{auth.RoleCustomer}}
+		operator = &auth.Principal{Subject: "operator", Roles: []string{auth.RoleOperator}}
+		admin    = &auth.Principal{Subject: "admin", Roles: []string{auth.RoleAdmin}, Scopes: []string{"applications.delete"}}
+	)
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		method    string
+		missing   string
+	}{
+		{name: "customer creates", principal: customer, method: "/api.ApplicationService/CreateApplication"},
+		{name: "customer reads", principal: customer, method: "/api.ApplicationService/GetApplicationsByFilters"},
+		{name: "customer updates", principal: customer, method: "/api.ApplicationService/UpdateApplication", missing: "role:admin|operator"},
+		{name: "operator updates", principal: operator, method: "/api.ApplicationService/UpdateApplication"},
+		{name: "operator updates in bulk", principal: operator, method: "/api.ApplicationService/BulkUpdateApplicationsStatus", missing: "role:admin"},
+		{name: "admin updates in bulk", principal: admin, method: "/api.ApplicationService/BulkUpdateApplicationsStatus"},
+		{name: "admin deletes", principal: admin, method: "/api.ApplicationService/DeleteApplication"},
+		{
+			name:      "admin without scope deletes",
+			principal: &auth.Principal{Subject: "admin", Roles: []string{auth.RoleAdmin}},
+			method:    "/api.ApplicationService/DeleteApplication",
+			missing:   "scope:applications.delete",
+		},
+		{name: "operator manages webhooks", principal: operator, method: "/api.WebhookService/CreateWebhookSubscription", missing: "role:admin"},
+		{name: "unknown method", principal: admin, method: "/api.ApplicationService/Unknown", missing: "isn't allowed by policy"},
+		{
+			name:      "unknown role",
+			principal: &auth.Principal{Subject: "billing", Roles: []string{"billing"}},
+			method:    "/api.ApplicationService/GetApplicationById",
+			missing:   "role:admin|customer|operator",
+		},
+		{name: "without roles", principal: &auth.Principal{Subject: "user"}, method: "/api.ApplicationService/CreateApplication", missing: "role:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Authorize(tt.principal, tt.method)
+			if tt.missing == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, auth.ErrPermissionDenied), err)
+			assert.Contains(t, err.Error(), tt.missing)
+		})
+	}
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	for _, method := range []string{"CreateApplication", "/api.ApplicationService", "/api.ApplicationService/Unknown", "/api.Application/Status"} {
+		assert.Error(t, auth.Policy{method: {Roles: []string{auth.RoleAdmin}}}.Validate(), method)
+	}
+	assert.Error(t, auth.Policy{"/api.ApplicationService/CreateApplication": {Scopes: []string{"applications.write"}}}.Validate())
+}
+
+func TestRequireRole(t *testing.T) {
+	assert.NoError(t, auth.RequireRole(context.Background(), auth.RoleAdmin, "include_deleted"))
+
+	adminCtx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "admin", Roles: []string{auth.RoleAdmin}})
+	assert.NoError(t, auth.RequireRole(adminCtx, auth.RoleAdmin, "include_deleted"))
+
+	operatorCtx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "operator", Roles: []string{auth.RoleOperator}})
+	err := auth.RequireRole(operatorCtx, auth.RoleAdmin, "include_deleted")
+	assert.True(t, errors.Is(err, auth.ErrPermissionDenied), err)
+	assert.Contains(t, err.Error(), "role:admin is required for include_deleted")
+}
+
+func TestPrincipal_IsCustomer(t *testing.T) {
+	assert.True(t, auth.Principal{Roles: []string{auth.RoleCustomer}}.IsCustomer())
+	assert.False(t, auth.Principal{Roles: []string{auth.RoleCustomer, auth.RoleOperator}}.IsCustomer())
+	assert.False(t, auth.Principal{Roles: []string{"billing"}}.IsCustomer())
+	assert.False(t, auth.Principal{}.IsCustomer())
+}
+
+func TestPolicy_UnaryInterceptor(t *testing.T) {
+	policy := auth.DefaultPolicy()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(ctx context.Context, method string) error {
+		_, err := policy.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	operatorCtx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "operator", Roles: []string{auth.RoleOperator}})
+	assert.NoError(t, call(operatorCtx, "/api.ApplicationService/UpdateApplication"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(call(operatorCtx, "/api.ApplicationService/DeleteApplication")))
+	assert.Equal(t, codes.Unauthenticated, status.Code(call(context.Background(), "/api.ApplicationService/UpdateApplication")))
+	assert.NoError(t, call(context.Background(), "/grpc.health.v1.Health/Check"))
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// RoleCustomer can create and read only own applications
+	RoleCustomer = "customer"
+	// RoleOperator handles applications of all customers
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+// Principal is caller authenticated by token
+type Principal struct {
+	// Subject is id of user
+	Subject string
+	Roles   []string
+	Scopes  []string
+}
+
+func (p Principal) HasRole(role string) bool {
+	return contains(p.Roles, role)
+}
+
+func (p Principal) HasScope(scope string) bool {
+	return contains(p.Scopes, scope)
+}
+
+// IsCustomer is true for principal with customer role and without staff role,
+// principals with unknown roles aren't customers and are denied by policy unless it lists their roles
+func (p Principal) IsCustomer() bool {
+	return p.HasRole(RoleCustomer) && !p.HasRole(RoleOperator) && !p.HasRole(RoleAdmin)
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns caller of request, it's missing if authentication is disabled
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// CustomerFromContext returns subject of caller if access of caller is limited to own applications
+func CustomerFromContext(ctx context.Context) (string, bool) {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || !p.IsCustomer() {
+		return "", false
+	}
+	return p.Subject, true
+}
+
+// RequireRole checks role of caller for action which isn't covered by policy of methods, e.g. flag of request,
+// it's allowed for every call if authentication is disabled
+func RequireRole(ctx context.Context, role string, action string) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || p.HasRole(role) {
+		return nil
+	}
+	return fmt.Errorf("%w: role:%s is required for %s", ErrPermissionDenied, role, action)
+}
@@ -14,6 +14,8 @@ import (
 //go:generate mockgen -destination=mock/client.go -package=external_mock "github.com/PxyUp/backend_tech_task/internal/external" Client
 type Client interface {
 	GetExternalStatus(ctx context.Context, id string) (Status, error)
+	// Ping checks that external service is reachable, any response except server error is fine
+	Ping(ctx context.Context) error
 }
 
 type Config struct {
@@ -74,6 +76,26 @@ func (c client) GetExternalStatus(ctx context.Context, id string) (Status, error
 	return status, nil
 }
 
+func (c client) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.RequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("external service isn't available: %s", resp.Status)
+	}
+	return nil
+}
+
 func NewClient(cfg Config) (Client, error) {
 	u, err := url.Parse(cfg.URL)
 	if err != nil {
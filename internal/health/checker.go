@@ -0,0 +1,164 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	grpc_health "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type Config struct {
+	// Interval is period of checking dependencies
+	Interval time.Duration `envconfig:"interval"`
+	// Timeout limits every check
+	Timeout time.Duration `envconfig:"timeout"`
+}
+
+const (
+	defaultInterval = 10 * time.Second
+	defaultTimeout  = 3 * time.Second
+)
+
+// Check returns error if dependency isn't available
+type Check func(ctx context.Context) error
+
+// Checker reports status of every dependency to grpc health service under its name,
+// overall status and status of every served service are SERVING only if all required dependencies are available
+type Checker struct {
+	cfg    Config
+	server *grpc_health.Server
+
+	mu     sync.Mutex
+	names  []string
+	checks []Check
+	// optional are dependencies which don't change overall status
+	optional []bool
+	services []string
+	// serving is the last overall status
+	serving bool
+}
+
+// NewChecker returns checker which reports NOT_SERVING until the first successful check
+func NewChecker(cfg Config) *Checker {
+	if cfg.Interval == 0 {
+		cfg.Interval = defaultInterval
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	c := &Checker{cfg: cfg, server: grpc_health.NewServer()}
+	c.server.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// Server returns grpc health service
+func (c *Checker) Server() grpc_health_v1.HealthServer {
+	return c.server
+}
+
+// AddCheck adds required dependency with name
+func (c *Checker) AddCheck(name string, check Check) {
+	c.addCheck(name, check, false)
+}
+
+// AddOptionalCheck adds dependency with name which has own status only, e.g. service required by some calls
+func (c *Checker) AddOptionalCheck(name string, check Check) {
+	c.addCheck(name, check, true)
+}
+
+func (c *Checker) addCheck(name string, check Check, optional bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.names = append(c.names, name)
+	c.checks = append(c.checks, check)
+	c.optional = append(c.optional, optional)
+	c.server.SetServingStatus(name, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+}
+
+// AddService adds served service, its status is overall status
+func (c *Checker) AddService(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.services = append(c.services, name)
+	c.server.SetServingStatus(name, servingStatus(c.serving))
+}
+
+// Run checks dependencies until ctx is done
+func (c *Checker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		c.CheckOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckOnce runs all checks concurrently and updates statuses, it returns true if all required dependencies are available
+func (c *Checker) CheckOnce(ctx context.Context) bool {
+	c.mu.Lock()
+	var (
+		names    = c.names
+		checks   = c.checks
+		optional = c.optional
+	)
+	c.mu.Unlock()
+
+	var (
+		errs = make([]error, len(checks))
+		wg   sync.WaitGroup
+	)
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+			defer cancel()
+			errs[i] = check(checkCtx)
+		}(i, check)
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var serving = true
+	for i, err := range errs {
+		if err != nil {
+			log.Warn().Err(err).Str("dependency", names[i]).Msg("dependency isn't available")
+			serving = serving && optional[i]
+		}
+		c.server.SetServingStatus(names[i], servingStatus(err == nil))
+	}
+	if serving != c.serving {
+		log.Info().Bool("serving", serving).Msg("serving status has been changed")
+	}
+	c.serving = serving
+
+	c.server.SetServingStatus("", servingStatus(serving))
+	for _, name := range c.services {
+		c.server.SetServingStatus(name, servingStatus(serving))
+	}
+	return serving
+}
+
+func servingStatus(serving bool) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if serving {
+		return grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+}
+
+// Shutdown reports NOT_SERVING for everything, statuses aren't changed by checks after it
+func (c *Checker) Shutdown() {
+	c.server.Shutdown()
+}
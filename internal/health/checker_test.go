@@ -0,0 +1,75 @@
+package health_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/PxyUp/backend_tech_task/internal/health"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestChecker_CheckOnce(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		mongoErr    error
+		externalErr error
+	)
+
+	checker := health.NewChecker(health.Config{})
+	checker.AddCheck("mongo", func(context.Context) error { return mongoErr })
+	checker.AddOptionalCheck("external", func(context.Context) error { return externalErr })
+
+	assertStatuses := func(t *testing.T, exp map[string]grpc_health_v1.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		for service, status := range exp {
+			resp, err := checker.Server().Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+			require.NoError(t, err)
+			assert.Equal(t, status, resp.Status, "status of %q", service)
+		}
+	}
+
+	// nothing is served before the first check
+	assertStatuses(t, map[string]grpc_health_v1.HealthCheckResponse_ServingStatus{
+		"":      grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		"mongo": grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+	})
+
+	mongoErr = fmt.Errorf("connection refused")
+	assert.False(t, checker.CheckOnce(ctx))
+	checker.AddService("api.ApplicationService")
+	assertStatuses(t, map[string]grpc_health_v1.HealthCheckResponse_ServingStatus{
+		"":                       grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		"api.ApplicationService": grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		"mongo":                  grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		"external":               grpc_health_v1.HealthCheckResponse_SERVING,
+	})
+
+	mongoErr = nil
+	assert.True(t, checker.CheckOnce(ctx))
+	assertStatuses(t, map[string]grpc_health_v1.HealthCheckResponse_ServingStatus{
+		"":                       grpc_health_v1.HealthCheckResponse_SERVING,
+		"api.ApplicationService": grpc_health_v1.HealthCheckResponse_SERVING,
+		"mongo":                  grpc_health_v1.HealthCheckResponse_SERVING,
+	})
+
+	// optional dependency has own status only
+	externalErr = fmt.Errorf("connection refused")
+	assert.True(t, checker.CheckOnce(ctx))
+	assertStatuses(t, map[string]grpc_health_v1.HealthCheckResponse_ServingStatus{
+		"":                       grpc_health_v1.HealthCheckResponse_SERVING,
+		"api.ApplicationService": grpc_health_v1.HealthCheckResponse_SERVING,
+		"external":               grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+	})
+
+	checker.Shutdown()
+	assert.True(t, checker.CheckOnce(ctx))
+	assertStatuses(t, map[string]grpc_health_v1.HealthCheckResponse_ServingStatus{
+		"":                       grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		"api.ApplicationService": grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		"mongo":                  grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+	})
+}
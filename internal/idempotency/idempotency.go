@@ -0,0 +1,58 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Record binds idempotency key to resource created by first request with the key
+type Record struct {
+	Key string
+	// Fingerprint is hash of payload of request, repeat with another payload is rejected
+	Fingerprint string
+	ResourceID  string
+	CreatedAt   time.Time
+	// ExpiresAt is time after which key can be used again
+	ExpiresAt time.Time
+}
+
+func (r Record) Expired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+type Store interface {
+	// Reserve saves record if key isn't used, otherwise it returns existing record
+	Reserve(ctx context.Context, record *Record) (*Record, error)
+	// Extend sets expiration of record of the same resource, it's used when resource has been created
+	Extend(ctx context.Context, record *Record) error
+	// Release removes record of the same resource, it's used when request has failed and can be retried
+	Release(ctx context.Context, record *Record) error
+}
+
+// NopStore never keeps keys, it's used when idempotency isn't configured
+type NopStore struct{}
+
+func (NopStore) Reserve(context.Context, *Record) (*Record, error) {
+	return nil, nil
+}
+
+func (NopStore) Extend(context.Context, *Record) error {
+	return nil
+}
+
+func (NopStore) Release(context.Context, *Record) error {
+	return nil
+}
+
+// Fingerprint returns hash of parts of payload
+func Fingerprint(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		// separator keeps ("ab", "c") and ("a", "bc") different
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,51 @@
+package idempotency_memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/idempotency"
+)
+
+// Store keeps idempotency keys in memory, it's used with storages without mongo
+type Store struct {
+	mu      sync.Mutex
+	records map[string]idempotency.Record
+}
+
+func NewStore() *Store {
+	return &Store{records: make(map[string]idempotency.Record)}
+}
+
+func (s *Store) Reserve(_ context.Context, record *idempotency.Record) (*idempotency.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.records[record.Key]; ok && !existing.Expired(time.Now()) {
+		return &existing, nil
+	}
+	s.records[record.Key] = *record
+	return nil, nil
+}
+
+func (s *Store) Extend(_ context.Context, record *idempotency.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.records[record.Key]; ok && existing.ResourceID == record.ResourceID {
+		existing.ExpiresAt = record.ExpiresAt
+		s.records[record.Key] = existing
+	}
+	return nil
+}
+
+func (s *Store) Release(_ context.Context, record *idempotency.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.records[record.Key]; ok && existing.ResourceID == record.ResourceID {
+		delete(s.records, record.Key)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package idempotency_mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/idempotency"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const collectionName = "idempotency_keys"
+
+var expireAfter time.Duration
+
+// Indexes make key unique and remove expired keys
+var Indexes = []mongoutil.Index{
+	{
+		Name:   "key",
+		Keys:   bson.D{{Key: "key", Value: 1}},
+		Unique: true,
+	},
+	{
+		Name:        "expires_at",
+		Keys:        bson.D{{Key: "expires_at", Value: 1}},
+		ExpireAfter: &expireAfter,
+	},
+}
+
+type Store struct {
+	coll *mongo.Collection
+}
+
+func NewStore(db *mongo.Database) *Store {
+	return &Store{coll: db.Collection(collectionName)}
+}
+
+func (s Store) EnsureIndexes(ctx context.Context, mode mongoutil.IndexMode) error {
+	_, err := mongoutil.EnsureIndexes(ctx, s.coll, Indexes, mode)
+	return err
+}
+
+type RecordModel struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	Key         string             `bson:"key"`
+	Fingerprint string             `bson:"fingerprint"`
+	ResourceID  string             `bson:"resource_id"`
+	CreatedAt   primitive.DateTime `bson:"created_at"`
+	ExpiresAt   primitive.DateTime `bson:"expires_at"`
+}
+
+func NewRecordModel(record *idempotency.Record) *RecordModel {
+	return &RecordModel{
+		ID:          primitive.NewObjectID(),
+		Key:         record.Key,
+		Fingerprint: record.Fingerprint,
+		ResourceID:  record.ResourceID,
+		CreatedAt:   primitive.NewDateTimeFromTime(record.CreatedAt),
+		ExpiresAt:   primitive.NewDateTimeFromTime(record.ExpiresAt),
+	}
+}
+
+func ParseRecordModel(m *RecordModel) *idempotency.Record {
+	return &idempotency.Record{
+		Key:         m.Key,
+		Fingerprint: m.Fingerprint,
+		ResourceID:  m.ResourceID,
+		CreatedAt:   m.CreatedAt.Time().UTC(),
+		ExpiresAt:   m.ExpiresAt.Time().UTC(),
+	}
+}
+
+// Reserve relies on unique index of key, expired record which hasn't been removed
+// by ttl monitor yet is replaced
+func (s Store) Reserve(ctx context.Context, record *idempotency.Record) (*idempotency.Record, error) {
+	m := NewRecordModel(record)
+	_, err := s.coll.InsertOne(ctx, m)
+	if err == nil {
+		return nil, nil
+	}
+	if !mongoutil.IsDuplicateKeyError(err) {
+		return nil, err
+	}
+
+	var existing RecordModel
+	if err := s.coll.FindOne(ctx, bson.D{{Key: "key", Value: record.Key}}).Decode(&existing); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			// record has been removed by ttl monitor right now
+			return s.Reserve(ctx, record)
+		}
+		return nil, err
+	}
+	if !ParseRecordModel(&existing).Expired(time.Now()) {
+		return ParseRecordModel(&existing), nil
+	}
+
+	m.ID = existing.ID
+	res, err := s.coll.ReplaceOne(ctx, bson.D{
+		{Key: "_id", Value: existing.ID},
+		{Key: "expires_at", Value: existing.ExpiresAt},
+	}, m)
+	if err != nil {
+		return nil, err
+	}
+	if res.MatchedCount == 0 {
+		// another request has replaced expired record first
+		return s.Reserve(ctx, record)
+	}
+	return nil, nil
+}
+
+// Extend doesn't change record which has replaced expired one of resource
+func (s Store) Extend(ctx context.Context, record *idempotency.Record) error {
+	_, err := s.coll.UpdateOne(
+		ctx,
+		bson.D{{Key: "key", Value: record.Key}, {Key: "resource_id", Value: record.ResourceID}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "expires_at", Value: primitive.NewDateTimeFromTime(record.ExpiresAt)}}}},
+	)
+	return err
+}
+
+func (s Store) Release(ctx context.Context, record *idempotency.Record) error {
+	_, err := s.coll.DeleteOne(ctx, bson.D{{Key: "key", Value: record.Key}, {Key: "resource_id", Value: record.ResourceID}})
+	return err
+}
@@ -0,0 +1,112 @@
+package idempotency_mongo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/idempotency"
+	idempotency_mongo "github.com/PxyUp/backend_tech_task/internal/idempotency/mongo"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil/mongotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStore(t *testing.T, prefix string) *idempotency_mongo.Store {
+	store := idempotency_mongo.NewStore(mongotest.NewDB(t, mongotest.NewClient(t), prefix))
+	require.NoError(t, store.EnsureIndexes(context.Background(), mongoutil.IndexModeSync))
+	return store
+}
+
+// newRecord returns record which expires after ttl, mongo keeps time with milliseconds precision
+func newRecord(key, resourceID string, ttl time.Duration) *idempotency.Record {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	return &idempotency.Record{
+		Key:         key,
+		Fingerprint: idempotency.Fingerprint(resourceID),
+		ResourceID:  resourceID,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+func TestStore_Reserve(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		store  = newStore(t, "test_idempotency")
+		record = newRecord("key", "first", time.Minute)
+	)
+
+	existing, err := store.Reserve(ctx, record)
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	existing, err = store.Reserve(ctx, newRecord("key", "second", time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, record, existing)
+
+	// another key is independent
+	existing, err = store.Reserve(ctx, newRecord("another", "third", time.Minute))
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+}
+
+func TestStore_ReserveExpired(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		store   = newStore(t, "test_idempotency_expired")
+		expired = newRecord("key", "first", -time.Second)
+		record  = newRecord("key", "second", time.Minute)
+	)
+
+	existing, err := store.Reserve(ctx, expired)
+	require.NoError(t, err)
+	require.Nil(t, existing)
+
+	// expired record which hasn't been removed by ttl monitor is replaced
+	existing, err = store.Reserve(ctx, record)
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	existing, err = store.Reserve(ctx, newRecord("key", "third", time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, record, existing)
+
+	// expired record doesn't change record which has replaced it
+	expired.ExpiresAt = time.Now().UTC().Add(time.Hour)
+	require.NoError(t, store.Extend(ctx, expired))
+	require.NoError(t, store.Release(ctx, expired))
+
+	existing, err = store.Reserve(ctx, newRecord("key", "third", time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, record, existing)
+}
+
+func TestStore_ExtendAndRelease(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		store  = newStore(t, "test_idempotency_lease")
+		record = newRecord("key", "first", time.Minute)
+	)
+
+	existing, err := store.Reserve(ctx, record)
+	require.NoError(t, err)
+	require.Nil(t, existing)
+
+	record.ExpiresAt = record.CreatedAt.Add(24 * time.Hour)
+	require.NoError(t, store.Extend(ctx, record))
+
+	existing, err = store.Reserve(ctx, newRecord("key", "second", time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, record, existing)
+
+	require.NoError(t, store.Release(ctx, record))
+
+	// released key is reserved again
+	retry := newRecord("key", "second", time.Minute)
+	existing, err = store.Reserve(ctx, retry)
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+}
@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil/migrate"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Dates of applications were stored as unix seconds inside of mongo date (milliseconds),
+// so all of them point to January 1970 and couldn't be compared with real dates in range filters.
+func init() {
+	register(migrate.Migration{
+		Version: 20210310120000,
+		Name:    "application_dates_in_milliseconds",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return convertApplicationDates(ctx, db, "$multiply")
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return convertApplicationDates(ctx, db, "$divide")
+		},
+	})
+}
+
+// legacy dates (seconds as milliseconds) fit in a few years around the epoch,
+// including zero time.Time which is stored for never updated applications
+var (
+	legacyDatesFrom = time.Unix(-100000000, 0)
+	legacyDatesTo   = time.Unix(100000000, 0)
+)
+
+func convertApplicationDates(ctx context.Context, db *mongo.Database, op string) error {
+	coll := db.Collection("applications")
+
+	for _, field := range []string{"created_at", "updated_at"} {
+		var filter = bson.D{{Key: field, Value: bson.D{
+			{Key: "$gt", Value: legacyDatesFrom},
+			{Key: "$lt", Value: legacyDatesTo},
+		}}}
+		if op == "$divide" {
+			// converted dates are out of legacy range
+			filter = bson.D{
+				{Key: field, Value: bson.D{{Key: "$type", Value: "date"}}},
+				{Key: "$nor", Value: bson.A{filter}},
+			}
+		}
+
+		if _, err := coll.UpdateMany(ctx, filter, bson.A{
+			bson.D{{Key: "$set", Value: bson.D{{Key: field, Value: bson.D{{Key: "$toDate", Value: bson.D{
+				{Key: "$toLong", Value: bson.D{{Key: op, Value: bson.A{
+					bson.D{{Key: "$toLong", Value: "$" + field}},
+					1000,
+				}}}},
+			}}}}}}},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
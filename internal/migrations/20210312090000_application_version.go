@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil/migrate"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Version is used by conditional updates, so it should exist in every application
+func init() {
+	register(migrate.Migration{
+		Version: 20210312090000,
+		Name:    "application_version",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("applications").UpdateMany(
+				ctx,
+				bson.D{{Key: "version", Value: bson.D{{Key: "$exists", Value: false}}}},
+				bson.D{{Key: "$set", Value: bson.D{{Key: "version", Value: int64(0)}}}},
+			)
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("applications").UpdateMany(
+				ctx,
+				bson.D{},
+				bson.D{{Key: "$unset", Value: bson.D{{Key: "version", Value: ""}}}},
+			)
+			return err
+		},
+	})
+}
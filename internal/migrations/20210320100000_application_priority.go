@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil/migrate"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Applications created before priorities get normal priority (2),
+// their deadlines are set by next change of status
+func init() {
+	register(migrate.Migration{
+		Version: 20210320100000,
+		Name:    "application_priority",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("applications").UpdateMany(
+				ctx,
+				bson.D{{Key: "priority", Value: bson.D{{Key: "$exists", Value: false}}}},
+				bson.D{{Key: "$set", Value: bson.D{{Key: "priority", Value: int32(2)}}}},
+			)
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("applications").UpdateMany(
+				ctx,
+				bson.D{},
+				bson.D{{Key: "$unset", Value: bson.D{
+					{Key: "priority", Value: ""},
+					{Key: "due_at", Value: ""},
+					{Key: "breached", Value: ""},
+				}}},
+			)
+			return err
+		},
+	})
+}
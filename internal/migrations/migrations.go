@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"sort"
+
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil/migrate"
+)
+
+var registry []migrate.Migration
+
+// register is called from init of every migration file,
+// file names should start with version to keep them ordered
+func register(m migrate.Migration) {
+	registry = append(registry, m)
+}
+
+// All returns registered migrations ordered by version
+func All() []migrate.Migration {
+	var migrations = make([]migrate.Migration, len(registry))
+	copy(migrations, registry)
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations
+}
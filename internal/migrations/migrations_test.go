@@ -0,0 +1,19 @@
+package migrations_test
+
+import (
+	"testing"
+
+	"github.com/PxyUp/backend_tech_task/internal/migrations"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil/migrate"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	all := migrations.All()
+	assert.NoError(t, migrate.Validate(all))
+
+	for i := 1; i < len(all); i++ {
+		assert.Less(t, all[i-1].Version, all[i].Version)
+	}
+}
@@ -0,0 +1,90 @@
+package outbox_memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/outbox"
+)
+
+type record struct {
+	event       outbox.Event
+	deliveredAt *time.Time
+}
+
+// Store keeps outbox in memory, it's used with storages without mongo,
+// events aren't persisted and writes aren't transactional
+type Store struct {
+	mu      sync.Mutex
+	records map[string]*record
+}
+
+func NewStore() *Store {
+	return &Store{records: make(map[string]*record)}
+}
+
+func (s *Store) Append(_ context.Context, events ...outbox.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, event := range events {
+		s.records[event.ID] = &record{event: event}
+	}
+	return nil
+}
+
+func (s *Store) Pending(_ context.Context, limit int) ([]outbox.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []outbox.Event
+	for _, r := range s.records {
+		if r.deliveredAt == nil {
+			events = append(events, r.event)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].ID < events[j].ID
+		}
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (s *Store) MarkDelivered(_ context.Context, ids ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	for _, id := range ids {
+		if r, ok := s.records[id]; ok {
+			r.deliveredAt = &now
+		}
+	}
+	return nil
+}
+
+func (s *Store) DeleteDelivered(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int64
+	for id, r := range s.records {
+		if r.deliveredAt != nil && r.deliveredAt.Before(before) {
+			delete(s.records, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// AcquireLease always succeeds, store lives in single process
+func (s *Store) AcquireLease(context.Context, string, time.Duration) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,197 @@
+package outbox_mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/outbox"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil"
+
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	collectionName      = "outbox"
+	leaseCollectionName = "outbox_lease"
+	leaseID             = "relay"
+)
+
+// Indexes cover search of pending events and cleanup of delivered ones
+var Indexes = []mongoutil.Index{
+	{
+		Name: "delivered_at_created_at",
+		Keys: bson.D{
+			{Key: "delivered_at", Value: 1},
+			{Key: "created_at", Value: 1},
+		},
+	},
+}
+
+// Store keeps outbox in mongo, events are appended in transaction from ctx
+type Store struct {
+	coll      *mongo.Collection
+	leaseColl *mongo.Collection
+}
+
+func NewStore(db *mongo.Database) *Store {
+	return &Store{
+		coll:      db.Collection(collectionName),
+		leaseColl: db.Collection(leaseCollectionName),
+	}
+}
+
+func (s Store) EnsureIndexes(ctx context.Context, mode mongoutil.IndexMode) error {
+	_, err := mongoutil.EnsureIndexes(ctx, s.coll, Indexes, mode)
+	return err
+}
+
+type EventModel struct {
+	ID               primitive.ObjectID  `bson:"_id"`
+	AggregateID      string              `bson:"aggregate_id"`
+	AggregateVersion int64               `bson:"aggregate_version"`
+	Type             string              `bson:"type"`
+	Payload          []byte              `bson:"payload"`
+	CreatedAt        primitive.DateTime  `bson:"created_at"`
+	DeliveredAt      *primitive.DateTime `bson:"delivered_at"`
+}
+
+func NewEventModel(event outbox.Event) (*EventModel, error) {
+	id, err := primitive.ObjectIDFromHex(event.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &EventModel{
+		ID:               id,
+		AggregateID:      event.AggregateID,
+		AggregateVersion: event.AggregateVersion,
+		Type:             event.Type,
+		Payload:          event.Payload,
+		CreatedAt:        primitive.NewDateTimeFromTime(event.CreatedAt),
+	}, nil
+}
+
+func ParseEventModel(m *EventModel) outbox.Event {
+	return outbox.Event{
+		ID:               m.ID.Hex(),
+		AggregateID:      m.AggregateID,
+		AggregateVersion: m.AggregateVersion,
+		Type:             m.Type,
+		Payload:          m.Payload,
+		CreatedAt:        m.CreatedAt.Time().UTC(),
+	}
+}
+
+func (s Store) Append(ctx context.Context, events ...outbox.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var docs = make([]interface{}, len(events))
+	for i, event := range events {
+		m, err := NewEventModel(event)
+		if err != nil {
+			return err
+		}
+		docs[i] = m
+	}
+
+	_, err := s.coll.InsertMany(ctx, docs)
+	return err
+}
+
+func (s Store) Pending(ctx context.Context, limit int) ([]outbox.Event, error) {
+	cur, err := s.coll.Find(
+		ctx,
+		bson.D{{Key: "delivered_at", Value: nil}},
+		options.Find().
+			SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+			SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := cur.Close(ctx); err != nil {
+			log.Err(err).Msg("couldn't close cursor after find pending outbox events")
+		}
+	}()
+
+	var events []outbox.Event
+	for cur.Next(ctx) {
+		var m EventModel
+		if err := cur.Decode(&m); err != nil {
+			return nil, err
+		}
+		events = append(events, ParseEventModel(&m))
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (s Store) MarkDelivered(ctx context.Context, ids ...string) error {
+	var mIDs = make([]primitive.ObjectID, len(ids))
+	for i, id := range ids {
+		mID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return err
+		}
+		mIDs[i] = mID
+	}
+
+	_, err := s.coll.UpdateMany(
+		ctx,
+		bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: mIDs}}}},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "delivered_at", Value: primitive.NewDateTimeFromTime(time.Now().UTC())},
+		}}},
+	)
+	return err
+}
+
+func (s Store) DeleteDelivered(ctx context.Context, before time.Time) (int64, error) {
+	res, err := s.coll.DeleteMany(ctx, bson.D{{Key: "delivered_at", Value: bson.D{
+		{Key: "$ne", Value: nil},
+		{Key: "$lt", Value: primitive.NewDateTimeFromTime(before)},
+	}}})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+// AcquireLease takes lease if it's free, expired or already held by owner
+func (s Store) AcquireLease(ctx context.Context, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+
+	_, err := s.leaseColl.UpdateOne(
+		ctx,
+		bson.D{
+			{Key: "_id", Value: leaseID},
+			{Key: "$or", Value: bson.A{
+				bson.D{{Key: "owner", Value: owner}},
+				bson.D{{Key: "expires_at", Value: bson.D{{Key: "$lt", Value: now}}}},
+			}},
+		},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: "owner", Value: owner},
+			{Key: "expires_at", Value: now.Add(ttl)},
+		}}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		// lease document exists and is held by another owner, so upsert has failed
+		if mongoutil.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
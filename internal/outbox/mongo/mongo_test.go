@@ -0,0 +1,131 @@
+package outbox_mongo_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	application_mongo "github.com/PxyUp/backend_tech_task/internal/application/mongo"
+	"github.com/PxyUp/backend_tech_task/internal/outbox"
+	outbox_mongo "github.com/PxyUp/backend_tech_task/internal/outbox/mongo"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil/mongotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// base is start of time used by tests, mongo keeps time with milliseconds precision
+var base = time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+func newStore(t *testing.T, db *mongo.Database) *outbox_mongo.Store {
+	store := outbox_mongo.NewStore(db)
+	require.NoError(t, store.EnsureIndexes(context.Background(), mongoutil.IndexModeSync))
+	return store
+}
+
+func newEvent(aggregateID string, version int64, createdAt time.Time) outbox.Event {
+	return outbox.Event{
+		ID:               primitive.NewObjectID().Hex(),
+		AggregateID:      aggregateID,
+		AggregateVersion: version,
+		Type:             "application.created",
+		Payload:          []byte(fmt.Sprintf(`{"version":%d}`, version)),
+		CreatedAt:        createdAt,
+	}
+}
+
+func TestStore_Pending(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		store = newStore(t, mongotest.NewDB(t, mongotest.NewClient(t), "test_outbox"))
+		late  = newEvent("a", 1, base.Add(time.Minute))
+		early = newEvent("b", 0, base)
+		last  = newEvent("a", 2, base.Add(2*time.Minute))
+	)
+	require.NoError(t, store.Append(ctx))
+	require.NoError(t, store.Append(ctx, late, early, last))
+
+	events, err := store.Pending(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []outbox.Event{early, late}, events)
+
+	require.NoError(t, store.MarkDelivered(ctx, early.ID, late.ID))
+	events, err = store.Pending(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []outbox.Event{last}, events)
+
+	// events delivered before time are removed only
+	deleted, err := store.DeleteDelivered(ctx, time.Now().UTC().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	deleted, err = store.DeleteDelivered(ctx, time.Now().UTC().Add(time.Minute))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, deleted)
+
+	events, err = store.Pending(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []outbox.Event{last}, events)
+}
+
+func TestStore_AcquireLease(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		store = newStore(t, mongotest.NewDB(t, mongotest.NewClient(t), "test_outbox_lease"))
+	)
+
+	acquired, err := store.AcquireLease(ctx, "first", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// owner extends own lease
+	acquired, err = store.AcquireLease(ctx, "first", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	acquired, err = store.AcquireLease(ctx, "second", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	// expired lease is taken over
+	acquired, err = store.AcquireLease(ctx, "first", -time.Second)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	acquired, err = store.AcquireLease(ctx, "second", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+}
+
+func TestStore_AppendWithinTransaction(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		client     = mongotest.NewClient(t)
+		db         = mongotest.NewDB(t, client, "test_outbox_tx")
+		store      = newStore(t, db)
+		transactor = application_mongo.NewTransactor(db)
+		committed  = newEvent("a", 0, base)
+		aborted    = newEvent("b", 0, base)
+		errAborted = fmt.Errorf("aborted")
+	)
+	mongotest.SkipWithoutTransactions(t, client)
+
+	require.NoError(t, transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return store.Append(ctx, committed)
+	}))
+	err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := store.Append(ctx, aborted); err != nil {
+			return err
+		}
+		return errAborted
+	})
+	require.Equal(t, errAborted, err)
+
+	events, err := store.Pending(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []outbox.Event{committed}, events)
+}
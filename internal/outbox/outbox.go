@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+// Event is domain event stored in outbox in the same transaction as change of aggregate,
+// later it's delivered to sinks by Relay
+type Event struct {
+	ID string
+	// AggregateID is id of changed entity, events of the same aggregate are delivered in order
+	AggregateID string
+	// AggregateVersion is version of entity after change, it defines order of events
+	AggregateVersion int64
+	Type             string
+	// Payload is json representation of event
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+// Writer appends events to outbox, it should join transaction from ctx
+type Writer interface {
+	Append(ctx context.Context, events ...Event) error
+}
+
+type Store interface {
+	Writer
+	// Pending returns undelivered events ordered by creation
+	Pending(ctx context.Context, limit int) ([]Event, error)
+	MarkDelivered(ctx context.Context, ids ...string) error
+	// DeleteDelivered removes events delivered before time and returns their count
+	DeleteDelivered(ctx context.Context, before time.Time) (int64, error)
+	// AcquireLease makes owner single relay of outbox for ttl, it returns false if lease is held by another owner
+	AcquireLease(ctx context.Context, owner string, ttl time.Duration) (bool, error)
+}
+
+// Sink receives events from relay, delivery is at least once,
+// so sink should be ready for duplicates
+type Sink interface {
+	Deliver(ctx context.Context, event Event) error
+}
+
+// NopWriter drops events, it's used when outbox isn't configured
+type NopWriter struct{}
+
+func (NopWriter) Append(context.Context, ...Event) error {
+	return nil
+}
@@ -0,0 +1,176 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Config struct {
+	PollInterval time.Duration `envconfig:"poll_interval"`
+	BatchSize    int           `envconfig:"batch_size"`
+	// Retention is how long delivered events are kept before cleanup
+	Retention       time.Duration `envconfig:"retention"`
+	CleanupInterval time.Duration `envconfig:"cleanup_interval"`
+	LeaseTTL        time.Duration `envconfig:"lease_ttl"`
+}
+
+const (
+	defaultPollInterval    = time.Second
+	defaultBatchSize       = 100
+	defaultRetention       = 24 * time.Hour
+	defaultCleanupInterval = time.Hour
+	defaultLeaseTTL        = 30 * time.Second
+)
+
+// Relay delivers pending events of outbox to sinks with at least once semantics.
+// Events of the same aggregate are delivered in order of versions,
+// failed event blocks next events of its aggregate until it's delivered.
+type Relay struct {
+	cfg   Config
+	store Store
+	sinks []Sink
+	owner string
+}
+
+func NewRelay(cfg Config, store Store, sinks ...Sink) *Relay {
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.Retention == 0 {
+		cfg.Retention = defaultRetention
+	}
+	if cfg.CleanupInterval == 0 {
+		cfg.CleanupInterval = defaultCleanupInterval
+	}
+	if cfg.LeaseTTL == 0 {
+		cfg.LeaseTTL = defaultLeaseTTL
+	}
+
+	hostname, _ := os.Hostname()
+
+	return &Relay{
+		cfg:   cfg,
+		store: store,
+		sinks: sinks,
+		owner: fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+	}
+}
+
+// Run relays events until ctx is done
+func (r *Relay) Run(ctx context.Context) error {
+	pollTicker := time.NewTicker(r.cfg.PollInterval)
+	defer pollTicker.Stop()
+
+	cleanupTicker := time.NewTicker(r.cfg.CleanupInterval)
+	defer cleanupTicker.Stop()
+
+	log.Info().Str("owner", r.owner).Msg("outbox relay started")
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("outbox relay stopped")
+			return nil
+		case <-pollTicker.C:
+			if _, err := r.RelayOnce(ctx); err != nil {
+				log.Err(err).Msg("couldn't relay outbox events")
+			}
+		case <-cleanupTicker.C:
+			if _, err := r.Cleanup(ctx); err != nil {
+				log.Err(err).Msg("couldn't cleanup outbox")
+			}
+		}
+	}
+}
+
+// RelayOnce delivers one batch of pending events and returns count of delivered ones
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	ok, err := r.store.AcquireLease(ctx, r.owner, r.cfg.LeaseTTL)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		// another replica relays events
+		return 0, nil
+	}
+
+	events, err := r.store.Pending(ctx, r.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var delivered int
+	for _, group := range groupEvents(events) {
+		for _, event := range group {
+			if err := r.deliver(ctx, event); err != nil {
+				log.Err(err).
+					Str("event_id", event.ID).
+					Str("event_type", event.Type).
+					Str("aggregate_id", event.AggregateID).
+					Msg("couldn't deliver outbox event")
+				// next events of aggregate wait for next batch to keep order
+				break
+			}
+
+			if err := r.store.MarkDelivered(ctx, event.ID); err != nil {
+				return delivered, err
+			}
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+func (r *Relay) deliver(ctx context.Context, event Event) error {
+	for _, sink := range r.sinks {
+		if err := sink.Deliver(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Cleanup removes events delivered earlier than retention
+func (r *Relay) Cleanup(ctx context.Context) (int64, error) {
+	n, err := r.store.DeleteDelivered(ctx, time.Now().UTC().Add(-r.cfg.Retention))
+	if err != nil {
+		return 0, err
+	}
+	if n != 0 {
+		log.Info().Int64("count", n).Msg("delivered outbox events have been removed")
+	}
+	return n, nil
+}
+
+// groupEvents splits events by aggregates keeping order of their first events,
+// events inside of group are ordered by versions of aggregate
+func groupEvents(events []Event) [][]Event {
+	var (
+		groups  [][]Event
+		indexes = make(map[string]int)
+	)
+	for _, event := range events {
+		i, ok := indexes[event.AggregateID]
+		if !ok {
+			i = len(groups)
+			indexes[event.AggregateID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], event)
+	}
+
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].AggregateVersion < group[j].AggregateVersion
+		})
+	}
+	return groups
+}
@@ -0,0 +1,86 @@
+package outbox_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/outbox"
+	outbox_memory "github.com/PxyUp/backend_tech_task/internal/outbox/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sink struct {
+	delivered []string
+	// fail makes delivery of event with id fail
+	fail map[string]bool
+}
+
+func (s *sink) Deliver(_ context.Context, event outbox.Event) error {
+	if s.fail[event.ID] {
+		return fmt.Errorf("sink is unavailable")
+	}
+	s.delivered = append(s.delivered, event.ID)
+	return nil
+}
+
+func TestRelay_RelayOnce(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		base  = time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+		store = outbox_memory.NewStore()
+	)
+
+	// events of the first aggregate are appended out of order of versions
+	require.NoError(t, store.Append(ctx,
+		outbox.Event{ID: "1", AggregateID: "a", AggregateVersion: 2, CreatedAt: base},
+		outbox.Event{ID: "2", AggregateID: "b", AggregateVersion: 0, CreatedAt: base.Add(time.Second)},
+		outbox.Event{ID: "3", AggregateID: "a", AggregateVersion: 1, CreatedAt: base.Add(2 * time.Second)},
+		outbox.Event{ID: "4", AggregateID: "b", AggregateVersion: 1, CreatedAt: base.Add(3 * time.Second)},
+	))
+
+	s := &sink{fail: map[string]bool{"2": true}}
+	relay := outbox.NewRelay(outbox.Config{}, store, s)
+
+	delivered, err := relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, delivered)
+	// failed event blocks next events of its aggregate
+	assert.Equal(t, []string{"3", "1"}, s.delivered)
+
+	s.fail = nil
+	delivered, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, delivered)
+	assert.Equal(t, []string{"3", "1", "2", "4"}, s.delivered)
+
+	delivered, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+}
+
+func TestRelay_Cleanup(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		store = outbox_memory.NewStore()
+	)
+
+	require.NoError(t, store.Append(ctx,
+		outbox.Event{ID: "1", AggregateID: "a"},
+		outbox.Event{ID: "2", AggregateID: "b"},
+	))
+	require.NoError(t, store.MarkDelivered(ctx, "1"))
+
+	relay := outbox.NewRelay(outbox.Config{Retention: -time.Minute}, store)
+	removed, err := relay.Cleanup(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	pending, err := store.Pending(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "2", pending[0].ID)
+}
@@ -0,0 +1,21 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// LogSink writes events to log, it's default sink of relay
+type LogSink struct{}
+
+func (LogSink) Deliver(_ context.Context, event Event) error {
+	log.Info().
+		Str("event_id", event.ID).
+		Str("event_type", event.Type).
+		Str("aggregate_id", event.AggregateID).
+		Int64("aggregate_version", event.AggregateVersion).
+		RawJSON("payload", event.Payload).
+		Msg("outbox event")
+	return nil
+}
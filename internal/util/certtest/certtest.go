@@ -0,0 +1,109 @@
+// Package certtest issues certificates for tests
+package certtest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Certificate struct {
+	Cert *x509.Certificate
+	Key  *ecdsa.PrivateKey
+}
+
+// NewCA returns self signed certificate authority
+func NewCA(t testing.TB) *Certificate {
+	return New(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "ca"},
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil)
+}
+
+// NewServer returns certificate of localhost issued by ca
+func NewServer(t testing.TB, ca *Certificate, commonName string) *Certificate {
+	return New(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: commonName},
+		DNSNames:    []string{"localhost"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+}
+
+// NewClient returns client certificate issued by ca
+func NewClient(t testing.TB, ca *Certificate, commonName string) *Certificate {
+	return New(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: commonName},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+}
+
+// New issues certificate of template by parent, certificate is self signed if parent is nil
+func New(t testing.TB, template *x509.Certificate, parent *Certificate) *Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template.SerialNumber = serial
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	var (
+		parentCert = template
+		parentKey  = key
+	)
+	if parent != nil {
+		parentCert, parentKey = parent.Cert, parent.Key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &Certificate{Cert: cert, Key: key}
+}
+
+// PEM returns PEM encoded certificate and private key
+func (c *Certificate) PEM(t testing.TB) ([]byte, []byte) {
+	t.Helper()
+	key, err := x509.MarshalECPrivateKey(c.Key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Cert.Raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: key})
+}
+
+// Write writes PEM encoded certificate and private key, key isn't written if keyFile is empty
+func (c *Certificate) Write(t testing.TB, certFile string, keyFile string) {
+	t.Helper()
+	certPEM, keyPEM := c.PEM(t)
+	require.NoError(t, ioutil.WriteFile(certFile, certPEM, 0600))
+	if keyFile != "" {
+		require.NoError(t, ioutil.WriteFile(keyFile, keyPEM, 0600))
+	}
+}
+
+func (c *Certificate) TLS(t testing.TB) tls.Certificate {
+	t.Helper()
+	cert, err := tls.X509KeyPair(c.PEM(t))
+	require.NoError(t, err)
+	return cert
+}
+
+// Pool returns pool of the certificate, e.g. roots of client
+func (c *Certificate) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(c.Cert)
+	return pool
+}
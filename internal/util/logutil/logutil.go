@@ -0,0 +1,24 @@
+package logutil
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type loggerKey struct{}
+
+// WithLogger returns copy of ctx with logger, e.g. logger of request with its id
+func WithLogger(ctx context.Context, logger zerolog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, &logger)
+}
+
+// FromContext returns logger of ctx, global logger is returned if ctx doesn't have one,
+// unlike zerolog.Ctx it never returns disabled logger, so background jobs keep logging
+func FromContext(ctx context.Context) *zerolog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zerolog.Logger); ok {
+		return logger
+	}
+	return &log.Logger
+}
@@ -0,0 +1,43 @@
+package mongoutil
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DuplicateKeyCode is code of write error caused by violation of unique index
+const DuplicateKeyCode = 11000
+
+// NamespaceExistsCode is code of error of creating existing collection
+const NamespaceExistsCode = 48
+
+// IsDuplicateKeyError reports whether err is caused by violation of unique index
+func IsDuplicateKeyError(err error) bool {
+	var we mongo.WriteException
+	if errors.As(err, &we) {
+		for _, e := range we.WriteErrors {
+			if e.Code == DuplicateKeyCode {
+				return true
+			}
+		}
+		return false
+	}
+
+	var bwe mongo.BulkWriteException
+	if errors.As(err, &bwe) {
+		for _, e := range bwe.WriteErrors {
+			if e.Code == DuplicateKeyCode {
+				return true
+			}
+		}
+		return false
+	}
+
+	var ce mongo.CommandError
+	if errors.As(err, &ce) {
+		return ce.Code == DuplicateKeyCode
+	}
+
+	return false
+}
@@ -0,0 +1,230 @@
+package mongoutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IndexMode describes how declared indexes are reconciled with existing ones
+type IndexMode string
+
+const (
+	// IndexModeSync creates missing indexes and recreates changed ones
+	IndexModeSync IndexMode = "sync"
+	// IndexModeReport only reports drift between declared and existing indexes
+	IndexModeReport IndexMode = "report"
+	// IndexModePrune works like IndexModeSync and drops unknown indexes as well
+	IndexModePrune IndexMode = "prune"
+)
+
+var ErrInvalidIndexMode = fmt.Errorf("invalid index mode")
+
+func (m IndexMode) Validate() error {
+	switch m {
+	case IndexModeSync, IndexModeReport, IndexModePrune:
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidIndexMode, string(m))
+}
+
+// Index is declarative definition of collection index
+type Index struct {
+	Name   string
+	Keys   bson.D
+	Unique bool
+	// ExpireAfter makes index TTL one, nil means index without expiration
+	ExpireAfter *time.Duration
+}
+
+func (idx Index) model() mongo.IndexModel {
+	opts := options.Index().SetName(idx.Name)
+	if idx.Unique {
+		opts.SetUnique(true)
+	}
+	if idx.ExpireAfter != nil {
+		opts.SetExpireAfterSeconds(int32(*idx.ExpireAfter / time.Second))
+	}
+	return mongo.IndexModel{Keys: idx.Keys, Options: opts}
+}
+
+type existingIndex struct {
+	Name               string `bson:"name"`
+	Key                bson.D `bson:"key"`
+	Unique             bool   `bson:"unique"`
+	ExpireAfterSeconds *int32 `bson:"expireAfterSeconds"`
+}
+
+func (e existingIndex) equal(idx Index) bool {
+	if e.Unique != idx.Unique {
+		return false
+	}
+
+	if (e.ExpireAfterSeconds == nil) != (idx.ExpireAfter == nil) {
+		return false
+	}
+	if e.ExpireAfterSeconds != nil && *e.ExpireAfterSeconds != int32(*idx.ExpireAfter/time.Second) {
+		return false
+	}
+
+	if len(e.Key) != len(idx.Keys) {
+		return false
+	}
+	for i := range e.Key {
+		if e.Key[i].Key != idx.Keys[i].Key {
+			return false
+		}
+		// server returns key directions as int32 or double,
+		// declared ones are usually int, so compare them as numbers
+		if fmt.Sprint(e.Key[i].Value) != fmt.Sprint(idx.Keys[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// IndexDrift is difference between declared and existing indexes
+type IndexDrift struct {
+	Missing []string
+	Changed []string
+	Unknown []string
+}
+
+func (d IndexDrift) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Changed) == 0 && len(d.Unknown) == 0
+}
+
+// EnsureIndexes reconciles indexes of collection with declared ones according to mode.
+// It's idempotent, so it's safe to call it on every startup.
+func EnsureIndexes(ctx context.Context, coll *mongo.Collection, indexes []Index, mode IndexMode) (*IndexDrift, error) {
+	if mode == "" {
+		mode = IndexModeSync
+	}
+	if err := mode.Validate(); err != nil {
+		return nil, err
+	}
+
+	drift, err := diffIndexes(ctx, coll, indexes)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := log.With().Str("collection", coll.Name()).Str("mode", string(mode)).Logger()
+	if drift.Empty() {
+		logger.Info().Msg("indexes are up to date")
+		return drift, nil
+	}
+
+	logger.Warn().
+		Strs("missing", drift.Missing).
+		Strs("changed", drift.Changed).
+		Strs("unknown", drift.Unknown).
+		Msg("indexes drift has been found")
+
+	if mode == IndexModeReport {
+		return drift, nil
+	}
+
+	var declared = make(map[string]Index, len(indexes))
+	for _, idx := range indexes {
+		declared[idx.Name] = idx
+	}
+
+	for _, name := range drift.Changed {
+		if _, err := coll.Indexes().DropOne(ctx, name); err != nil {
+			return nil, fmt.Errorf("couldn't drop changed index %s: %w", name, err)
+		}
+	}
+
+	var models []mongo.IndexModel
+	for _, name := range append(drift.Missing, drift.Changed...) {
+		models = append(models, declared[name].model())
+	}
+	if len(models) != 0 {
+		if _, err := coll.Indexes().CreateMany(ctx, models); err != nil {
+			return nil, fmt.Errorf("couldn't create indexes: %w", err)
+		}
+	}
+
+	if mode == IndexModePrune {
+		for _, name := range drift.Unknown {
+			if _, err := coll.Indexes().DropOne(ctx, name); err != nil {
+				return nil, fmt.Errorf("couldn't drop unknown index %s: %w", name, err)
+			}
+		}
+	}
+
+	logger.Info().Msg("indexes have been reconciled")
+	return drift, nil
+}
+
+func diffIndexes(ctx context.Context, coll *mongo.Collection, indexes []Index) (*IndexDrift, error) {
+	cur, err := coll.Indexes().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := cur.Close(ctx); err != nil {
+			log.Err(err).Msg("couldn't close cursor after list indexes")
+		}
+	}()
+
+	var existing = make(map[string]existingIndex)
+	for cur.Next(ctx) {
+		var e existingIndex
+		if err := cur.Decode(&e); err != nil {
+			return nil, err
+		}
+		existing[e.Name] = e
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	var (
+		drift    = new(IndexDrift)
+		declared = make(map[string]struct{}, len(indexes))
+	)
+	for _, idx := range indexes {
+		declared[idx.Name] = struct{}{}
+
+		e, ok := existing[idx.Name]
+		if !ok {
+			drift.Missing = append(drift.Missing, idx.Name)
+			continue
+		}
+		if !e.equal(idx) {
+			drift.Changed = append(drift.Changed, idx.Name)
+		}
+	}
+
+	for name := range existing {
+		// _id index is created by mongo itself and couldn't be dropped
+		if name == "_id_" {
+			continue
+		}
+		if _, ok := declared[name]; !ok {
+			drift.Unknown = append(drift.Unknown, name)
+		}
+	}
+	sort.Strings(drift.Unknown)
+
+	return drift, nil
+}
+
+// EnsureCollection creates collection if it doesn't exist
+func EnsureCollection(ctx context.Context, coll *mongo.Collection) error {
+	err := coll.Database().CreateCollection(ctx, coll.Name())
+	var ce mongo.CommandError
+	if errors.As(err, &ce) && ce.Code == NamespaceExistsCode {
+		return nil
+	}
+	return err
+}
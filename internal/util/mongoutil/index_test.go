@@ -0,0 +1,63 @@
+package mongoutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestExistingIndex_Equal(t *testing.T) {
+	var (
+		day     = 24 * time.Hour
+		seconds = int32(day / time.Second)
+	)
+
+	var cases = map[string]struct {
+		Existing existingIndex
+		Declared Index
+
+		ExpEqual bool
+	}{
+		"equal_with_different_number_types": {
+			Existing: existingIndex{Key: bson.D{{Key: "status", Value: int32(1)}, {Key: "updated_at", Value: float64(-1)}}},
+			Declared: Index{Keys: bson.D{{Key: "status", Value: 1}, {Key: "updated_at", Value: -1}}},
+			ExpEqual: true,
+		},
+		"different_keys_order": {
+			Existing: existingIndex{Key: bson.D{{Key: "updated_at", Value: int32(1)}, {Key: "status", Value: int32(1)}}},
+			Declared: Index{Keys: bson.D{{Key: "status", Value: 1}, {Key: "updated_at", Value: 1}}},
+		},
+		"different_direction": {
+			Existing: existingIndex{Key: bson.D{{Key: "status", Value: int32(-1)}}},
+			Declared: Index{Keys: bson.D{{Key: "status", Value: 1}}},
+		},
+		"different_unique": {
+			Existing: existingIndex{Key: bson.D{{Key: "key", Value: int32(1)}}},
+			Declared: Index{Keys: bson.D{{Key: "key", Value: 1}}, Unique: true},
+		},
+		"equal_ttl": {
+			Existing: existingIndex{Key: bson.D{{Key: "expires_at", Value: int32(1)}}, ExpireAfterSeconds: &seconds},
+			Declared: Index{Keys: bson.D{{Key: "expires_at", Value: 1}}, ExpireAfter: &day},
+			ExpEqual: true,
+		},
+		"missing_ttl": {
+			Existing: existingIndex{Key: bson.D{{Key: "expires_at", Value: int32(1)}}},
+			Declared: Index{Keys: bson.D{{Key: "expires_at", Value: 1}}, ExpireAfter: &day},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.ExpEqual, c.Existing.equal(c.Declared))
+		})
+	}
+}
+
+func TestIndexMode_Validate(t *testing.T) {
+	assert.NoError(t, IndexModeSync.Validate())
+	assert.NoError(t, IndexModeReport.Validate())
+	assert.NoError(t, IndexModePrune.Validate())
+	assert.EqualError(t, IndexMode("drop").Validate(), "invalid index mode: drop")
+}
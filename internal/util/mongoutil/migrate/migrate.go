@@ -0,0 +1,368 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil"
+
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	collectionName = "schema_migrations"
+	lockID         = "lock"
+
+	defaultLockTTL = 10 * time.Minute
+)
+
+var (
+	ErrLocked           = fmt.Errorf("migrations are locked by another process")
+	ErrInvalidMigration = fmt.Errorf("invalid migration")
+	ErrUnknownVersion   = fmt.Errorf("applied migration is unknown")
+	ErrPending          = fmt.Errorf("migrations aren't applied")
+)
+
+// Func changes database during migration
+type Func func(ctx context.Context, db *mongo.Database) error
+
+// Migration is single step of schema evolution,
+// versions are ordered and usually are timestamps in format 20060102150405
+type Migration struct {
+	Version int64
+	Name    string
+	Up      Func
+	Down    Func
+}
+
+// Status is state of migration in database
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+func (s Status) Applied() bool {
+	return s.AppliedAt != nil
+}
+
+type record struct {
+	Version   int64     `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+type lock struct {
+	ID        string    `bson:"_id"`
+	Owner     string    `bson:"owner"`
+	LockedAt  time.Time `bson:"locked_at"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+type Migrator struct {
+	db         *mongo.Database
+	coll       *mongo.Collection
+	migrations []Migration
+
+	owner   string
+	lockTTL time.Duration
+}
+
+type Option func(m *Migrator)
+
+// WithLockTTL sets time after which lock of killed process is taken over,
+// lock of running process is renewed every third of ttl
+func WithLockTTL(ttl time.Duration) Option {
+	return func(m *Migrator) {
+		if ttl > 0 {
+			m.lockTTL = ttl
+		}
+	}
+}
+
+func NewMigrator(db *mongo.Database, migrations []Migration, opts ...Option) (*Migrator, error) {
+	if err := Validate(migrations); err != nil {
+		return nil, err
+	}
+
+	var sorted = make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	hostname, _ := os.Hostname()
+
+	m := &Migrator{
+		db:         db,
+		coll:       db.Collection(collectionName),
+		migrations: sorted,
+		owner:      fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+		lockTTL:    defaultLockTTL,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m, nil
+}
+
+// Validate checks that migrations have unique positive versions, names and both steps
+func Validate(migrations []Migration) error {
+	var versions = make(map[int64]struct{}, len(migrations))
+	for _, m := range migrations {
+		if m.Version <= 0 {
+			return fmt.Errorf("%w: version of %q should be positive", ErrInvalidMigration, m.Name)
+		}
+		if m.Name == "" {
+			return fmt.Errorf("%w: name of %d cannot be empty", ErrInvalidMigration, m.Version)
+		}
+		if m.Up == nil || m.Down == nil {
+			return fmt.Errorf("%w: %d_%s should have up and down steps", ErrInvalidMigration, m.Version, m.Name)
+		}
+		if _, ok := versions[m.Version]; ok {
+			return fmt.Errorf("%w: duplicated version %d", ErrInvalidMigration, m.Version)
+		}
+		versions[m.Version] = struct{}{}
+	}
+	return nil
+}
+
+// Status returns all known migrations with time of applying
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses = make([]Status, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i].Migration = migration
+		if r, ok := applied[migration.Version]; ok {
+			appliedAt := r.AppliedAt
+			statuses[i].AppliedAt = &appliedAt
+			delete(applied, migration.Version)
+		}
+	}
+
+	// database was migrated by binary with migrations unknown for this one
+	if len(applied) != 0 {
+		var unknown []string
+		for version, r := range applied {
+			unknown = append(unknown, fmt.Sprintf("%d_%s", version, r.Name))
+		}
+		sort.Strings(unknown)
+		return statuses, fmt.Errorf("%w: %s", ErrUnknownVersion, strings.Join(unknown, ", "))
+	}
+
+	return statuses, nil
+}
+
+// Check fails with ErrPending if any known migration isn't applied, so binary doesn't run against old schema,
+// applied migrations unknown for binary are allowed, e.g. binary is rolled back without rollback of schema
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil && !errors.Is(err, ErrUnknownVersion) {
+		return err
+	}
+
+	var pending []string
+	for _, s := range statuses {
+		if !s.Applied() {
+			pending = append(pending, fmt.Sprintf("%d_%s", s.Version, s.Name))
+		}
+	}
+	if len(pending) != 0 {
+		return fmt.Errorf("%w: %s", ErrPending, strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// Up applies pending migrations with versions lower or equal target,
+// zero target means all pending migrations
+func (m *Migrator) Up(ctx context.Context, target int64) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func() error {
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range statuses {
+			if s.Applied() {
+				continue
+			}
+			if target != 0 && s.Version > target {
+				break
+			}
+
+			log.Info().Int64("version", s.Version).Str("name", s.Name).Msg("try to apply migration")
+			if err := s.Up(ctx, m.db); err != nil {
+				return fmt.Errorf("couldn't apply migration %d_%s: %w", s.Version, s.Name, err)
+			}
+
+			if _, err := m.coll.InsertOne(ctx, record{
+				Version:   s.Version,
+				Name:      s.Name,
+				AppliedAt: time.Now().UTC(),
+			}); err != nil {
+				return fmt.Errorf("couldn't save migration %d_%s: %w", s.Version, s.Name, err)
+			}
+
+			log.Info().Int64("version", s.Version).Msg("migration has been applied")
+			done = append(done, s.Migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down rollbacks last applied migrations, steps is count of migrations to rollback
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func() error {
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i := len(statuses) - 1; i >= 0 && len(done) < steps; i-- {
+			s := statuses[i]
+			if !s.Applied() {
+				continue
+			}
+
+			log.Info().Int64("version", s.Version).Str("name", s.Name).Msg("try to rollback migration")
+			if err := s.Down(ctx, m.db); err != nil {
+				return fmt.Errorf("couldn't rollback migration %d_%s: %w", s.Version, s.Name, err)
+			}
+
+			if _, err := m.coll.DeleteOne(ctx, bson.D{{Key: "_id", Value: s.Version}}); err != nil {
+				return fmt.Errorf("couldn't remove migration %d_%s: %w", s.Version, s.Name, err)
+			}
+
+			log.Info().Int64("version", s.Version).Msg("migration has been rolled back")
+			done = append(done, s.Migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int64]record, error) {
+	cur, err := m.coll.Find(
+		ctx,
+		bson.D{{Key: "_id", Value: bson.D{{Key: "$ne", Value: lockID}}}},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := cur.Close(ctx); err != nil {
+			log.Err(err).Msg("couldn't close cursor after find applied migrations")
+		}
+	}()
+
+	var applied = make(map[int64]record)
+	for cur.Next(ctx) {
+		var r record
+		if err := cur.Decode(&r); err != nil {
+			return nil, err
+		}
+		applied[r.Version] = r
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+// withLock runs fn holding lock document, so concurrent runs couldn't apply the same migrations.
+// Lock is renewed while fn runs, expired lock (e.g. left by killed process) is taken over.
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+
+	var (
+		done    = make(chan struct{})
+		renewed = make(chan struct{})
+	)
+	go func() {
+		defer close(renewed)
+		m.renew(done)
+	}()
+
+	defer func() {
+		close(done)
+		<-renewed
+		if _, err := m.coll.DeleteOne(
+			context.Background(),
+			bson.D{{Key: "_id", Value: lockID}, {Key: "owner", Value: m.owner}},
+		); err != nil {
+			log.Err(err).Msg("couldn't release migrations lock")
+		}
+	}()
+
+	return fn()
+}
+
+// renew extends lock every third of ttl until done is closed
+func (m *Migrator) renew(done <-chan struct{}) {
+	ticker := time.NewTicker(m.lockTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		res, err := m.coll.UpdateOne(
+			context.Background(),
+			bson.D{{Key: "_id", Value: lockID}, {Key: "owner", Value: m.owner}},
+			bson.D{{Key: "$set", Value: bson.D{{Key: "expires_at", Value: time.Now().UTC().Add(m.lockTTL)}}}},
+		)
+		if err != nil {
+			log.Err(err).Msg("couldn't renew migrations lock")
+			continue
+		}
+		if res.MatchedCount == 0 {
+			log.Error().Str("owner", m.owner).Msg("migrations lock has been lost")
+			return
+		}
+	}
+}
+
+func (m *Migrator) lock(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	// remove lock if it's expired, it's noop in other cases
+	if _, err := m.coll.DeleteOne(ctx, bson.D{
+		{Key: "_id", Value: lockID},
+		{Key: "expires_at", Value: bson.D{{Key: "$lt", Value: now}}},
+	}); err != nil {
+		return err
+	}
+
+	_, err := m.coll.InsertOne(ctx, lock{
+		ID:        lockID,
+		Owner:     m.owner,
+		LockedAt:  now,
+		ExpiresAt: now.Add(m.lockTTL),
+	})
+	if mongoutil.IsDuplicateKeyError(err) {
+		return ErrLocked
+	}
+	return err
+}
@@ -0,0 +1,129 @@
+package migrate_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil/migrate"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil/mongotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestValidate(t *testing.T) {
+	var noop = func(ctx context.Context, db *mongo.Database) error { return nil }
+
+	var cases = map[string]struct {
+		Migrations []migrate.Migration
+
+		ExpError string
+	}{
+		"success": {
+			Migrations: []migrate.Migration{
+				{Version: 2, Name: "second", Up: noop, Down: noop},
+				{Version: 1, Name: "first", Up: noop, Down: noop},
+			},
+		},
+		"failed_duplicated_version": {
+			Migrations: []migrate.Migration{
+				{Version: 1, Name: "first", Up: noop, Down: noop},
+				{Version: 1, Name: "second", Up: noop, Down: noop},
+			},
+			ExpError: "invalid migration: duplicated version 1",
+		},
+		"failed_without_down": {
+			Migrations: []migrate.Migration{
+				{Version: 1, Name: "first", Up: noop},
+			},
+			ExpError: "invalid migration: 1_first should have up and down steps",
+		},
+		"failed_zero_version": {
+			Migrations: []migrate.Migration{
+				{Name: "first", Up: noop, Down: noop},
+			},
+			ExpError: `invalid migration: version of "first" should be positive`,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := migrate.Validate(c.Migrations)
+			if c.ExpError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, c.ExpError)
+			}
+		})
+	}
+}
+
+func TestMigrator_Check(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		db   = mongotest.NewDB(t, mongotest.NewClient(t), "test_migrate")
+		noop = func(ctx context.Context, db *mongo.Database) error { return nil }
+		all  = []migrate.Migration{
+			{Version: 1, Name: "first", Up: noop, Down: noop},
+			{Version: 2, Name: "second", Up: noop, Down: noop},
+		}
+	)
+
+	migrator, err := migrate.NewMigrator(db, all)
+	require.NoError(t, err)
+
+	err = migrator.Check(ctx)
+	assert.True(t, errors.Is(err, migrate.ErrPending), err)
+	assert.EqualError(t, err, "migrations aren't applied: 1_first, 2_second")
+
+	_, err = migrator.Up(ctx, 1)
+	require.NoError(t, err)
+	assert.EqualError(t, migrator.Check(ctx), "migrations aren't applied: 2_second")
+
+	_, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	assert.NoError(t, migrator.Check(ctx))
+
+	// binary without the last migration runs against newer schema
+	older, err := migrate.NewMigrator(db, all[:1])
+	require.NoError(t, err)
+	assert.NoError(t, older.Check(ctx))
+}
+
+func TestMigrator_LockIsRenewed(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		db   = mongotest.NewDB(t, mongotest.NewClient(t), "test_migrate")
+		noop = func(ctx context.Context, db *mongo.Database) error { return nil }
+	)
+
+	var concurrent error
+	slow := func(ctx context.Context, db *mongo.Database) error {
+		// lock is held longer than ttl
+		time.Sleep(500 * time.Millisecond)
+
+		another, err := migrate.NewMigrator(db, []migrate.Migration{{Version: 1, Name: "slow", Up: noop, Down: noop}})
+		if err != nil {
+			return err
+		}
+		_, concurrent = another.Up(ctx, 0)
+		return nil
+	}
+
+	migrator, err := migrate.NewMigrator(db, []migrate.Migration{{Version: 1, Name: "slow", Up: slow, Down: noop}},
+		migrate.WithLockTTL(150*time.Millisecond))
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	assert.True(t, errors.Is(concurrent, migrate.ErrLocked), concurrent)
+
+	// lock is released after migrations
+	count, err := db.Collection("schema_migrations").CountDocuments(ctx, bson.D{{Key: "_id", Value: "lock"}})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
@@ -2,32 +2,236 @@ package mongoutil
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
+	"strconv"
+	"time"
 
-	"go.mongodb.org/mongo-driver/mongo/options"
-
+	"github.com/rs/zerolog/log"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 type Config struct {
 	URL      string `envconfig:"url"`
 	Database string `envconfig:"database"`
-	User     string `envconfig:"user"`
-	Password string `envconfig:"password"`
+
+	// credentials are optional, auth is used only if user is set
+	User          string `envconfig:"user"`
+	Password      string `envconfig:"password"`
+	AuthSource    string `envconfig:"auth_source"`
+	AuthMechanism string `envconfig:"auth_mechanism"`
+
+	MinPoolSize            uint64        `envconfig:"min_pool_size"`
+	MaxPoolSize            uint64        `envconfig:"max_pool_size"`
+	ConnectTimeout         time.Duration `envconfig:"connect_timeout"`
+	ServerSelectionTimeout time.Duration `envconfig:"server_selection_timeout"`
+	SocketTimeout          time.Duration `envconfig:"socket_timeout"`
+
+	TLS                   bool   `envconfig:"tls"`
+	TLSCAFile             string `envconfig:"tls_ca_file"`
+	TLSCertificateKeyFile string `envconfig:"tls_certificate_key_file"`
+	TLSInsecure           bool   `envconfig:"tls_insecure"`
+
+	// ReadConcern is one of local, available, majority, linearizable, snapshot
+	ReadConcern string `envconfig:"read_concern"`
+	// WriteConcern is majority or number of nodes
+	WriteConcern        string        `envconfig:"write_concern"`
+	WriteConcernJournal bool          `envconfig:"write_concern_journal"`
+	WriteConcernTimeout time.Duration `envconfig:"write_concern_timeout"`
+
+	// ping is retried with exponential backoff on startup
+	ConnectRetries int           `envconfig:"connect_retries"`
+	ConnectBackoff time.Duration `envconfig:"connect_backoff"`
+
+	IndexMode IndexMode `envconfig:"index_mode"`
+	// DisableTransactions is required for standalone mongo, which doesn't support transactions
+	DisableTransactions bool `envconfig:"disable_transactions"`
 }
 
-func NewDB(cfg Config) (*mongo.Database, error) {
+const (
+	defaultConnectTimeout         = 5 * time.Second
+	defaultServerSelectionTimeout = 5 * time.Second
+	defaultConnectRetries         = 5
+	defaultConnectBackoff         = 500 * time.Millisecond
+	maxConnectBackoff             = 10 * time.Second
+)
+
+func (cfg Config) clientOptions() (*options.ClientOptions, error) {
 	clientOptions := options.Client().ApplyURI(cfg.URL)
-	clientOptions.SetAuth(options.Credential{Username: cfg.User, Password: cfg.Password})
 
-	client, err := mongo.Connect(context.TODO(), clientOptions)
-	if err != nil {
-		return nil, err
+	if cfg.User != "" {
+		clientOptions.SetAuth(options.Credential{
+			Username:      cfg.User,
+			Password:      cfg.Password,
+			AuthSource:    cfg.AuthSource,
+			AuthMechanism: cfg.AuthMechanism,
+		})
+	}
+
+	if cfg.MinPoolSize != 0 {
+		clientOptions.SetMinPoolSize(cfg.MinPoolSize)
+	}
+	if cfg.MaxPoolSize != 0 {
+		clientOptions.SetMaxPoolSize(cfg.MaxPoolSize)
+	}
+	clientOptions.SetConnectTimeout(cfg.ConnectTimeout)
+	clientOptions.SetServerSelectionTimeout(cfg.ServerSelectionTimeout)
+	if cfg.SocketTimeout != 0 {
+		clientOptions.SetSocketTimeout(cfg.SocketTimeout)
+	}
+
+	if cfg.TLS {
+		tlsConfig, err := cfg.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		clientOptions.SetTLSConfig(tlsConfig)
+	}
+
+	if cfg.ReadConcern != "" {
+		clientOptions.SetReadConcern(readconcern.New(readconcern.Level(cfg.ReadConcern)))
+	}
+
+	if cfg.WriteConcern != "" || cfg.WriteConcernJournal || cfg.WriteConcernTimeout != 0 {
+		var opts []writeconcern.Option
+		switch {
+		case cfg.WriteConcern == "majority":
+			opts = append(opts, writeconcern.WMajority())
+		case cfg.WriteConcern != "":
+			w, err := strconv.Atoi(cfg.WriteConcern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid mongo write concern %q: %w", cfg.WriteConcern, err)
+			}
+			opts = append(opts, writeconcern.W(w))
+		}
+		if cfg.WriteConcernJournal {
+			opts = append(opts, writeconcern.J(true))
+		}
+		if cfg.WriteConcernTimeout != 0 {
+			opts = append(opts, writeconcern.WTimeout(cfg.WriteConcernTimeout))
+		}
+		clientOptions.SetWriteConcern(writeconcern.New(opts...))
+	}
+
+	return clientOptions, nil
+}
+
+func (cfg Config) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.TLSInsecure, // nolint:gosec
+	}
+
+	if cfg.TLSCAFile != "" {
+		ca, err := ioutil.ReadFile(cfg.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read mongo tls ca file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("mongo tls ca file doesn't contain certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.TLSCertificateKeyFile != "" {
+		// the same file contains certificate and private key as mongo tools expect
+		cert, err := tls.LoadX509KeyPair(cfg.TLSCertificateKeyFile, cfg.TLSCertificateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't load mongo tls certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
 	}
 
+	return tlsConfig, nil
+}
+
+// NewDB connects to mongo and checks connection by ping,
+// ping is retried with backoff, so mongo can be started a bit later than application
+func NewDB(ctx context.Context, cfg Config) (*mongo.Database, error) {
 	if cfg.Database == "" {
 		return nil, fmt.Errorf("mongo database cannot be empty")
 	}
+	if cfg.ConnectTimeout == 0 {
+		cfg.ConnectTimeout = defaultConnectTimeout
+	}
+	if cfg.ServerSelectionTimeout == 0 {
+		cfg.ServerSelectionTimeout = defaultServerSelectionTimeout
+	}
+	if cfg.ConnectRetries == 0 {
+		cfg.ConnectRetries = defaultConnectRetries
+	}
+	if cfg.ConnectBackoff == 0 {
+		cfg.ConnectBackoff = defaultConnectBackoff
+	}
+
+	clientOptions, err := cfg.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := mongo.NewClient(clientOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	connectCtx, cancel := context.WithTimeout(ctx, cfg.ConnectTimeout)
+	defer cancel()
+	if err := client.Connect(connectCtx); err != nil {
+		return nil, err
+	}
+
+	if err := ping(ctx, client, cfg); err != nil {
+		_ = client.Disconnect(context.Background())
+		return nil, err
+	}
 
 	return client.Database(cfg.Database), nil
 }
+
+func ping(ctx context.Context, client *mongo.Client, cfg Config) error {
+	var (
+		backoff = cfg.ConnectBackoff
+		err     error
+	)
+
+	for attempt := 1; ; attempt++ {
+		pingCtx, cancel := context.WithTimeout(ctx, cfg.ServerSelectionTimeout)
+		err = client.Ping(pingCtx, nil)
+		cancel()
+		if err == nil {
+			log.Info().Int("attempt", attempt).Msg("mongo is reachable")
+			return nil
+		}
+
+		if attempt >= cfg.ConnectRetries {
+			return fmt.Errorf("couldn't ping mongo after %d attempts: %w", attempt, err)
+		}
+
+		log.Warn().Err(err).Int("attempt", attempt).Dur("backoff", backoff).Msg("couldn't ping mongo, try again")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxConnectBackoff {
+			backoff = maxConnectBackoff
+		}
+	}
+}
+
+// Ping checks that mongo of database is reachable
+func Ping(ctx context.Context, db *mongo.Database) error {
+	return db.Client().Ping(ctx, nil)
+}
+
+// Close disconnects client of database, it waits for in progress operations until ctx is done
+func Close(ctx context.Context, db *mongo.Database) error {
+	return db.Client().Disconnect(ctx)
+}
@@ -0,0 +1,86 @@
+// Package mongotest connects tests to mongo
+package mongotest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// URIEnv points to mongo used by tests, e.g. service of CI, tests fail if it's set and mongo isn't reachable
+	URIEnv = "MONGO_URI"
+
+	// defaultURI is used without URIEnv, tests are skipped if it isn't reachable
+	defaultURI = "mongodb://localhost:27017"
+
+	connectTimeout = 2 * time.Second
+)
+
+// NewClient returns client of mongo, it's disconnected after test
+func NewClient(t testing.TB) *mongo.Client {
+	t.Helper()
+
+	uri, required := os.LookupEnv(URIEnv)
+	if !required || uri == "" {
+		uri, required = defaultURI, false
+	}
+	unavailable := func(err error) {
+		t.Helper()
+		if required {
+			t.Fatalf("mongo of %s isn't available: %s", URIEnv, err)
+		}
+		t.Skipf("mongo is not available: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetServerSelectionTimeout(connectTimeout))
+	if err != nil {
+		unavailable(err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		_ = client.Disconnect(context.Background())
+		unavailable(err)
+	}
+	t.Cleanup(func() { _ = client.Disconnect(context.Background()) })
+
+	return client
+}
+
+// NewDB returns new database of client, it's dropped after test
+func NewDB(t testing.TB, client *mongo.Client, prefix string) *mongo.Database {
+	t.Helper()
+
+	db := client.Database(fmt.Sprintf("%s_%d", prefix, time.Now().UnixNano()))
+	t.Cleanup(func() { _ = db.Drop(context.Background()) })
+
+	return db
+}
+
+// SkipWithoutTransactions skips test if mongo of client is standalone one, which doesn't support transactions
+func SkipWithoutTransactions(t testing.TB, client *mongo.Client) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+
+	var reply struct {
+		SetName string `bson:"setName"`
+		// Msg is isdbgrid for mongos of sharded cluster
+		Msg string `bson:"msg"`
+	}
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&reply); err != nil {
+		t.Fatalf("couldn't get topology of mongo: %s", err)
+	}
+	if reply.SetName == "" && reply.Msg != "isdbgrid" {
+		t.Skip("mongo is standalone, transactions aren't supported")
+	}
+}
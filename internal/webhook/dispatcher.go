@@ -0,0 +1,193 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Config struct {
+	PollInterval time.Duration `envconfig:"poll_interval"`
+	BatchSize    int           `envconfig:"batch_size"`
+	// Timeout is timeout of one request to receiver
+	Timeout time.Duration `envconfig:"timeout"`
+	// MaxAttempts is number of attempts before delivery becomes dead
+	MaxAttempts int `envconfig:"max_attempts"`
+	// delay before next attempt grows exponentially from initial to max backoff
+	InitialBackoff time.Duration `envconfig:"initial_backoff"`
+	MaxBackoff     time.Duration `envconfig:"max_backoff"`
+	// LockTTL should be greater than time of sending whole batch
+	LockTTL time.Duration `envconfig:"lock_ttl"`
+}
+
+const (
+	defaultPollInterval   = time.Second
+	defaultBatchSize      = 20
+	defaultTimeout        = 10 * time.Second
+	defaultMaxAttempts    = 8
+	defaultInitialBackoff = 10 * time.Second
+	defaultMaxBackoff     = time.Hour
+	defaultLockTTL        = 5 * time.Minute
+
+	// maxResponseBody is size of response read to reuse connection
+	maxResponseBody = 4 << 10
+)
+
+// Dispatcher sends pending deliveries to receivers, failed deliveries are retried with backoff
+type Dispatcher struct {
+	cfg        Config
+	repository Repository
+	client     *http.Client
+}
+
+func NewDispatcher(cfg Config, repository Repository) *Dispatcher {
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.InitialBackoff == 0 {
+		cfg.InitialBackoff = defaultInitialBackoff
+	}
+	if cfg.MaxBackoff == 0 {
+		cfg.MaxBackoff = defaultMaxBackoff
+	}
+	if cfg.LockTTL == 0 {
+		cfg.LockTTL = defaultLockTTL
+	}
+
+	return &Dispatcher{
+		cfg:        cfg,
+		repository: repository,
+		client:     &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// Run dispatches deliveries until ctx is done
+func (d *Dispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	log.Info().Msg("webhook dispatcher started")
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("webhook dispatcher stopped")
+			return nil
+		case <-ticker.C:
+			if _, err := d.DispatchOnce(ctx); err != nil {
+				log.Err(err).Msg("couldn't dispatch webhook deliveries")
+			}
+		}
+	}
+}
+
+// DispatchOnce sends one batch of due deliveries and returns count of processed ones
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	deliveries, err := d.repository.ClaimDueDeliveries(ctx, time.Now().UTC(), d.cfg.LockTTL, d.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range deliveries {
+		if err := d.dispatch(ctx, &deliveries[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(deliveries), nil
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, delivery *Delivery) error {
+	var attempt = Attempt{At: time.Now().UTC()}
+
+	subscription, err := d.repository.FindSubscriptionByID(ctx, delivery.SubscriptionID)
+	switch {
+	case err == nil:
+		attempt.StatusCode, err = d.send(ctx, subscription, delivery, attempt.At)
+		if err != nil {
+			attempt.Error = err.Error()
+		}
+	case errors.Is(err, ErrSubscriptionNotFound):
+		// nothing to retry, subscription has been deleted
+		attempt.Error = err.Error()
+		delivery.AttemptCount = d.cfg.MaxAttempts - 1
+	default:
+		return err
+	}
+
+	delivery.Attempts = append(delivery.Attempts, attempt)
+	delivery.AttemptCount++
+	delivery.LockedUntil = time.Time{}
+	delivery.UpdatedAt = time.Now().UTC()
+
+	logger := log.With().
+		Str("delivery_id", delivery.ID).
+		Str("subscription_id", delivery.SubscriptionID).
+		Int("attempt", delivery.AttemptCount).
+		Logger()
+
+	switch {
+	case attempt.Error == "":
+		delivery.State = DeliveryStateSucceeded
+		logger.Info().Msg("webhook has been delivered")
+	case delivery.AttemptCount >= d.cfg.MaxAttempts:
+		delivery.State = DeliveryStateDead
+		logger.Warn().Str("error", attempt.Error).Msg("webhook delivery is dead")
+	default:
+		delivery.NextAttemptAt = attempt.At.Add(d.backoff(delivery.AttemptCount))
+		logger.Warn().Str("error", attempt.Error).Time("next_attempt_at", delivery.NextAttemptAt).Msg("couldn't deliver webhook")
+	}
+
+	return d.repository.UpdateDelivery(ctx, delivery)
+}
+
+func (d *Dispatcher) send(ctx context.Context, subscription *Subscription, delivery *Delivery, at time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(at.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, at, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff returns delay after attempt, it's doubled on every attempt
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	var delay = d.cfg.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= d.cfg.MaxBackoff {
+			return d.cfg.MaxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,250 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	"github.com/PxyUp/backend_tech_task/internal/outbox"
+	"github.com/PxyUp/backend_tech_task/internal/webhook"
+	webhook_memory "github.com/PxyUp/backend_tech_task/internal/webhook/memory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "0123456789abcdef"
+
+// receiver is webhook endpoint which responds with given status codes in order
+type receiver struct {
+	mu       sync.Mutex
+	codes    []int
+	requests int
+	errors   []error
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	body, _ := ioutil.ReadAll(r.Body)
+	if err := webhook.Verify(secret, r.Header.Get(webhook.HeaderTimestamp), body, r.Header.Get(webhook.HeaderSignature)); err != nil {
+		rc.errors = append(rc.errors, err)
+	}
+	if r.Header.Get(webhook.HeaderEvent) == "" || r.Header.Get(webhook.HeaderDelivery) == "" {
+		rc.errors = append(rc.errors, errors.New("event headers are missing"))
+	}
+
+	var code = http.StatusOK
+	if rc.requests < len(rc.codes) {
+		code = rc.codes[rc.requests]
+	}
+	rc.requests++
+	w.WriteHeader(code)
+}
+
+func setup(t *testing.T, rc *receiver) (webhook.Service, *webhook.Dispatcher, *webhook.Delivery) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(rc)
+	t.Cleanup(server.Close)
+
+	repository := webhook_memory.NewRepository()
+	svc := webhook.NewService(repository)
+	subscription, err := svc.CreateSubscription(ctx, &webhook.Subscription{URL: server.URL, Secret: secret})
+	require.NoError(t, err)
+
+	app := &application.Application{
+		ID:     "603bd5e5967f2dba00c8e326",
+		UserID: "603bd5e5967f2dba00c8e325",
+		Status: application.StatusOpen,
+	}
+	event, err := application.NewEvent(application.EventApplicationCreated, app)
+	require.NoError(t, err)
+	require.NoError(t, webhook.NewSink(repository).Deliver(ctx, event))
+
+	deliveries, err := svc.ListDeliveries(ctx, subscription.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	dispatcher := webhook.NewDispatcher(webhook.Config{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+	}, repository)
+
+	return svc, dispatcher, &deliveries[0]
+}
+
+// dispatch sends deliveries until nothing is due for a while
+func dispatch(t *testing.T, dispatcher *webhook.Dispatcher) {
+	for idle := 0; idle < 10; {
+		n, err := dispatcher.DispatchOnce(context.Background())
+		require.NoError(t, err)
+		if n == 0 {
+			idle++
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+func TestDispatcher_DispatchOnce(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		rc := &receiver{}
+		svc, dispatcher, delivery := setup(t, rc)
+
+		dispatch(t, dispatcher)
+
+		deliveries, err := svc.ListDeliveries(context.Background(), delivery.SubscriptionID)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, webhook.DeliveryStateSucceeded, deliveries[0].State)
+		require.Len(t, deliveries[0].Attempts, 1)
+		assert.Equal(t, http.StatusOK, deliveries[0].Attempts[0].StatusCode)
+		assert.Equal(t, 1, rc.requests)
+		assert.Empty(t, rc.errors)
+	})
+
+	t.Run("success_after_retry", func(t *testing.T) {
+		rc := &receiver{codes: []int{http.StatusInternalServerError, http.StatusBadGateway}}
+		svc, dispatcher, delivery := setup(t, rc)
+
+		dispatch(t, dispatcher)
+
+		deliveries, err := svc.ListDeliveries(context.Background(), delivery.SubscriptionID)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.DeliveryStateSucceeded, deliveries[0].State)
+		require.Len(t, deliveries[0].Attempts, 3)
+		assert.Equal(t, http.StatusInternalServerError, deliveries[0].Attempts[0].StatusCode)
+		assert.Equal(t, "unexpected status code 500", deliveries[0].Attempts[0].Error)
+		assert.Equal(t, 3, rc.requests)
+		assert.Empty(t, rc.errors)
+	})
+
+	t.Run("dead_and_redeliver", func(t *testing.T) {
+		rc := &receiver{codes: []int{500, 500, 500}}
+		svc, dispatcher, delivery := setup(t, rc)
+
+		dispatch(t, dispatcher)
+
+		deliveries, err := svc.ListDeliveries(context.Background(), delivery.SubscriptionID)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.DeliveryStateDead, deliveries[0].State)
+		assert.Equal(t, 3, rc.requests)
+
+		redelivered, err := svc.Redeliver(context.Background(), delivery.ID)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.DeliveryStatePending, redelivered.State)
+
+		_, err = svc.Redeliver(context.Background(), delivery.ID)
+		assert.True(t, errors.Is(err, webhook.ErrDeliveryPending), "unexpected error: %v", err)
+
+		dispatch(t, dispatcher)
+
+		deliveries, err = svc.ListDeliveries(context.Background(), delivery.SubscriptionID)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.DeliveryStateSucceeded, deliveries[0].State)
+		assert.Len(t, deliveries[0].Attempts, 4)
+		assert.Empty(t, rc.errors)
+	})
+
+	t.Run("dead_deleted_subscription", func(t *testing.T) {
+		rc := &receiver{}
+		svc, dispatcher, delivery := setup(t, rc)
+		require.NoError(t, svc.DeleteSubscription(context.Background(), delivery.SubscriptionID))
+
+		dispatch(t, dispatcher)
+
+		_, err := svc.Redeliver(context.Background(), delivery.ID)
+		assert.NoError(t, err, "dead delivery can be redelivered")
+		assert.Zero(t, rc.requests)
+	})
+}
+
+func TestSink_Deliver(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		repository = webhook_memory.NewRepository()
+		svc        = webhook.NewService(repository)
+		sink       = webhook.NewSink(repository)
+
+		userID = "603bd5e5967f2dba00c8e325"
+		closed = application.StatusClosed
+	)
+
+	all, err := svc.CreateSubscription(ctx, &webhook.Subscription{URL: "http://localhost/all", Secret: secret})
+	require.NoError(t, err)
+	byUser, err := svc.CreateSubscription(ctx, &webhook.Subscription{URL: "http://localhost/user", Secret: secret, UserID: &userID})
+	require.NoError(t, err)
+	byStatus, err := svc.CreateSubscription(ctx, &webhook.Subscription{
+		URL:        "http://localhost/closed",
+		Secret:     secret,
+		EventTypes: []string{application.EventApplicationStatusChanged},
+		Status:     &closed,
+	})
+	require.NoError(t, err)
+
+	newEvent := func(eventType string, userID string, status application.Status) outbox.Event {
+		event, err := application.NewEvent(eventType, &application.Application{ID: "603bd5e5967f2dba00c8e326", UserID: userID, Status: status})
+		require.NoError(t, err)
+		return event
+	}
+
+	var (
+		created = newEvent(application.EventApplicationCreated, userID, application.StatusOpen)
+		changed = newEvent(application.EventApplicationStatusChanged, "603bd5e5967f2dba00c8e327", application.StatusClosed)
+	)
+	require.NoError(t, sink.Deliver(ctx, created))
+	require.NoError(t, sink.Deliver(ctx, changed))
+	// relay can deliver the same event again
+	require.NoError(t, sink.Deliver(ctx, created))
+
+	for _, c := range []struct {
+		Subscription *webhook.Subscription
+		ExpEvents    []string
+	}{
+		{all, []string{created.ID, changed.ID}},
+		{byUser, []string{created.ID}},
+		{byStatus, []string{changed.ID}},
+	} {
+		deliveries, err := svc.ListDeliveries(ctx, c.Subscription.ID)
+		require.NoError(t, err)
+
+		var events []string
+		for _, delivery := range deliveries {
+			events = append(events, delivery.EventID)
+		}
+		assert.ElementsMatch(t, c.ExpEvents, events, c.Subscription.URL)
+	}
+}
+
+func TestSubscription_Validate(t *testing.T) {
+	var cases = map[string]struct {
+		Subscription webhook.Subscription
+		ExpError     bool
+	}{
+		"success":             {Subscription: webhook.Subscription{URL: "https://example.com/hook", Secret: secret}},
+		"failed_relative":     {Subscription: webhook.Subscription{URL: "/hook", Secret: secret}, ExpError: true},
+		"failed_scheme":       {Subscription: webhook.Subscription{URL: "ftp://example.com", Secret: secret}, ExpError: true},
+		"failed_secret":       {Subscription: webhook.Subscription{URL: "https://example.com", Secret: "short"}, ExpError: true},
+		"failed_event_type":   {Subscription: webhook.Subscription{URL: "https://example.com", Secret: secret, EventTypes: []string{"Unknown"}}, ExpError: true},
+		"failed_invalid_user": {Subscription: webhook.Subscription{URL: "https://example.com", Secret: secret, UserID: new(string)}, ExpError: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := c.Subscription.Validate()
+			if c.ExpError {
+				assert.True(t, errors.Is(err, webhook.ErrInvalidArgument), "unexpected error: %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package webhook_memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/webhook"
+)
+
+// Repository keeps subscriptions and deliveries in memory, it's used with storages without mongo
+type Repository struct {
+	mu            sync.Mutex
+	subscriptions map[string]webhook.Subscription
+	deliveries    map[string]webhook.Delivery
+}
+
+func NewRepository() *Repository {
+	return &Repository{
+		subscriptions: make(map[string]webhook.Subscription),
+		deliveries:    make(map[string]webhook.Delivery),
+	}
+}
+
+func (r *Repository) CreateSubscription(_ context.Context, subscription *webhook.Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions[subscription.ID] = *subscription
+	return nil
+}
+
+func (r *Repository) UpdateSubscription(_ context.Context, subscription *webhook.Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.subscriptions[subscription.ID]; !ok {
+		return webhook.ErrSubscriptionNotFound
+	}
+	r.subscriptions[subscription.ID] = *subscription
+	return nil
+}
+
+func (r *Repository) DeleteSubscription(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.subscriptions[id]; !ok {
+		return webhook.ErrSubscriptionNotFound
+	}
+	delete(r.subscriptions, id)
+	return nil
+}
+
+func (r *Repository) FindSubscriptionByID(_ context.Context, id string) (*webhook.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	subscription, ok := r.subscriptions[id]
+	if !ok {
+		return nil, webhook.ErrSubscriptionNotFound
+	}
+	return &subscription, nil
+}
+
+func (r *Repository) FindSubscriptions(context.Context) ([]webhook.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var subscriptions = make([]webhook.Subscription, 0, len(r.subscriptions))
+	for _, subscription := range r.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].ID < subscriptions[j].ID
+	})
+	return subscriptions, nil
+}
+
+func (r *Repository) CreateDeliveries(_ context.Context, deliveries ...webhook.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var existing = make(map[[2]string]bool, len(r.deliveries))
+	for _, delivery := range r.deliveries {
+		existing[[2]string{delivery.EventID, delivery.SubscriptionID}] = true
+	}
+	for _, delivery := range deliveries {
+		key := [2]string{delivery.EventID, delivery.SubscriptionID}
+		if existing[key] {
+			continue
+		}
+		existing[key] = true
+		r.deliveries[delivery.ID] = delivery
+	}
+	return nil
+}
+
+func (r *Repository) UpdateDelivery(_ context.Context, delivery *webhook.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.deliveries[delivery.ID]; !ok {
+		return webhook.ErrDeliveryNotFound
+	}
+	r.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (r *Repository) FindDeliveryByID(_ context.Context, id string) (*webhook.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delivery, ok := r.deliveries[id]
+	if !ok {
+		return nil, webhook.ErrDeliveryNotFound
+	}
+	return &delivery, nil
+}
+
+func (r *Repository) FindDeliveries(_ context.Context, subscriptionID string) ([]webhook.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deliveries []webhook.Delivery
+	for _, delivery := range r.deliveries {
+		if delivery.SubscriptionID == subscriptionID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	sortDeliveries(deliveries)
+	return deliveries, nil
+}
+
+func (r *Repository) ClaimDueDeliveries(_ context.Context, now time.Time, lockTTL time.Duration, limit int) ([]webhook.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deliveries []webhook.Delivery
+	for _, delivery := range r.deliveries {
+		if delivery.State == webhook.DeliveryStatePending &&
+			!delivery.NextAttemptAt.After(now) &&
+			!delivery.LockedUntil.After(now) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	sortDeliveries(deliveries)
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	for i := range deliveries {
+		deliveries[i].LockedUntil = now.Add(lockTTL)
+		r.deliveries[deliveries[i].ID] = deliveries[i]
+	}
+	return deliveries, nil
+}
+
+func sortDeliveries(deliveries []webhook.Delivery) {
+	sort.Slice(deliveries, func(i, j int) bool {
+		if deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].ID < deliveries[j].ID
+		}
+		return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+	})
+}
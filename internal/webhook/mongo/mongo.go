@@ -0,0 +1,417 @@
+package webhook_mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil"
+	"github.com/PxyUp/backend_tech_task/internal/webhook"
+
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	subscriptionsCollectionName = "webhook_subscriptions"
+	deliveriesCollectionName    = "webhook_deliveries"
+)
+
+// DeliveryIndexes cover claiming of due deliveries, listing by subscription
+// and deduplication of deliveries of the same event
+var DeliveryIndexes = []mongoutil.Index{
+	{
+		Name: "event_id_subscription_id",
+		Keys: bson.D{
+			{Key: "event_id", Value: 1},
+			{Key: "subscription_id", Value: 1},
+		},
+		Unique: true,
+	},
+	{
+		Name: "state_next_attempt_at",
+		Keys: bson.D{
+			{Key: "state", Value: 1},
+			{Key: "next_attempt_at", Value: 1},
+		},
+	},
+	{
+		Name: "subscription_id_created_at",
+		Keys: bson.D{
+			{Key: "subscription_id", Value: 1},
+			{Key: "created_at", Value: 1},
+		},
+	},
+}
+
+type Repository struct {
+	subscriptions *mongo.Collection
+	deliveries    *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) *Repository {
+	return &Repository{
+		subscriptions: db.Collection(subscriptionsCollectionName),
+		deliveries:    db.Collection(deliveriesCollectionName),
+	}
+}
+
+func (r Repository) EnsureIndexes(ctx context.Context, mode mongoutil.IndexMode) error {
+	_, err := mongoutil.EnsureIndexes(ctx, r.deliveries, DeliveryIndexes, mode)
+	return err
+}
+
+func (r Repository) CreateSubscription(ctx context.Context, subscription *webhook.Subscription) error {
+	m, err := NewSubscriptionModel(subscription)
+	if err != nil {
+		return err
+	}
+	_, err = r.subscriptions.InsertOne(ctx, m)
+	return err
+}
+
+func (r Repository) UpdateSubscription(ctx context.Context, subscription *webhook.Subscription) error {
+	m, err := NewSubscriptionModel(subscription)
+	if err != nil {
+		return err
+	}
+	res, err := r.subscriptions.ReplaceOne(ctx, bson.D{{Key: "_id", Value: m.ID}}, m)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return webhook.ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+func (r Repository) DeleteSubscription(ctx context.Context, id string) error {
+	mID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return webhook.ErrSubscriptionNotFound
+	}
+	res, err := r.subscriptions.DeleteOne(ctx, bson.D{{Key: "_id", Value: mID}})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return webhook.ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+func (r Repository) FindSubscriptionByID(ctx context.Context, id string) (*webhook.Subscription, error) {
+	mID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, webhook.ErrSubscriptionNotFound
+	}
+
+	var m = new(SubscriptionModel)
+	if err := r.subscriptions.FindOne(ctx, bson.D{{Key: "_id", Value: mID}}).Decode(m); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, webhook.ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+	return ParseSubscriptionModel(m), nil
+}
+
+func (r Repository) FindSubscriptions(ctx context.Context) ([]webhook.Subscription, error) {
+	cur, err := r.subscriptions.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := cur.Close(ctx); err != nil {
+			log.Err(err).Msg("couldn't close cursor after find webhook subscriptions")
+		}
+	}()
+
+	var subscriptions []webhook.Subscription
+	for cur.Next(ctx) {
+		var m SubscriptionModel
+		if err := cur.Decode(&m); err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, *ParseSubscriptionModel(&m))
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r Repository) CreateDeliveries(ctx context.Context, deliveries ...webhook.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	var docs = make([]interface{}, len(deliveries))
+	for i := range deliveries {
+		m, err := NewDeliveryModel(&deliveries[i])
+		if err != nil {
+			return err
+		}
+		docs[i] = m
+	}
+
+	// unordered insert keeps going after duplicates of already scheduled deliveries
+	_, err := r.deliveries.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err != nil && !onlyDuplicateKeyErrors(err) {
+		return err
+	}
+	return nil
+}
+
+func onlyDuplicateKeyErrors(err error) bool {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		return false
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Code != mongoutil.DuplicateKeyCode {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Repository) UpdateDelivery(ctx context.Context, delivery *webhook.Delivery) error {
+	m, err := NewDeliveryModel(delivery)
+	if err != nil {
+		return err
+	}
+	res, err := r.deliveries.ReplaceOne(ctx, bson.D{{Key: "_id", Value: m.ID}}, m)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return webhook.ErrDeliveryNotFound
+	}
+	return nil
+}
+
+func (r Repository) FindDeliveryByID(ctx context.Context, id string) (*webhook.Delivery, error) {
+	mID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, webhook.ErrDeliveryNotFound
+	}
+
+	var m = new(DeliveryModel)
+	if err := r.deliveries.FindOne(ctx, bson.D{{Key: "_id", Value: mID}}).Decode(m); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, webhook.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	return ParseDeliveryModel(m), nil
+}
+
+func (r Repository) FindDeliveries(ctx context.Context, subscriptionID string) ([]webhook.Delivery, error) {
+	mID, err := primitive.ObjectIDFromHex(subscriptionID)
+	if err != nil {
+		return nil, nil
+	}
+
+	cur, err := r.deliveries.Find(
+		ctx,
+		bson.D{{Key: "subscription_id", Value: mID}},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := cur.Close(ctx); err != nil {
+			log.Err(err).Msg("couldn't close cursor after find webhook deliveries")
+		}
+	}()
+
+	var deliveries []webhook.Delivery
+	for cur.Next(ctx) {
+		var m DeliveryModel
+		if err := cur.Decode(&m); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *ParseDeliveryModel(&m))
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ClaimDueDeliveries locks deliveries one by one, so concurrent dispatchers never get the same delivery
+func (r Repository) ClaimDueDeliveries(ctx context.Context, now time.Time, lockTTL time.Duration, limit int) ([]webhook.Delivery, error) {
+	var (
+		mNow   = primitive.NewDateTimeFromTime(now)
+		filter = bson.D{
+			{Key: "state", Value: int32(webhook.DeliveryStatePending)},
+			{Key: "next_attempt_at", Value: bson.D{{Key: "$lte", Value: mNow}}},
+			{Key: "locked_until", Value: bson.D{{Key: "$lte", Value: mNow}}},
+		}
+		update = bson.D{{Key: "$set", Value: bson.D{
+			{Key: "locked_until", Value: primitive.NewDateTimeFromTime(now.Add(lockTTL))},
+		}}}
+		opts = options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+			SetReturnDocument(options.After)
+		deliveries []webhook.Delivery
+	)
+
+	for len(deliveries) < limit {
+		var m = new(DeliveryModel)
+		if err := r.deliveries.FindOneAndUpdate(ctx, filter, update, opts).Decode(m); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				break
+			}
+			return deliveries, err
+		}
+		deliveries = append(deliveries, *ParseDeliveryModel(m))
+	}
+	return deliveries, nil
+}
+
+type SubscriptionModel struct {
+	ID         primitive.ObjectID  `bson:"_id"`
+	URL        string              `bson:"url"`
+	Secret     string              `bson:"secret"`
+	EventTypes []string            `bson:"event_types"`
+	UserID     *primitive.ObjectID `bson:"user_id"`
+	Status     *int32              `bson:"status"`
+	CreatedAt  primitive.DateTime  `bson:"created_at"`
+	UpdatedAt  primitive.DateTime  `bson:"updated_at"`
+}
+
+func NewSubscriptionModel(s *webhook.Subscription) (*SubscriptionModel, error) {
+	var (
+		m = &SubscriptionModel{
+			URL:        s.URL,
+			Secret:     s.Secret,
+			EventTypes: s.EventTypes,
+			CreatedAt:  primitive.NewDateTimeFromTime(s.CreatedAt),
+			UpdatedAt:  primitive.NewDateTimeFromTime(s.UpdatedAt),
+		}
+		err error
+	)
+	m.ID, err = primitive.ObjectIDFromHex(s.ID)
+	if err != nil {
+		return nil, err
+	}
+	if s.UserID != nil {
+		userID, err := primitive.ObjectIDFromHex(*s.UserID)
+		if err != nil {
+			return nil, err
+		}
+		m.UserID = &userID
+	}
+	if s.Status != nil {
+		status := s.Status.Int32()
+		m.Status = &status
+	}
+	return m, nil
+}
+
+func ParseSubscriptionModel(m *SubscriptionModel) *webhook.Subscription {
+	var s = &webhook.Subscription{
+		ID:         m.ID.Hex(),
+		URL:        m.URL,
+		Secret:     m.Secret,
+		EventTypes: m.EventTypes,
+		CreatedAt:  m.CreatedAt.Time().UTC(),
+		UpdatedAt:  m.UpdatedAt.Time().UTC(),
+	}
+	if m.UserID != nil {
+		userID := m.UserID.Hex()
+		s.UserID = &userID
+	}
+	if m.Status != nil {
+		status := application.NewStatus(*m.Status)
+		s.Status = &status
+	}
+	return s
+}
+
+type DeliveryModel struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	SubscriptionID primitive.ObjectID `bson:"subscription_id"`
+	EventID        string             `bson:"event_id"`
+	EventType      string             `bson:"event_type"`
+	Payload        []byte             `bson:"payload"`
+	State          int32              `bson:"state"`
+	Attempts       []AttemptModel     `bson:"attempts"`
+	AttemptCount   int                `bson:"attempt_count"`
+	NextAttemptAt  primitive.DateTime `bson:"next_attempt_at"`
+	LockedUntil    primitive.DateTime `bson:"locked_until"`
+	CreatedAt      primitive.DateTime `bson:"created_at"`
+	UpdatedAt      primitive.DateTime `bson:"updated_at"`
+}
+
+type AttemptModel struct {
+	At         primitive.DateTime `bson:"at"`
+	StatusCode int                `bson:"status_code"`
+	Error      string             `bson:"error,omitempty"`
+}
+
+func NewDeliveryModel(d *webhook.Delivery) (*DeliveryModel, error) {
+	var (
+		m = &DeliveryModel{
+			EventID:       d.EventID,
+			EventType:     d.EventType,
+			Payload:       d.Payload,
+			State:         int32(d.State),
+			AttemptCount:  d.AttemptCount,
+			NextAttemptAt: primitive.NewDateTimeFromTime(d.NextAttemptAt),
+			LockedUntil:   primitive.NewDateTimeFromTime(d.LockedUntil),
+			CreatedAt:     primitive.NewDateTimeFromTime(d.CreatedAt),
+			UpdatedAt:     primitive.NewDateTimeFromTime(d.UpdatedAt),
+		}
+		err error
+	)
+	m.ID, err = primitive.ObjectIDFromHex(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	m.SubscriptionID, err = primitive.ObjectIDFromHex(d.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range d.Attempts {
+		m.Attempts = append(m.Attempts, AttemptModel{
+			At:         primitive.NewDateTimeFromTime(a.At),
+			StatusCode: a.StatusCode,
+			Error:      a.Error,
+		})
+	}
+	return m, nil
+}
+
+func ParseDeliveryModel(m *DeliveryModel) *webhook.Delivery {
+	var d = &webhook.Delivery{
+		ID:             m.ID.Hex(),
+		SubscriptionID: m.SubscriptionID.Hex(),
+		EventID:        m.EventID,
+		EventType:      m.EventType,
+		Payload:        m.Payload,
+		State:          webhook.DeliveryState(m.State),
+		AttemptCount:   m.AttemptCount,
+		NextAttemptAt:  m.NextAttemptAt.Time().UTC(),
+		LockedUntil:    m.LockedUntil.Time().UTC(),
+		CreatedAt:      m.CreatedAt.Time().UTC(),
+		UpdatedAt:      m.UpdatedAt.Time().UTC(),
+	}
+	for _, a := range m.Attempts {
+		d.Attempts = append(d.Attempts, webhook.Attempt{
+			At:         a.At.Time().UTC(),
+			StatusCode: a.StatusCode,
+			Error:      a.Error,
+		})
+	}
+	return d
+}
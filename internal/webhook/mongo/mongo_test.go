@@ -0,0 +1,152 @@
+package webhook_mongo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil"
+	"github.com/PxyUp/backend_tech_task/internal/util/mongoutil/mongotest"
+	"github.com/PxyUp/backend_tech_task/internal/webhook"
+	webhook_mongo "github.com/PxyUp/backend_tech_task/internal/webhook/mongo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// base is start of time used by tests, mongo keeps time with milliseconds precision
+var base = time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+func newRepository(t *testing.T, prefix string) *webhook_mongo.Repository {
+	r := webhook_mongo.NewRepository(mongotest.NewDB(t, mongotest.NewClient(t), prefix))
+	require.NoError(t, r.EnsureIndexes(context.Background(), mongoutil.IndexModeSync))
+	return r
+}
+
+func newDelivery(subscriptionID, eventID string, nextAttemptAt time.Time) webhook.Delivery {
+	return webhook.Delivery{
+		ID:             primitive.NewObjectID().Hex(),
+		SubscriptionID: subscriptionID,
+		EventID:        eventID,
+		EventType:      application.EventApplicationCreated,
+		Payload:        []byte(`{}`),
+		State:          webhook.DeliveryStatePending,
+		NextAttemptAt:  nextAttemptAt,
+		CreatedAt:      base,
+		UpdatedAt:      base,
+	}
+}
+
+func TestRepository_Subscriptions(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		r            = newRepository(t, "test_webhook_subscriptions")
+		userID       = primitive.NewObjectID().Hex()
+		status       = application.StatusOpen
+		subscription = &webhook.Subscription{
+			ID:         primitive.NewObjectID().Hex(),
+			URL:        "https://example.com/hook",
+			Secret:     "0123456789abcdef",
+			EventTypes: []string{application.EventApplicationCreated},
+			UserID:     &userID,
+			Status:     &status,
+			CreatedAt:  base,
+			UpdatedAt:  base,
+		}
+		other = &webhook.Subscription{
+			ID:        primitive.NewObjectID().Hex(),
+			URL:       "https://example.com/other",
+			Secret:    "0123456789abcdef",
+			CreatedAt: base,
+			UpdatedAt: base,
+		}
+	)
+	require.NoError(t, r.CreateSubscription(ctx, subscription))
+	require.NoError(t, r.CreateSubscription(ctx, other))
+
+	found, err := r.FindSubscriptionByID(ctx, subscription.ID)
+	require.NoError(t, err)
+	assert.Equal(t, subscription, found)
+
+	subscription.UserID, subscription.Status, subscription.UpdatedAt = nil, nil, base.Add(time.Minute)
+	require.NoError(t, r.UpdateSubscription(ctx, subscription))
+
+	subscriptions, err := r.FindSubscriptions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []webhook.Subscription{*subscription, *other}, subscriptions)
+
+	require.NoError(t, r.DeleteSubscription(ctx, subscription.ID))
+	_, err = r.FindSubscriptionByID(ctx, subscription.ID)
+	assert.True(t, errors.Is(err, webhook.ErrSubscriptionNotFound), err)
+	assert.True(t, errors.Is(r.UpdateSubscription(ctx, subscription), webhook.ErrSubscriptionNotFound))
+	assert.True(t, errors.Is(r.DeleteSubscription(ctx, subscription.ID), webhook.ErrSubscriptionNotFound))
+	assert.True(t, errors.Is(r.DeleteSubscription(ctx, "invalid"), webhook.ErrSubscriptionNotFound))
+}
+
+func TestRepository_Deliveries(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		r              = newRepository(t, "test_webhook_deliveries")
+		subscriptionID = primitive.NewObjectID().Hex()
+		first          = newDelivery(subscriptionID, "1", base)
+		second         = newDelivery(subscriptionID, "2", base.Add(time.Second))
+	)
+	require.NoError(t, r.CreateDeliveries(ctx))
+	require.NoError(t, r.CreateDeliveries(ctx, first))
+
+	// delivery of the same event to subscription is skipped
+	duplicate := newDelivery(subscriptionID, "1", base)
+	require.NoError(t, r.CreateDeliveries(ctx, duplicate, second))
+
+	deliveries, err := r.FindDeliveries(ctx, subscriptionID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.ElementsMatch(t, []string{first.ID, second.ID}, []string{deliveries[0].ID, deliveries[1].ID})
+
+	_, err = r.FindDeliveryByID(ctx, duplicate.ID)
+	assert.True(t, errors.Is(err, webhook.ErrDeliveryNotFound), err)
+
+	first.State = webhook.DeliveryStateSucceeded
+	first.Attempts = []webhook.Attempt{{At: base.Add(time.Minute), StatusCode: 200}}
+	first.AttemptCount = 1
+	require.NoError(t, r.UpdateDelivery(ctx, &first))
+
+	found, err := r.FindDeliveryByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &first, found)
+
+	assert.True(t, errors.Is(r.UpdateDelivery(ctx, &duplicate), webhook.ErrDeliveryNotFound))
+}
+
+func TestRepository_ClaimDueDeliveries(t *testing.T) {
+	var (
+		ctx            = context.Background()
+		r              = newRepository(t, "test_webhook_claim")
+		subscriptionID = primitive.NewObjectID().Hex()
+		late           = newDelivery(subscriptionID, "1", base.Add(time.Minute))
+		early          = newDelivery(subscriptionID, "2", base)
+		future         = newDelivery(subscriptionID, "3", base.Add(time.Hour))
+	)
+	require.NoError(t, r.CreateDeliveries(ctx, late, early, future))
+
+	now := base.Add(2 * time.Minute)
+	claimed, err := r.ClaimDueDeliveries(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+	assert.Equal(t, early.ID, claimed[0].ID)
+	assert.Equal(t, late.ID, claimed[1].ID)
+	assert.Equal(t, now.Add(time.Minute), claimed[0].LockedUntil)
+
+	// locked deliveries aren't claimed again until lock expires
+	claimed, err = r.ClaimDueDeliveries(ctx, now, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	claimed, err = r.ClaimDueDeliveries(ctx, now.Add(time.Minute), time.Minute, 1)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, early.ID, claimed[0].ID)
+}
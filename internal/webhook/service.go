@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Service interface {
+	CreateSubscription(ctx context.Context, subscription *Subscription) (*Subscription, error)
+	GetSubscription(ctx context.Context, id string) (*Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]Subscription, error)
+	// UpdateSubscription replaces subscription, empty secret keeps current one
+	UpdateSubscription(ctx context.Context, subscription *Subscription) (*Subscription, error)
+	DeleteSubscription(ctx context.Context, id string) error
+
+	ListDeliveries(ctx context.Context, subscriptionID string) ([]Delivery, error)
+	// Redeliver schedules succeeded or dead delivery to be sent again with full set of attempts
+	Redeliver(ctx context.Context, id string) (*Delivery, error)
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &service{repository: repository}
+}
+
+func (svc service) CreateSubscription(ctx context.Context, subscription *Subscription) (*Subscription, error) {
+	log.Info().Str("url", subscription.URL).Msg("try to create webhook subscription")
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+
+	subscription.ID = primitive.NewObjectID().Hex()
+	subscription.CreatedAt = time.Now().UTC()
+	subscription.UpdatedAt = time.Time{}
+
+	if err := svc.repository.CreateSubscription(ctx, subscription); err != nil {
+		log.Err(err).Msg("couldn't save webhook subscription")
+		return nil, ErrRepository
+	}
+
+	log.Info().Msgf("webhook subscription %s has been created", subscription.ID)
+	return subscription, nil
+}
+
+func (svc service) GetSubscription(ctx context.Context, id string) (*Subscription, error) {
+	subscription, err := svc.repository.FindSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	return subscription, nil
+}
+
+func (svc service) ListSubscriptions(ctx context.Context) ([]Subscription, error) {
+	subscriptions, err := svc.repository.FindSubscriptions(ctx)
+	if err != nil {
+		log.Err(err).Msg("couldn't find webhook subscriptions")
+		return nil, ErrRepository
+	}
+	return subscriptions, nil
+}
+
+func (svc service) UpdateSubscription(ctx context.Context, subscription *Subscription) (*Subscription, error) {
+	log.Info().Str("id", subscription.ID).Msg("try to update webhook subscription")
+	current, err := svc.repository.FindSubscriptionByID(ctx, subscription.ID)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+
+	if subscription.Secret == "" {
+		subscription.Secret = current.Secret
+	}
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+	subscription.CreatedAt = current.CreatedAt
+	subscription.UpdatedAt = time.Now().UTC()
+
+	if err := svc.repository.UpdateSubscription(ctx, subscription); err != nil {
+		return nil, repositoryError(err)
+	}
+
+	log.Info().Msg("webhook subscription has been updated")
+	return subscription, nil
+}
+
+func (svc service) DeleteSubscription(ctx context.Context, id string) error {
+	log.Info().Str("id", id).Msg("try to delete webhook subscription")
+	if err := svc.repository.DeleteSubscription(ctx, id); err != nil {
+		return repositoryError(err)
+	}
+	log.Info().Msg("webhook subscription has been deleted")
+	return nil
+}
+
+func (svc service) ListDeliveries(ctx context.Context, subscriptionID string) ([]Delivery, error) {
+	if _, err := svc.repository.FindSubscriptionByID(ctx, subscriptionID); err != nil {
+		return nil, repositoryError(err)
+	}
+
+	deliveries, err := svc.repository.FindDeliveries(ctx, subscriptionID)
+	if err != nil {
+		log.Err(err).Msg("couldn't find webhook deliveries")
+		return nil, ErrRepository
+	}
+	return deliveries, nil
+}
+
+func (svc service) Redeliver(ctx context.Context, id string) (*Delivery, error) {
+	log.Info().Str("id", id).Msg("try to redeliver webhook delivery")
+	delivery, err := svc.repository.FindDeliveryByID(ctx, id)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	if delivery.State == DeliveryStatePending {
+		return nil, ErrDeliveryPending
+	}
+
+	var now = time.Now().UTC()
+	delivery.State = DeliveryStatePending
+	delivery.AttemptCount = 0
+	delivery.NextAttemptAt = now
+	delivery.LockedUntil = time.Time{}
+	delivery.UpdatedAt = now
+
+	if err := svc.repository.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, repositoryError(err)
+	}
+
+	log.Info().Msg("webhook delivery has been scheduled")
+	return delivery, nil
+}
+
+func repositoryError(err error) error {
+	if errors.Is(err, ErrSubscriptionNotFound) || errors.Is(err, ErrDeliveryNotFound) {
+		return err
+	}
+	log.Err(err).Msg("webhook repository error")
+	return ErrRepository
+}
@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+	"github.com/PxyUp/backend_tech_task/internal/outbox"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Sink schedules deliveries of outbox events to matching subscriptions,
+// requests are sent later by Dispatcher, so slow receivers don't block relay
+type Sink struct {
+	repository Repository
+}
+
+func NewSink(repository Repository) *Sink {
+	return &Sink{repository: repository}
+}
+
+func (s Sink) Deliver(ctx context.Context, event outbox.Event) error {
+	var payload application.EventPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("couldn't decode payload of event %s: %w", event.ID, err)
+	}
+
+	subscriptions, err := s.repository.FindSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		now        = time.Now().UTC()
+		deliveries []Delivery
+	)
+	for _, subscription := range subscriptions {
+		if !subscription.Match(event.Type, &payload) {
+			continue
+		}
+		deliveries = append(deliveries, Delivery{
+			ID:             primitive.NewObjectID().Hex(),
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        event.Payload,
+			State:          DeliveryStatePending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+	}
+
+	// relay delivers event at least once, duplicates are skipped by repository
+	return s.repository.CreateDeliveries(ctx, deliveries...)
+}
@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/PxyUp/backend_tech_task/internal/application"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInvalidArgument      = fmt.Errorf("invalid argument")
+	ErrRepository           = fmt.Errorf("undefined repository error")
+	ErrSubscriptionNotFound = fmt.Errorf("webhook subscription is not found")
+	ErrDeliveryNotFound     = fmt.Errorf("webhook delivery is not found")
+	ErrDeliveryPending      = fmt.Errorf("webhook delivery is pending")
+)
+
+// Subscription describes which events are sent to url
+type Subscription struct {
+	ID     string
+	URL    string
+	Secret string
+	// EventTypes are types of application events, empty means all events
+	EventTypes []string
+	// UserID and Status are optional filters of applications
+	UserID    *string
+	Status    *application.Status
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+var eventTypes = map[string]bool{
+	application.EventApplicationCreated:       true,
+	application.EventApplicationStatusChanged: true,
+	application.EventApplicationDeleted:       true,
+	application.EventApplicationRestored:      true,
+	application.EventApplicationAssigned:      true,
+	application.EventApplicationUnassigned:    true,
+	application.EventApplicationSLABreached:   true,
+}
+
+const minSecretLength = 16
+
+func (s Subscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return fmt.Errorf("%w: url is not valid: %s", ErrInvalidArgument, err.Error())
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url should be absolute http or https url", ErrInvalidArgument)
+	}
+	if len(s.Secret) < minSecretLength {
+		return fmt.Errorf("%w: secret should contain at least %d characters", ErrInvalidArgument, minSecretLength)
+	}
+	for _, eventType := range s.EventTypes {
+		if !eventTypes[eventType] {
+			return fmt.Errorf("%w: unknown event type %q", ErrInvalidArgument, eventType)
+		}
+	}
+	if s.UserID != nil {
+		if _, err := primitive.ObjectIDFromHex(*s.UserID); err != nil {
+			return fmt.Errorf("%w: user_id is not valid: %s", ErrInvalidArgument, err.Error())
+		}
+	}
+	if s.Status != nil {
+		if err := s.Status.Validate(); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidArgument, err.Error())
+		}
+	}
+	return nil
+}
+
+// Match checks that event of application should be sent to subscription
+func (s Subscription) Match(eventType string, payload *application.EventPayload) bool {
+	if len(s.EventTypes) != 0 {
+		var found bool
+		for _, t := range s.EventTypes {
+			if t == eventType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if s.UserID != nil && *s.UserID != payload.UserID {
+		return false
+	}
+	if s.Status != nil && s.Status.String() != payload.Status {
+		return false
+	}
+	return true
+}
+
+type DeliveryState int32
+
+const (
+	DeliveryStatePending   DeliveryState = 1
+	DeliveryStateSucceeded DeliveryState = 2
+	// DeliveryStateDead is state of delivery which has failed all attempts, it's sent again only by redeliver
+	DeliveryStateDead DeliveryState = 3
+)
+
+// Delivery is sending of one event to one subscription
+type Delivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	Payload        []byte
+	State          DeliveryState
+	// Attempts is history of all attempts, AttemptCount is number of attempts since last redeliver
+	Attempts      []Attempt
+	AttemptCount  int
+	NextAttemptAt time.Time
+	// LockedUntil prevents sending of delivery by several dispatchers at the same time
+	LockedUntil time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Attempt struct {
+	At time.Time
+	// StatusCode is status of response, it's 0 if request has failed
+	StatusCode int
+	Error      string
+}
+
+type Repository interface {
+	CreateSubscription(ctx context.Context, subscription *Subscription) error
+	UpdateSubscription(ctx context.Context, subscription *Subscription) error
+	DeleteSubscription(ctx context.Context, id string) error
+	FindSubscriptionByID(ctx context.Context, id string) (*Subscription, error)
+	FindSubscriptions(ctx context.Context) ([]Subscription, error)
+
+	// CreateDeliveries skips deliveries of event which already exist for subscription
+	CreateDeliveries(ctx context.Context, deliveries ...Delivery) error
+	UpdateDelivery(ctx context.Context, delivery *Delivery) error
+	FindDeliveryByID(ctx context.Context, id string) (*Delivery, error)
+	FindDeliveries(ctx context.Context, subscriptionID string) ([]Delivery, error)
+	// ClaimDueDeliveries returns pending deliveries which should be sent before now
+	// and locks them until now+lockTTL
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lockTTL time.Duration, limit int) ([]Delivery, error)
+}
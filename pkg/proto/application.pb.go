@@ -12,6 +12,7 @@ import (
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	math "math"
 )
 
@@ -26,6 +27,33 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type CreateApplicationsRequest_Mode int32
+
+const (
+	// nothing is created if any item fails
+	CreateApplicationsRequest_CREATE_APPLICATIONS_MODE_ALL_OR_NOTHING CreateApplicationsRequest_Mode = 0
+	// every item which succeeds is created
+	CreateApplicationsRequest_CREATE_APPLICATIONS_MODE_BEST_EFFORT CreateApplicationsRequest_Mode = 1
+)
+
+var CreateApplicationsRequest_Mode_name = map[int32]string{
+	0: "CREATE_APPLICATIONS_MODE_ALL_OR_NOTHING",
+	1: "CREATE_APPLICATIONS_MODE_BEST_EFFORT",
+}
+
+var CreateApplicationsRequest_Mode_value = map[string]int32{
+	"CREATE_APPLICATIONS_MODE_ALL_OR_NOTHING": 0,
+	"CREATE_APPLICATIONS_MODE_BEST_EFFORT":    1,
+}
+
+func (x CreateApplicationsRequest_Mode) String() string {
+	return proto.EnumName(CreateApplicationsRequest_Mode_name, int32(x))
+}
+
+func (CreateApplicationsRequest_Mode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{8, 0}
+}
+
 type Application_Status int32
 
 const (
@@ -56,7 +84,41 @@ func (x Application_Status) String() string {
 }
 
 func (Application_Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_fc846aced8fe6ea6, []int{7, 0}
+	return fileDescriptor_fc846aced8fe6ea6, []int{16, 0}
+}
+
+type Application_Priority int32
+
+const (
+	Application_APPLICATION_PRIORITY_UNSPECIFIED Application_Priority = 0
+	Application_APPLICATION_PRIORITY_LOW         Application_Priority = 1
+	Application_APPLICATION_PRIORITY_NORMAL      Application_Priority = 2
+	Application_APPLICATION_PRIORITY_HIGH        Application_Priority = 3
+	Application_APPLICATION_PRIORITY_URGENT      Application_Priority = 4
+)
+
+var Application_Priority_name = map[int32]string{
+	0: "APPLICATION_PRIORITY_UNSPECIFIED",
+	1: "APPLICATION_PRIORITY_LOW",
+	2: "APPLICATION_PRIORITY_NORMAL",
+	3: "APPLICATION_PRIORITY_HIGH",
+	4: "APPLICATION_PRIORITY_URGENT",
+}
+
+var Application_Priority_value = map[string]int32{
+	"APPLICATION_PRIORITY_UNSPECIFIED": 0,
+	"APPLICATION_PRIORITY_LOW":         1,
+	"APPLICATION_PRIORITY_NORMAL":      2,
+	"APPLICATION_PRIORITY_HIGH":        3,
+	"APPLICATION_PRIORITY_URGENT":      4,
+}
+
+func (x Application_Priority) String() string {
+	return proto.EnumName(Application_Priority_name, int32(x))
+}
+
+func (Application_Priority) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{16, 1}
 }
 
 type Application_ExternalStatus int32
@@ -86,7 +148,7 @@ func (x Application_ExternalStatus) String() string {
 }
 
 func (Application_ExternalStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_fc846aced8fe6ea6, []int{7, 1}
+	return fileDescriptor_fc846aced8fe6ea6, []int{16, 2}
 }
 
 type GetApplicationByIdRequestRequest struct {
@@ -129,13 +191,29 @@ func (m *GetApplicationByIdRequestRequest) GetId() string {
 }
 
 type GetApplicationsByFiltersRequest struct {
-	Status               Application_Status `protobuf:"varint,1,opt,name=status,proto3,enum=api.Application_Status" json:"status,omitempty"`
-	CreatedAtTimerange   *TimeRange         `protobuf:"bytes,2,opt,name=created_at_timerange,json=createdAtTimerange,proto3" json:"created_at_timerange,omitempty"`
-	UpdatedAtTimerange   *TimeRange         `protobuf:"bytes,3,opt,name=updated_at_timerange,json=updatedAtTimerange,proto3" json:"updated_at_timerange,omitempty"`
-	UserId               string             `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	Status             Application_Status `protobuf:"varint,1,opt,name=status,proto3,enum=api.Application_Status" json:"status,omitempty"`
+	CreatedAtTimerange *TimeRange         `protobuf:"bytes,2,opt,name=created_at_timerange,json=createdAtTimerange,proto3" json:"created_at_timerange,omitempty"`
+	UpdatedAtTimerange *TimeRange         `protobuf:"bytes,3,opt,name=updated_at_timerange,json=updatedAtTimerange,proto3" json:"updated_at_timerange,omitempty"`
+	UserId             string             `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// include_deleted adds deleted applications to result
+	IncludeDeleted bool `protobuf:"varint,5,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// reason is code of reason of the last status update
+	Reason     string `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	AssigneeId string `protobuf:"bytes,7,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	// unassigned matches applications without assignee, it can't be used with assignee_id
+	Unassigned bool `protobuf:"varint,8,opt,name=unassigned,proto3" json:"unassigned,omitempty"`
+	// overdue matches applications which have passed deadline
+	Overdue bool `protobuf:"varint,9,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	// due_before matches applications with deadline before time
+	DueBefore *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=due_before,json=dueBefore,proto3" json:"due_before,omitempty"`
+	// page_size limits number of applications of response, it's 100 by default,
+	// applications are ordered by id, page fields can't be used by bulk update
+	PageSize int32 `protobuf:"varint,11,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is next_page_token of previous page
+	PageToken            string   `protobuf:"bytes,12,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GetApplicationsByFiltersRequest) Reset()         { *m = GetApplicationsByFiltersRequest{} }
@@ -191,6 +269,62 @@ func (m *GetApplicationsByFiltersRequest) GetUserId() string {
 	return ""
 }
 
+func (m *GetApplicationsByFiltersRequest) GetIncludeDeleted() bool {
+	if m != nil {
+		return m.IncludeDeleted
+	}
+	return false
+}
+
+func (m *GetApplicationsByFiltersRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *GetApplicationsByFiltersRequest) GetAssigneeId() string {
+	if m != nil {
+		return m.AssigneeId
+	}
+	return ""
+}
+
+func (m *GetApplicationsByFiltersRequest) GetUnassigned() bool {
+	if m != nil {
+		return m.Unassigned
+	}
+	return false
+}
+
+func (m *GetApplicationsByFiltersRequest) GetOverdue() bool {
+	if m != nil {
+		return m.Overdue
+	}
+	return false
+}
+
+func (m *GetApplicationsByFiltersRequest) GetDueBefore() *timestamppb.Timestamp {
+	if m != nil {
+		return m.DueBefore
+	}
+	return nil
+}
+
+func (m *GetApplicationsByFiltersRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *GetApplicationsByFiltersRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
 type TimeRange struct {
 	Start                *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
 	End                  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
@@ -239,10 +373,12 @@ func (m *TimeRange) GetEnd() *timestamppb.Timestamp {
 }
 
 type GetApplicationsByFiltersResponse struct {
-	Applications         []*Application `protobuf:"bytes,1,rep,name=applications,proto3" json:"applications,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Applications []*Application `protobuf:"bytes,1,rep,name=applications,proto3" json:"applications,omitempty"`
+	// next_page_token is token of the next page, it's empty for the last page
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GetApplicationsByFiltersResponse) Reset()         { *m = GetApplicationsByFiltersResponse{} }
@@ -277,12 +413,27 @@ func (m *GetApplicationsByFiltersResponse) GetApplications() []*Application {
 	return nil
 }
 
+func (m *GetApplicationsByFiltersResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 type UpdateApplicationRequest struct {
-	Id                   string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Status               Application_Status `protobuf:"varint,2,opt,name=status,proto3,enum=api.Application_Status" json:"status,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	Id     string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status Application_Status `protobuf:"varint,2,opt,name=status,proto3,enum=api.Application_Status" json:"status,omitempty"`
+	// application is updated only if it has expected status, unspecified means any status
+	ExpectedStatus Application_Status `protobuf:"varint,3,opt,name=expected_status,json=expectedStatus,proto3,enum=api.Application_Status" json:"expected_status,omitempty"`
+	// application is updated only if it has expected version
+	ExpectedVersion *wrapperspb.Int64Value `protobuf:"bytes,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// reason is code from configured list, it's required for closing
+	Reason string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	// comment is free text explanation of reason
+	Comment              string   `protobuf:"bytes,6,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *UpdateApplicationRequest) Reset()         { *m = UpdateApplicationRequest{} }
@@ -324,246 +475,931 @@ func (m *UpdateApplicationRequest) GetStatus() Application_Status {
 	return Application_APPLICATION_STATUS_UNSPECIFIED
 }
 
-type CreateApplicationRequest struct {
-	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+func (m *UpdateApplicationRequest) GetExpectedStatus() Application_Status {
+	if m != nil {
+		return m.ExpectedStatus
+	}
+	return Application_APPLICATION_STATUS_UNSPECIFIED
+}
+
+func (m *UpdateApplicationRequest) GetExpectedVersion() *wrapperspb.Int64Value {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return nil
+}
+
+func (m *UpdateApplicationRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *UpdateApplicationRequest) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+type BulkUpdateApplicationsStatusRequest struct {
+	// filter of applications, deleted applications are never updated
+	Filter *GetApplicationsByFiltersRequest `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Status Application_Status               `protobuf:"varint,2,opt,name=status,proto3,enum=api.Application_Status" json:"status,omitempty"`
+	// dry_run only counts applications which would be updated
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// reason and comment are the same as in UpdateApplicationRequest
+	Reason               string   `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Comment              string   `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateApplicationRequest) Reset()         { *m = CreateApplicationRequest{} }
-func (m *CreateApplicationRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateApplicationRequest) ProtoMessage()    {}
-func (*CreateApplicationRequest) Descriptor() ([]byte, []int) {
+func (m *BulkUpdateApplicationsStatusRequest) Reset()         { *m = BulkUpdateApplicationsStatusRequest{} }
+func (m *BulkUpdateApplicationsStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*BulkUpdateApplicationsStatusRequest) ProtoMessage()    {}
+func (*BulkUpdateApplicationsStatusRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_fc846aced8fe6ea6, []int{5}
 }
 
-func (m *CreateApplicationRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateApplicationRequest.Unmarshal(m, b)
+func (m *BulkUpdateApplicationsStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkUpdateApplicationsStatusRequest.Unmarshal(m, b)
 }
-func (m *CreateApplicationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateApplicationRequest.Marshal(b, m, deterministic)
+func (m *BulkUpdateApplicationsStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkUpdateApplicationsStatusRequest.Marshal(b, m, deterministic)
 }
-func (m *CreateApplicationRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateApplicationRequest.Merge(m, src)
+func (m *BulkUpdateApplicationsStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkUpdateApplicationsStatusRequest.Merge(m, src)
 }
-func (m *CreateApplicationRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateApplicationRequest.Size(m)
+func (m *BulkUpdateApplicationsStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_BulkUpdateApplicationsStatusRequest.Size(m)
 }
-func (m *CreateApplicationRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateApplicationRequest.DiscardUnknown(m)
+func (m *BulkUpdateApplicationsStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkUpdateApplicationsStatusRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateApplicationRequest proto.InternalMessageInfo
+var xxx_messageInfo_BulkUpdateApplicationsStatusRequest proto.InternalMessageInfo
 
-func (m *CreateApplicationRequest) GetUserId() string {
+func (m *BulkUpdateApplicationsStatusRequest) GetFilter() *GetApplicationsByFiltersRequest {
 	if m != nil {
-		return m.UserId
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *BulkUpdateApplicationsStatusRequest) GetStatus() Application_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Application_APPLICATION_STATUS_UNSPECIFIED
+}
+
+func (m *BulkUpdateApplicationsStatusRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *BulkUpdateApplicationsStatusRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
 	return ""
 }
 
-type GetApplicationByIdRequest struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (m *BulkUpdateApplicationsStatusRequest) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+type BulkUpdateApplicationsStatusResponse struct {
+	// matched_count is number of applications matching filter which don't have target status
+	MatchedCount int64 `protobuf:"varint,1,opt,name=matched_count,json=matchedCount,proto3" json:"matched_count,omitempty"`
+	// updated_count is 0 for dry run
+	UpdatedCount int64 `protobuf:"varint,2,opt,name=updated_count,json=updatedCount,proto3" json:"updated_count,omitempty"`
+	// sample_ids are ids of some matched applications
+	SampleIds            []string `protobuf:"bytes,3,rep,name=sample_ids,json=sampleIds,proto3" json:"sample_ids,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetApplicationByIdRequest) Reset()         { *m = GetApplicationByIdRequest{} }
-func (m *GetApplicationByIdRequest) String() string { return proto.CompactTextString(m) }
-func (*GetApplicationByIdRequest) ProtoMessage()    {}
-func (*GetApplicationByIdRequest) Descriptor() ([]byte, []int) {
+func (m *BulkUpdateApplicationsStatusResponse) Reset()         { *m = BulkUpdateApplicationsStatusResponse{} }
+func (m *BulkUpdateApplicationsStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*BulkUpdateApplicationsStatusResponse) ProtoMessage()    {}
+func (*BulkUpdateApplicationsStatusResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_fc846aced8fe6ea6, []int{6}
 }
 
-func (m *GetApplicationByIdRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetApplicationByIdRequest.Unmarshal(m, b)
+func (m *BulkUpdateApplicationsStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkUpdateApplicationsStatusResponse.Unmarshal(m, b)
 }
-func (m *GetApplicationByIdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetApplicationByIdRequest.Marshal(b, m, deterministic)
+func (m *BulkUpdateApplicationsStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkUpdateApplicationsStatusResponse.Marshal(b, m, deterministic)
 }
-func (m *GetApplicationByIdRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetApplicationByIdRequest.Merge(m, src)
+func (m *BulkUpdateApplicationsStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkUpdateApplicationsStatusResponse.Merge(m, src)
 }
-func (m *GetApplicationByIdRequest) XXX_Size() int {
-	return xxx_messageInfo_GetApplicationByIdRequest.Size(m)
+func (m *BulkUpdateApplicationsStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_BulkUpdateApplicationsStatusResponse.Size(m)
 }
-func (m *GetApplicationByIdRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetApplicationByIdRequest.DiscardUnknown(m)
+func (m *BulkUpdateApplicationsStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkUpdateApplicationsStatusResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetApplicationByIdRequest proto.InternalMessageInfo
+var xxx_messageInfo_BulkUpdateApplicationsStatusResponse proto.InternalMessageInfo
 
-func (m *GetApplicationByIdRequest) GetId() string {
+func (m *BulkUpdateApplicationsStatusResponse) GetMatchedCount() int64 {
 	if m != nil {
-		return m.Id
+		return m.MatchedCount
 	}
-	return ""
+	return 0
 }
 
-type Application struct {
-	Id                   string                     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Status               Application_Status         `protobuf:"varint,2,opt,name=status,proto3,enum=api.Application_Status" json:"status,omitempty"`
-	UserId               string                     `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	CreatedAt            *timestamppb.Timestamp     `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt            *timestamppb.Timestamp     `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	ExternalStatus       Application_ExternalStatus `protobuf:"varint,6,opt,name=external_status,json=externalStatus,proto3,enum=api.Application_ExternalStatus" json:"external_status,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+func (m *BulkUpdateApplicationsStatusResponse) GetUpdatedCount() int64 {
+	if m != nil {
+		return m.UpdatedCount
+	}
+	return 0
 }
 
-func (m *Application) Reset()         { *m = Application{} }
-func (m *Application) String() string { return proto.CompactTextString(m) }
-func (*Application) ProtoMessage()    {}
-func (*Application) Descriptor() ([]byte, []int) {
+func (m *BulkUpdateApplicationsStatusResponse) GetSampleIds() []string {
+	if m != nil {
+		return m.SampleIds
+	}
+	return nil
+}
+
+type CreateApplicationRequest struct {
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// idempotency_key makes retries of request safe, it can be passed by idempotency-key metadata as well,
+	// it's ignored by CreateApplications
+	IdempotencyKey string `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// unspecified priority is normal
+	Priority             Application_Priority `protobuf:"varint,3,opt,name=priority,proto3,enum=api.Application_Priority" json:"priority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CreateApplicationRequest) Reset()         { *m = CreateApplicationRequest{} }
+func (m *CreateApplicationRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateApplicationRequest) ProtoMessage()    {}
+func (*CreateApplicationRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_fc846aced8fe6ea6, []int{7}
 }
 
-func (m *Application) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Application.Unmarshal(m, b)
+func (m *CreateApplicationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateApplicationRequest.Unmarshal(m, b)
 }
-func (m *Application) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Application.Marshal(b, m, deterministic)
+func (m *CreateApplicationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateApplicationRequest.Marshal(b, m, deterministic)
 }
-func (m *Application) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Application.Merge(m, src)
+func (m *CreateApplicationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateApplicationRequest.Merge(m, src)
 }
-func (m *Application) XXX_Size() int {
-	return xxx_messageInfo_Application.Size(m)
+func (m *CreateApplicationRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateApplicationRequest.Size(m)
 }
-func (m *Application) XXX_DiscardUnknown() {
-	xxx_messageInfo_Application.DiscardUnknown(m)
+func (m *CreateApplicationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateApplicationRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Application proto.InternalMessageInfo
+var xxx_messageInfo_CreateApplicationRequest proto.InternalMessageInfo
 
-func (m *Application) GetId() string {
+func (m *CreateApplicationRequest) GetUserId() string {
 	if m != nil {
-		return m.Id
+		return m.UserId
 	}
 	return ""
 }
 
-func (m *Application) GetStatus() Application_Status {
+func (m *CreateApplicationRequest) GetIdempotencyKey() string {
 	if m != nil {
-		return m.Status
+		return m.IdempotencyKey
 	}
-	return Application_APPLICATION_STATUS_UNSPECIFIED
+	return ""
 }
 
-func (m *Application) GetUserId() string {
+func (m *CreateApplicationRequest) GetPriority() Application_Priority {
 	if m != nil {
-		return m.UserId
+		return m.Priority
 	}
-	return ""
+	return Application_APPLICATION_PRIORITY_UNSPECIFIED
 }
 
-func (m *Application) GetCreatedAt() *timestamppb.Timestamp {
+type CreateApplicationsRequest struct {
+	Applications         []*CreateApplicationRequest    `protobuf:"bytes,1,rep,name=applications,proto3" json:"applications,omitempty"`
+	Mode                 CreateApplicationsRequest_Mode `protobuf:"varint,2,opt,name=mode,proto3,enum=api.CreateApplicationsRequest_Mode" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *CreateApplicationsRequest) Reset()         { *m = CreateApplicationsRequest{} }
+func (m *CreateApplicationsRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateApplicationsRequest) ProtoMessage()    {}
+func (*CreateApplicationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{8}
+}
+
+func (m *CreateApplicationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateApplicationsRequest.Unmarshal(m, b)
+}
+func (m *CreateApplicationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateApplicationsRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateApplicationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateApplicationsRequest.Merge(m, src)
+}
+func (m *CreateApplicationsRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateApplicationsRequest.Size(m)
+}
+func (m *CreateApplicationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateApplicationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateApplicationsRequest proto.InternalMessageInfo
+
+func (m *CreateApplicationsRequest) GetApplications() []*CreateApplicationRequest {
 	if m != nil {
-		return m.CreatedAt
+		return m.Applications
 	}
 	return nil
 }
 
-func (m *Application) GetUpdatedAt() *timestamppb.Timestamp {
+func (m *CreateApplicationsRequest) GetMode() CreateApplicationsRequest_Mode {
 	if m != nil {
-		return m.UpdatedAt
+		return m.Mode
 	}
-	return nil
+	return CreateApplicationsRequest_CREATE_APPLICATIONS_MODE_ALL_OR_NOTHING
 }
 
-func (m *Application) GetExternalStatus() Application_ExternalStatus {
+type CreateApplicationsResponse struct {
+	Results              []*CreateApplicationsResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
+	XXX_unrecognized     []byte                               `json:"-"`
+	XXX_sizecache        int32                                `json:"-"`
+}
+
+func (m *CreateApplicationsResponse) Reset()         { *m = CreateApplicationsResponse{} }
+func (m *CreateApplicationsResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateApplicationsResponse) ProtoMessage()    {}
+func (*CreateApplicationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{9}
+}
+
+func (m *CreateApplicationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateApplicationsResponse.Unmarshal(m, b)
+}
+func (m *CreateApplicationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateApplicationsResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateApplicationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateApplicationsResponse.Merge(m, src)
+}
+func (m *CreateApplicationsResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateApplicationsResponse.Size(m)
+}
+func (m *CreateApplicationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateApplicationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateApplicationsResponse proto.InternalMessageInfo
+
+func (m *CreateApplicationsResponse) GetResults() []*CreateApplicationsResponse_Result {
 	if m != nil {
-		return m.ExternalStatus
+		return m.Results
 	}
-	return Application_APPLICATION_EXTERNAL_STATUS_UNSPECIFIED
+	return nil
 }
 
-func init() {
-	proto.RegisterEnum("api.Application_Status", Application_Status_name, Application_Status_value)
-	proto.RegisterEnum("api.Application_ExternalStatus", Application_ExternalStatus_name, Application_ExternalStatus_value)
-	proto.RegisterType((*GetApplicationByIdRequestRequest)(nil), "api.GetApplicationByIdRequestRequest")
-	proto.RegisterType((*GetApplicationsByFiltersRequest)(nil), "api.GetApplicationsByFiltersRequest")
-	proto.RegisterType((*TimeRange)(nil), "api.TimeRange")
-	proto.RegisterType((*GetApplicationsByFiltersResponse)(nil), "api.GetApplicationsByFiltersResponse")
-	proto.RegisterType((*UpdateApplicationRequest)(nil), "api.UpdateApplicationRequest")
-	proto.RegisterType((*CreateApplicationRequest)(nil), "api.CreateApplicationRequest")
-	proto.RegisterType((*GetApplicationByIdRequest)(nil), "api.GetApplicationByIdRequest")
-	proto.RegisterType((*Application)(nil), "api.Application")
+// Result has either application or error
+type CreateApplicationsResponse_Result struct {
+	Application          *Application `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+	Error                *Error       `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func init() { proto.RegisterFile("application.proto", fileDescriptor_fc846aced8fe6ea6) }
+func (m *CreateApplicationsResponse_Result) Reset()         { *m = CreateApplicationsResponse_Result{} }
+func (m *CreateApplicationsResponse_Result) String() string { return proto.CompactTextString(m) }
+func (*CreateApplicationsResponse_Result) ProtoMessage()    {}
+func (*CreateApplicationsResponse_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{9, 0}
+}
 
-var fileDescriptor_fc846aced8fe6ea6 = []byte{
-	// 648 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xc1, 0x4e, 0xdb, 0x4c,
-	0x10, 0xc7, 0xb1, 0x03, 0xf9, 0x3e, 0x26, 0x55, 0x1a, 0x56, 0x95, 0x62, 0x52, 0x01, 0x91, 0x5b,
-	0x04, 0x15, 0x95, 0x53, 0x99, 0x5e, 0xb8, 0xd5, 0x49, 0x0c, 0xb2, 0x8a, 0x12, 0x6b, 0x6d, 0x24,
-	0x6e, 0xd6, 0x82, 0xb7, 0xd1, 0xaa, 0x21, 0x76, 0xed, 0x0d, 0x2a, 0xd7, 0x9e, 0x7b, 0xe9, 0xa3,
-	0xf5, 0x05, 0xfa, 0x0a, 0xbd, 0xf7, 0x54, 0x79, 0xe3, 0x24, 0x0e, 0xb6, 0xc3, 0xa5, 0xa7, 0x38,
-	0xde, 0xdf, 0xcc, 0xce, 0xfc, 0xe7, 0xef, 0x81, 0x1d, 0x12, 0x86, 0x63, 0x76, 0x4b, 0x38, 0x0b,
-	0x26, 0x5a, 0x18, 0x05, 0x3c, 0x40, 0x15, 0x12, 0xb2, 0xd6, 0xc1, 0x28, 0x08, 0x46, 0x63, 0xda,
-	0x11, 0xaf, 0x6e, 0xa6, 0x9f, 0x3a, 0x9c, 0xdd, 0xd1, 0x98, 0x93, 0xbb, 0x70, 0x46, 0xb5, 0x9a,
-	0xf7, 0x64, 0xcc, 0x7c, 0xc2, 0x69, 0x67, 0xfe, 0x30, 0x3b, 0x50, 0x75, 0x68, 0x5f, 0x50, 0x6e,
-	0x2c, 0xd3, 0x76, 0x1f, 0x2c, 0x1f, 0xd3, 0x2f, 0x53, 0x1a, 0xf3, 0xf4, 0x07, 0xd5, 0x41, 0x66,
-	0xbe, 0x22, 0xb5, 0xa5, 0xe3, 0x6d, 0x2c, 0x33, 0x5f, 0xfd, 0x2d, 0xc1, 0xc1, 0x6a, 0x50, 0xdc,
-	0x7d, 0x38, 0x67, 0x63, 0x4e, 0xa3, 0x78, 0x1e, 0xd3, 0x81, 0x6a, 0xcc, 0x09, 0x9f, 0xc6, 0x22,
-	0xae, 0xae, 0x37, 0x35, 0x12, 0x32, 0x2d, 0x13, 0xa2, 0x39, 0xe2, 0x18, 0xa7, 0x18, 0xfa, 0x00,
-	0x2f, 0x6e, 0x23, 0x4a, 0x38, 0xf5, 0x3d, 0xc2, 0xbd, 0xa4, 0xfe, 0x88, 0x4c, 0x46, 0x54, 0x91,
-	0xdb, 0xd2, 0x71, 0x4d, 0xaf, 0x8b, 0x70, 0x97, 0xdd, 0x51, 0x9c, 0xbc, 0xc5, 0x28, 0x65, 0x0d,
-	0xee, 0xce, 0xc9, 0x24, 0xc3, 0x34, 0xf4, 0xf3, 0x19, 0x2a, 0xc5, 0x19, 0x52, 0x36, 0x9b, 0xa1,
-	0x09, 0xff, 0x4d, 0x63, 0x1a, 0x79, 0xcc, 0x57, 0x36, 0x45, 0xb7, 0xd5, 0xe4, 0xaf, 0xe5, 0xab,
-	0x9f, 0x61, 0x7b, 0x11, 0x89, 0xde, 0xc1, 0x56, 0xcc, 0x49, 0xc4, 0x45, 0x67, 0x35, 0xbd, 0xa5,
-	0xcd, 0xc4, 0xd7, 0xe6, 0xe2, 0x8b, 0x4b, 0x84, 0xf8, 0x78, 0x06, 0xa2, 0xb7, 0x50, 0xa1, 0x13,
-	0x3f, 0x6d, 0x65, 0x1d, 0x9f, 0x60, 0xea, 0xf5, 0xe3, 0x91, 0x64, 0xd5, 0x8d, 0xc3, 0x60, 0x12,
-	0x53, 0xf4, 0x1e, 0x9e, 0x65, 0xac, 0x90, 0x88, 0x5c, 0x39, 0xae, 0xe9, 0x8d, 0xc7, 0x22, 0xe3,
-	0x15, 0x4a, 0xa5, 0xa0, 0x5c, 0x89, 0xae, 0xb3, 0x48, 0xf1, 0x90, 0xd1, 0xd9, 0x62, 0x80, 0xf2,
-	0xda, 0x01, 0x76, 0xff, 0xff, 0xd3, 0xdd, 0xfa, 0x26, 0xc9, 0xed, 0x8d, 0xf9, 0x28, 0xd5, 0x53,
-	0x50, 0x7a, 0x62, 0x3c, 0x05, 0xd7, 0x64, 0x24, 0x96, 0x56, 0x24, 0x3e, 0x81, 0xdd, 0x52, 0x23,
-	0xe6, 0x1c, 0xf8, 0x6b, 0x13, 0x6a, 0x19, 0xf4, 0x1f, 0x16, 0x9f, 0x2d, 0xb0, 0x92, 0x2d, 0x10,
-	0x9d, 0x01, 0x2c, 0x0d, 0x2a, 0xfc, 0xb1, 0x7e, 0x96, 0xdb, 0x0b, 0x8b, 0x26, 0xa1, 0x4b, 0x67,
-	0x2a, 0x5b, 0x4f, 0x87, 0x2e, 0xbc, 0x89, 0x30, 0x3c, 0xa7, 0x5f, 0x39, 0x8d, 0x26, 0x64, 0xec,
-	0xa5, 0x2d, 0x55, 0x45, 0x4b, 0x07, 0xb9, 0x96, 0xcc, 0x94, 0xcb, 0xb5, 0x56, 0xa7, 0x2b, 0x27,
-	0xea, 0x77, 0x09, 0xaa, 0xb3, 0x47, 0xa4, 0xc2, 0xbe, 0x61, 0xdb, 0x97, 0x56, 0xcf, 0x70, 0xad,
-	0xe1, 0xc0, 0x73, 0x5c, 0xc3, 0xbd, 0x72, 0xbc, 0xab, 0x81, 0x63, 0x9b, 0x3d, 0xeb, 0xdc, 0x32,
-	0xfb, 0x8d, 0x0d, 0xf4, 0x12, 0x9a, 0x05, 0xcc, 0xd0, 0x36, 0x07, 0x0d, 0xa9, 0x24, 0x81, 0x35,
-	0xf0, 0x6c, 0x3c, 0xbc, 0xc0, 0xa6, 0xe3, 0x34, 0x64, 0xb4, 0x07, 0xbb, 0x05, 0x4c, 0xef, 0x72,
-	0xe8, 0x98, 0xfd, 0x46, 0x45, 0xfd, 0x21, 0x41, 0x7d, 0xb5, 0x76, 0x74, 0x02, 0x47, 0xd9, 0x08,
-	0xf3, 0xda, 0x35, 0xf1, 0xc0, 0xb8, 0x2c, 0xae, 0xef, 0x0d, 0x1c, 0xae, 0x83, 0x6d, 0x3c, 0xec,
-	0x99, 0x4e, 0x72, 0x95, 0x84, 0x8e, 0xe0, 0xd5, 0x3a, 0xd4, 0xf9, 0x68, 0xd9, 0xb6, 0xd9, 0x6f,
-	0xc8, 0xfa, 0x4f, 0x19, 0x50, 0x46, 0x5b, 0x87, 0x46, 0xf7, 0xec, 0x96, 0xa2, 0x3e, 0xec, 0xe4,
-	0x9c, 0x8d, 0xf6, 0xc4, 0x24, 0xca, 0x1c, 0xdf, 0xca, 0x7d, 0x94, 0xe8, 0x1c, 0x50, 0xde, 0xea,
-	0x68, 0x5f, 0x70, 0xa5, 0xdf, 0x40, 0x41, 0x9e, 0x11, 0x28, 0x65, 0x8b, 0x02, 0xbd, 0x2e, 0xc8,
-	0x96, 0xdb, 0xd2, 0xad, 0xc3, 0x27, 0xa8, 0x74, 0xdb, 0xf4, 0x61, 0x27, 0xb7, 0x37, 0xd2, 0xb6,
-	0xcb, 0xf6, 0x49, 0xbe, 0xdc, 0x9b, 0xaa, 0x70, 0xfa, 0xe9, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0xdf, 0xda, 0x82, 0x7f, 0xc5, 0x06, 0x00, 0x00,
+func (m *CreateApplicationsResponse_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateApplicationsResponse_Result.Unmarshal(m, b)
+}
+func (m *CreateApplicationsResponse_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateApplicationsResponse_Result.Marshal(b, m, deterministic)
+}
+func (m *CreateApplicationsResponse_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateApplicationsResponse_Result.Merge(m, src)
+}
+func (m *CreateApplicationsResponse_Result) XXX_Size() int {
+	return xxx_messageInfo_CreateApplicationsResponse_Result.Size(m)
+}
+func (m *CreateApplicationsResponse_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateApplicationsResponse_Result.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_CreateApplicationsResponse_Result proto.InternalMessageInfo
+
+func (m *CreateApplicationsResponse_Result) GetApplication() *Application {
+	if m != nil {
+		return m.Application
+	}
+	return nil
+}
+
+func (m *CreateApplicationsResponse_Result) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+// Error is error of item of batch
+type Error struct {
+	// code is grpc status code
+	Code                 int32    `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Error) Reset()         { *m = Error{} }
+func (m *Error) String() string { return proto.CompactTextString(m) }
+func (*Error) ProtoMessage()    {}
+func (*Error) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{10}
+}
+
+func (m *Error) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Error.Unmarshal(m, b)
+}
+func (m *Error) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Error.Marshal(b, m, deterministic)
+}
+func (m *Error) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Error.Merge(m, src)
+}
+func (m *Error) XXX_Size() int {
+	return xxx_messageInfo_Error.Size(m)
+}
+func (m *Error) XXX_DiscardUnknown() {
+	xxx_messageInfo_Error.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Error proto.InternalMessageInfo
+
+func (m *Error) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *Error) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type GetApplicationByIdRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// include_deleted allows to get deleted application
+	IncludeDeleted       bool     `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetApplicationByIdRequest) Reset()         { *m = GetApplicationByIdRequest{} }
+func (m *GetApplicationByIdRequest) String() string { return proto.CompactTextString(m) }
+func (*GetApplicationByIdRequest) ProtoMessage()    {}
+func (*GetApplicationByIdRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{11}
+}
+
+func (m *GetApplicationByIdRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetApplicationByIdRequest.Unmarshal(m, b)
+}
+func (m *GetApplicationByIdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetApplicationByIdRequest.Marshal(b, m, deterministic)
+}
+func (m *GetApplicationByIdRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetApplicationByIdRequest.Merge(m, src)
+}
+func (m *GetApplicationByIdRequest) XXX_Size() int {
+	return xxx_messageInfo_GetApplicationByIdRequest.Size(m)
+}
+func (m *GetApplicationByIdRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetApplicationByIdRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetApplicationByIdRequest proto.InternalMessageInfo
+
+func (m *GetApplicationByIdRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GetApplicationByIdRequest) GetIncludeDeleted() bool {
+	if m != nil {
+		return m.IncludeDeleted
+	}
+	return false
+}
+
+type DeleteApplicationRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteApplicationRequest) Reset()         { *m = DeleteApplicationRequest{} }
+func (m *DeleteApplicationRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteApplicationRequest) ProtoMessage()    {}
+func (*DeleteApplicationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{12}
+}
+
+func (m *DeleteApplicationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteApplicationRequest.Unmarshal(m, b)
+}
+func (m *DeleteApplicationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteApplicationRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteApplicationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteApplicationRequest.Merge(m, src)
+}
+func (m *DeleteApplicationRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteApplicationRequest.Size(m)
+}
+func (m *DeleteApplicationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteApplicationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteApplicationRequest proto.InternalMessageInfo
+
+func (m *DeleteApplicationRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type RestoreApplicationRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreApplicationRequest) Reset()         { *m = RestoreApplicationRequest{} }
+func (m *RestoreApplicationRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreApplicationRequest) ProtoMessage()    {}
+func (*RestoreApplicationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{13}
+}
+
+func (m *RestoreApplicationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreApplicationRequest.Unmarshal(m, b)
+}
+func (m *RestoreApplicationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreApplicationRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreApplicationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreApplicationRequest.Merge(m, src)
+}
+func (m *RestoreApplicationRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreApplicationRequest.Size(m)
+}
+func (m *RestoreApplicationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreApplicationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreApplicationRequest proto.InternalMessageInfo
+
+func (m *RestoreApplicationRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type AssignApplicationRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// assignee_id is id of operator
+	AssigneeId           string   `protobuf:"bytes,2,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AssignApplicationRequest) Reset()         { *m = AssignApplicationRequest{} }
+func (m *AssignApplicationRequest) String() string { return proto.CompactTextString(m) }
+func (*AssignApplicationRequest) ProtoMessage()    {}
+func (*AssignApplicationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{14}
+}
+
+func (m *AssignApplicationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AssignApplicationRequest.Unmarshal(m, b)
+}
+func (m *AssignApplicationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AssignApplicationRequest.Marshal(b, m, deterministic)
+}
+func (m *AssignApplicationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AssignApplicationRequest.Merge(m, src)
+}
+func (m *AssignApplicationRequest) XXX_Size() int {
+	return xxx_messageInfo_AssignApplicationRequest.Size(m)
+}
+func (m *AssignApplicationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AssignApplicationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AssignApplicationRequest proto.InternalMessageInfo
+
+func (m *AssignApplicationRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AssignApplicationRequest) GetAssigneeId() string {
+	if m != nil {
+		return m.AssigneeId
+	}
+	return ""
+}
+
+type UnassignApplicationRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnassignApplicationRequest) Reset()         { *m = UnassignApplicationRequest{} }
+func (m *UnassignApplicationRequest) String() string { return proto.CompactTextString(m) }
+func (*UnassignApplicationRequest) ProtoMessage()    {}
+func (*UnassignApplicationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{15}
+}
+
+func (m *UnassignApplicationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnassignApplicationRequest.Unmarshal(m, b)
+}
+func (m *UnassignApplicationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnassignApplicationRequest.Marshal(b, m, deterministic)
+}
+func (m *UnassignApplicationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnassignApplicationRequest.Merge(m, src)
+}
+func (m *UnassignApplicationRequest) XXX_Size() int {
+	return xxx_messageInfo_UnassignApplicationRequest.Size(m)
+}
+func (m *UnassignApplicationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnassignApplicationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnassignApplicationRequest proto.InternalMessageInfo
+
+func (m *UnassignApplicationRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type Application struct {
+	Id             string                     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status         Application_Status         `protobuf:"varint,2,opt,name=status,proto3,enum=api.Application_Status" json:"status,omitempty"`
+	UserId         string                     `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CreatedAt      *timestamppb.Timestamp     `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp     `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ExternalStatus Application_ExternalStatus `protobuf:"varint,6,opt,name=external_status,json=externalStatus,proto3,enum=api.Application_ExternalStatus" json:"external_status,omitempty"`
+	// incremented on every update
+	Version int64 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	// set for deleted application only
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// reason of the last status update
+	Reason        string `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+	ReasonComment string `protobuf:"bytes,10,opt,name=reason_comment,json=reasonComment,proto3" json:"reason_comment,omitempty"`
+	// id of operator handling application, empty for unassigned one
+	AssigneeId string               `protobuf:"bytes,11,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	Priority   Application_Priority `protobuf:"varint,12,opt,name=priority,proto3,enum=api.Application_Priority" json:"priority,omitempty"`
+	// deadline of current status by SLA policy, it's empty if status doesn't have deadline
+	DueAt *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	// breached is set when application hasn't left status before deadline
+	Breached             bool     `protobuf:"varint,14,opt,name=breached,proto3" json:"breached,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Application) Reset()         { *m = Application{} }
+func (m *Application) String() string { return proto.CompactTextString(m) }
+func (*Application) ProtoMessage()    {}
+func (*Application) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc846aced8fe6ea6, []int{16}
+}
+
+func (m *Application) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Application.Unmarshal(m, b)
+}
+func (m *Application) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Application.Marshal(b, m, deterministic)
+}
+func (m *Application) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Application.Merge(m, src)
+}
+func (m *Application) XXX_Size() int {
+	return xxx_messageInfo_Application.Size(m)
+}
+func (m *Application) XXX_DiscardUnknown() {
+	xxx_messageInfo_Application.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Application proto.InternalMessageInfo
+
+func (m *Application) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Application) GetStatus() Application_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Application_APPLICATION_STATUS_UNSPECIFIED
+}
+
+func (m *Application) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *Application) GetCreatedAt() *timestamppb.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Application) GetUpdatedAt() *timestamppb.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *Application) GetExternalStatus() Application_ExternalStatus {
+	if m != nil {
+		return m.ExternalStatus
+	}
+	return Application_APPLICATION_EXTERNAL_STATUS_UNSPECIFIED
+}
+
+func (m *Application) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Application) GetDeletedAt() *timestamppb.Timestamp {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return nil
+}
+
+func (m *Application) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Application) GetReasonComment() string {
+	if m != nil {
+		return m.ReasonComment
+	}
+	return ""
+}
+
+func (m *Application) GetAssigneeId() string {
+	if m != nil {
+		return m.AssigneeId
+	}
+	return ""
+}
+
+func (m *Application) GetPriority() Application_Priority {
+	if m != nil {
+		return m.Priority
+	}
+	return Application_APPLICATION_PRIORITY_UNSPECIFIED
+}
+
+func (m *Application) GetDueAt() *timestamppb.Timestamp {
+	if m != nil {
+		return m.DueAt
+	}
+	return nil
+}
+
+func (m *Application) GetBreached() bool {
+	if m != nil {
+		return m.Breached
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterEnum("api.CreateApplicationsRequest_Mode", CreateApplicationsRequest_Mode_name, CreateApplicationsRequest_Mode_value)
+	proto.RegisterEnum("api.Application_Status", Application_Status_name, Application_Status_value)
+	proto.RegisterEnum("api.Application_Priority", Application_Priority_name, Application_Priority_value)
+	proto.RegisterEnum("api.Application_ExternalStatus", Application_ExternalStatus_name, Application_ExternalStatus_value)
+	proto.RegisterType((*GetApplicationByIdRequestRequest)(nil), "api.GetApplicationByIdRequestRequest")
+	proto.RegisterType((*GetApplicationsByFiltersRequest)(nil), "api.GetApplicationsByFiltersRequest")
+	proto.RegisterType((*TimeRange)(nil), "api.TimeRange")
+	proto.RegisterType((*GetApplicationsByFiltersResponse)(nil), "api.GetApplicationsByFiltersResponse")
+	proto.RegisterType((*UpdateApplicationRequest)(nil), "api.UpdateApplicationRequest")
+	proto.RegisterType((*BulkUpdateApplicationsStatusRequest)(nil), "api.BulkUpdateApplicationsStatusRequest")
+	proto.RegisterType((*BulkUpdateApplicationsStatusResponse)(nil), "api.BulkUpdateApplicationsStatusResponse")
+	proto.RegisterType((*CreateApplicationRequest)(nil), "api.CreateApplicationRequest")
+	proto.RegisterType((*CreateApplicationsRequest)(nil), "api.CreateApplicationsRequest")
+	proto.RegisterType((*CreateApplicationsResponse)(nil), "api.CreateApplicationsResponse")
+	proto.RegisterType((*CreateApplicationsResponse_Result)(nil), "api.CreateApplicationsResponse.Result")
+	proto.RegisterType((*Error)(nil), "api.Error")
+	proto.RegisterType((*GetApplicationByIdRequest)(nil), "api.GetApplicationByIdRequest")
+	proto.RegisterType((*DeleteApplicationRequest)(nil), "api.DeleteApplicationRequest")
+	proto.RegisterType((*RestoreApplicationRequest)(nil), "api.RestoreApplicationRequest")
+	proto.RegisterType((*AssignApplicationRequest)(nil), "api.AssignApplicationRequest")
+	proto.RegisterType((*UnassignApplicationRequest)(nil), "api.UnassignApplicationRequest")
+	proto.RegisterType((*Application)(nil), "api.Application")
+}
+
+func init() { proto.RegisterFile("application.proto", fileDescriptor_fc846aced8fe6ea6) }
+
+var fileDescriptor_fc846aced8fe6ea6 = []byte{
+	// 1553 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdd, 0x6e, 0xdb, 0x56,
+	0x12, 0x36, 0xa9, 0xff, 0x91, 0x2d, 0xcb, 0x67, 0x17, 0x6b, 0x5a, 0x89, 0x6d, 0x81, 0x76, 0x12,
+	0x65, 0x13, 0xc8, 0xbb, 0x4e, 0xb2, 0x8b, 0x60, 0x6f, 0xa2, 0x1f, 0xda, 0x16, 0xe2, 0x48, 0xc2,
+	0x11, 0x9d, 0xdd, 0xbd, 0xd8, 0x25, 0x68, 0xf1, 0xc4, 0x4b, 0x58, 0x22, 0xb5, 0xfc, 0x71, 0xa3,
+	0x5c, 0x15, 0x2d, 0x7a, 0x53, 0x14, 0xe8, 0xcf, 0x0b, 0xf4, 0x11, 0x72, 0xdf, 0x02, 0x45, 0xdf,
+	0xa0, 0xaf, 0x91, 0x3e, 0x45, 0x0b, 0x1e, 0x1e, 0xca, 0x94, 0x49, 0x4a, 0x06, 0xda, 0x2b, 0xeb,
+	0xcc, 0xf9, 0x66, 0x34, 0x33, 0xfa, 0xce, 0x7c, 0x63, 0xd8, 0x50, 0x27, 0x93, 0x91, 0x3e, 0x54,
+	0x1d, 0xdd, 0x34, 0xea, 0x13, 0xcb, 0x74, 0x4c, 0x94, 0x52, 0x27, 0x7a, 0x65, 0xf7, 0xc2, 0x34,
+	0x2f, 0x46, 0xe4, 0x80, 0x9a, 0xce, 0xdd, 0x37, 0x07, 0x8e, 0x3e, 0x26, 0xb6, 0xa3, 0x8e, 0x27,
+	0x3e, 0xaa, 0xb2, 0x73, 0x13, 0xf0, 0x91, 0xa5, 0x4e, 0x26, 0xc4, 0xb2, 0xd9, 0xfd, 0xe6, 0x95,
+	0x3a, 0xd2, 0x35, 0xd5, 0x21, 0x07, 0xc1, 0x07, 0xff, 0x42, 0x3c, 0x84, 0xea, 0x31, 0x71, 0x1a,
+	0xd7, 0x5f, 0xdb, 0x9c, 0x76, 0x34, 0x4c, 0xfe, 0xef, 0x12, 0xdb, 0x61, 0x7f, 0x50, 0x09, 0x78,
+	0x5d, 0x13, 0xb8, 0x2a, 0x57, 0x2b, 0x60, 0x5e, 0xd7, 0xc4, 0xaf, 0xd2, 0xb0, 0x3b, 0xef, 0x64,
+	0x37, 0xa7, 0x47, 0xfa, 0xc8, 0x21, 0x96, 0x1d, 0xf8, 0x1c, 0x40, 0xd6, 0x76, 0x54, 0xc7, 0xb5,
+	0xa9, 0x5f, 0xe9, 0x70, 0xb3, 0xae, 0x4e, 0xf4, 0x7a, 0xc8, 0xa5, 0x3e, 0xa0, 0xd7, 0x98, 0xc1,
+	0xd0, 0x0b, 0xf8, 0xe3, 0xd0, 0x22, 0xaa, 0x43, 0x34, 0x45, 0x75, 0x14, 0xaf, 0x3e, 0x4b, 0x35,
+	0x2e, 0x88, 0xc0, 0x57, 0xb9, 0x5a, 0xf1, 0xb0, 0x44, 0xdd, 0x65, 0x7d, 0x4c, 0xb0, 0x67, 0xc5,
+	0x88, 0x61, 0x1b, 0x8e, 0x1c, 0x20, 0xbd, 0x08, 0xee, 0x44, 0x8b, 0x46, 0x48, 0xc5, 0x47, 0x60,
+	0xd8, 0x70, 0x84, 0x4d, 0xc8, 0xb9, 0x36, 0xb1, 0x14, 0x5d, 0x13, 0xd2, 0xb4, 0xda, 0xac, 0x77,
+	0xec, 0x68, 0xe8, 0x01, 0xac, 0xeb, 0xc6, 0x70, 0xe4, 0x6a, 0x44, 0xd1, 0xc8, 0x88, 0x38, 0x44,
+	0x13, 0x32, 0x55, 0xae, 0x96, 0xc7, 0x25, 0x66, 0x6e, 0xfb, 0x56, 0xf4, 0x27, 0xc8, 0x5a, 0x44,
+	0xb5, 0x4d, 0x43, 0xc8, 0xfa, 0x01, 0xfc, 0x13, 0xda, 0x85, 0xa2, 0x6a, 0xdb, 0xfa, 0x85, 0x41,
+	0x88, 0x17, 0x3d, 0x47, 0x2f, 0x21, 0x30, 0x75, 0x34, 0xb4, 0x03, 0xe0, 0x1a, 0xec, 0xac, 0x09,
+	0x79, 0x1a, 0x3c, 0x64, 0x41, 0x02, 0xe4, 0xcc, 0x2b, 0x62, 0x69, 0x2e, 0x11, 0x0a, 0xf4, 0x32,
+	0x38, 0xa2, 0xe7, 0x00, 0x9a, 0x4b, 0x94, 0x73, 0xf2, 0xc6, 0xb4, 0x88, 0x00, 0xb4, 0xd8, 0x4a,
+	0xdd, 0xe7, 0x43, 0x3d, 0xe0, 0x43, 0x5d, 0x0e, 0x08, 0x83, 0x0b, 0x9a, 0x4b, 0x9a, 0x14, 0x8c,
+	0x1e, 0x42, 0x61, 0xa2, 0x5e, 0x10, 0xc5, 0xd6, 0xdf, 0x11, 0xa1, 0x58, 0xe5, 0x6a, 0x99, 0xe6,
+	0xea, 0x77, 0x3f, 0xff, 0x98, 0xca, 0x55, 0x32, 0xc2, 0x87, 0x5c, 0x6d, 0x05, 0xe7, 0xbd, 0xeb,
+	0x81, 0xfe, 0x8e, 0xa0, 0x6d, 0x00, 0x0a, 0x75, 0xcc, 0x4b, 0x62, 0x08, 0xab, 0x34, 0x7f, 0xea,
+	0x2c, 0x7b, 0x06, 0xf1, 0x12, 0x0a, 0xb3, 0xd6, 0xa2, 0xbf, 0x40, 0xc6, 0x76, 0x54, 0xcb, 0x11,
+	0xb8, 0xa5, 0xc9, 0xf8, 0x40, 0xf4, 0x18, 0x52, 0xc4, 0xd0, 0x04, 0x7e, 0x29, 0xde, 0x83, 0x89,
+	0x1f, 0x73, 0x50, 0x4d, 0xe6, 0x9f, 0x3d, 0x31, 0x0d, 0x9b, 0xa0, 0xa7, 0xb0, 0x1a, 0x7a, 0x4c,
+	0x1e, 0x0d, 0x53, 0xb5, 0xe2, 0x61, 0xf9, 0x26, 0x0d, 0xf1, 0x1c, 0x0a, 0xdd, 0x87, 0x75, 0x83,
+	0xbc, 0x75, 0x94, 0x50, 0xad, 0x3c, 0xad, 0x75, 0xcd, 0x33, 0xf7, 0x67, 0xf5, 0xbe, 0xe7, 0x41,
+	0x38, 0xa3, 0x04, 0x0a, 0xc7, 0x8a, 0x7f, 0x2f, 0xe8, 0x1f, 0xb3, 0xb7, 0xc0, 0x2f, 0x7c, 0x0b,
+	0x4d, 0xf0, 0x9a, 0x9f, 0xf9, 0x84, 0xe3, 0xab, 0x2b, 0xa1, 0x77, 0xb1, 0x4e, 0xde, 0x4e, 0xc8,
+	0xd0, 0xa3, 0x35, 0x8b, 0x92, 0x5a, 0xfc, 0xa2, 0x4a, 0x01, 0xde, 0x3f, 0xa3, 0x23, 0x28, 0xcf,
+	0x22, 0x5c, 0x11, 0xcb, 0xd6, 0x4d, 0x83, 0xd2, 0xbb, 0x78, 0x78, 0x27, 0xd2, 0xe9, 0x8e, 0xe1,
+	0xfc, 0xed, 0xe9, 0x6b, 0x75, 0xe4, 0x12, 0x3c, 0xfb, 0xda, 0xd7, 0xbe, 0x4f, 0x88, 0xdb, 0x99,
+	0x39, 0x6e, 0xef, 0x43, 0x6e, 0x68, 0x8e, 0xc7, 0xc4, 0x70, 0x7c, 0xd2, 0xb3, 0x32, 0xac, 0x94,
+	0xf0, 0x21, 0x87, 0x83, 0x2b, 0xf1, 0x53, 0x1e, 0xf6, 0x9a, 0xee, 0xe8, 0x32, 0xd2, 0x35, 0x9b,
+	0xa5, 0xcd, 0x9a, 0x77, 0x02, 0xd9, 0x37, 0xf4, 0xa7, 0x64, 0xec, 0xd9, 0xa7, 0x65, 0x2e, 0x19,
+	0x37, 0xec, 0x2b, 0x3f, 0xe7, 0xf8, 0x32, 0x87, 0x99, 0xff, 0x6f, 0x6b, 0xfb, 0x26, 0xe4, 0x34,
+	0x6b, 0xaa, 0x58, 0xae, 0x41, 0xdb, 0x9d, 0xc7, 0x59, 0xcd, 0x9a, 0x62, 0x37, 0xdc, 0x85, 0x74,
+	0x52, 0x17, 0x32, 0xc9, 0x5d, 0xf8, 0x92, 0x83, 0xfd, 0xc5, 0x5d, 0x60, 0xf4, 0xdd, 0x83, 0xb5,
+	0xb1, 0xea, 0x0c, 0xff, 0x47, 0x34, 0x65, 0x68, 0xba, 0x86, 0xff, 0x96, 0x52, 0x78, 0x95, 0x19,
+	0x5b, 0x9e, 0xcd, 0x03, 0x05, 0x13, 0xcf, 0x07, 0xf1, 0x3e, 0x88, 0x19, 0x7d, 0xd0, 0x36, 0x80,
+	0xad, 0x8e, 0x27, 0x23, 0x6f, 0xf0, 0x78, 0xdc, 0x49, 0x79, 0x2f, 0xd7, 0xb7, 0x74, 0x34, 0x5b,
+	0xfc, 0x96, 0x03, 0xa1, 0x65, 0x91, 0xf9, 0x6c, 0x82, 0x1f, 0x23, 0x34, 0x10, 0xb9, 0xb9, 0x81,
+	0xf8, 0x04, 0xd6, 0x75, 0x8d, 0x8c, 0x27, 0xa6, 0x43, 0x8c, 0xe1, 0x54, 0xb9, 0x24, 0x53, 0x81,
+	0x9f, 0xaf, 0xfa, 0x17, 0x0e, 0x97, 0x42, 0x90, 0x97, 0x64, 0x8a, 0x9e, 0x41, 0x7e, 0x62, 0xe9,
+	0xa6, 0xa5, 0x3b, 0x53, 0xc6, 0xe1, 0xad, 0xc8, 0x4f, 0xd2, 0x67, 0x00, 0x3c, 0x83, 0x8a, 0x9f,
+	0xf1, 0xb0, 0x15, 0xc9, 0x70, 0xc6, 0x97, 0x7e, 0xec, 0x3b, 0xdf, 0xa6, 0x81, 0x93, 0xea, 0x6a,
+	0xae, 0x79, 0x59, 0xe6, 0xbf, 0xe1, 0x32, 0x79, 0xae, 0xfc, 0x21, 0x77, 0x63, 0x06, 0xfc, 0x1d,
+	0xd2, 0x63, 0x53, 0x23, 0x8c, 0x35, 0x7b, 0xf1, 0x91, 0x82, 0xef, 0xaf, 0xbf, 0x32, 0x35, 0x82,
+	0xa9, 0x83, 0xf8, 0x1f, 0x48, 0x7b, 0x27, 0xf4, 0x08, 0x1e, 0xb4, 0xb0, 0xd4, 0x90, 0x25, 0xa5,
+	0xd1, 0xef, 0x9f, 0x76, 0x5a, 0x0d, 0xb9, 0xd3, 0xeb, 0x0e, 0x94, 0x57, 0xbd, 0xb6, 0xa4, 0x34,
+	0x4e, 0x4f, 0x95, 0x1e, 0x56, 0xba, 0x3d, 0xf9, 0xa4, 0xd3, 0x3d, 0x2e, 0xaf, 0xa0, 0x1a, 0xec,
+	0x27, 0x82, 0x9b, 0xd2, 0x40, 0x56, 0xa4, 0xa3, 0xa3, 0x1e, 0x96, 0xcb, 0x9c, 0xf8, 0x03, 0x07,
+	0x95, 0xb8, 0x3c, 0x18, 0x63, 0x5e, 0x40, 0xce, 0x22, 0xb6, 0x3b, 0x72, 0x82, 0x1e, 0xdc, 0x4f,
+	0xcc, 0xdc, 0xf7, 0xa8, 0x63, 0x0a, 0xc7, 0x81, 0x5b, 0xe5, 0xbf, 0x90, 0xf5, 0x4d, 0xe8, 0x10,
+	0x8a, 0xa1, 0x96, 0xb0, 0x97, 0x18, 0x9d, 0x9d, 0x61, 0x10, 0xaa, 0x42, 0x86, 0x58, 0x96, 0x69,
+	0xb1, 0x29, 0x0e, 0x14, 0x2d, 0x79, 0x16, 0xec, 0x5f, 0x88, 0xcf, 0x20, 0x43, 0xcf, 0x08, 0x41,
+	0x7a, 0xe8, 0x75, 0xd8, 0x8b, 0x9b, 0xc1, 0xf4, 0xb3, 0x27, 0x70, 0x63, 0x62, 0xdb, 0x2a, 0x93,
+	0xfc, 0x02, 0x0e, 0x8e, 0xa2, 0x0c, 0x5b, 0x89, 0x2b, 0x4a, 0x64, 0xd6, 0xc6, 0x28, 0x35, 0x1f,
+	0xa7, 0xd4, 0xe2, 0x9f, 0x41, 0xf0, 0x3f, 0x2e, 0x1f, 0xe0, 0xe2, 0x23, 0xd8, 0xc2, 0xc4, 0x76,
+	0x4c, 0xeb, 0x36, 0xe0, 0x97, 0x20, 0x34, 0xa8, 0x6a, 0x2f, 0xc7, 0xde, 0x5c, 0x0b, 0xf8, 0x9b,
+	0x6b, 0x81, 0xf8, 0x18, 0x2a, 0x67, 0x86, 0x7a, 0xcb, 0x70, 0xe2, 0x4f, 0x79, 0x28, 0x86, 0x60,
+	0xbf, 0xaf, 0x10, 0x85, 0x66, 0x41, 0x6a, 0x6e, 0x16, 0x3c, 0x07, 0xb8, 0xde, 0xdc, 0x84, 0xf4,
+	0x52, 0x0d, 0x2f, 0xcc, 0x76, 0x37, 0xcf, 0xf5, 0x7a, 0x65, 0x13, 0x32, 0xcb, 0x5d, 0x67, 0x4b,
+	0x1b, 0x92, 0x3d, 0x5d, 0x74, 0x88, 0x65, 0xa8, 0xa3, 0x40, 0x17, 0xb3, 0xb4, 0xa8, 0xdd, 0x48,
+	0x51, 0x12, 0xc3, 0xc5, 0x14, 0x57, 0x22, 0x73, 0x77, 0x1e, 0x0b, 0x03, 0x89, 0xcc, 0xd1, 0x59,
+	0x1a, 0x1c, 0xe9, 0x9a, 0xe5, 0x53, 0xc7, 0x4b, 0x35, 0x7f, 0x8b, 0x35, 0xcb, 0x47, 0x37, 0x9c,
+	0x90, 0x64, 0x14, 0xe6, 0x24, 0xe3, 0x1e, 0x94, 0xfc, 0x4f, 0x4a, 0xa0, 0x1c, 0xe0, 0xef, 0x1a,
+	0xbe, 0xb5, 0xe5, 0x1b, 0x6f, 0x92, 0xa4, 0x18, 0xd9, 0x1d, 0xc3, 0x73, 0x75, 0xf5, 0xd6, 0x73,
+	0x15, 0xfd, 0x15, 0xb2, 0xde, 0xe2, 0xa8, 0x3a, 0xc2, 0xda, 0xf2, 0x3d, 0x4d, 0x73, 0x49, 0xc3,
+	0x41, 0x15, 0xc8, 0x9f, 0x5b, 0x44, 0xf5, 0x14, 0x48, 0x28, 0xd1, 0x67, 0x35, 0x3b, 0x8b, 0x5f,
+	0x70, 0x90, 0x65, 0x5d, 0x14, 0x61, 0x27, 0x34, 0xcc, 0x94, 0x81, 0xdc, 0x90, 0xcf, 0x06, 0xca,
+	0x59, 0x77, 0xd0, 0x97, 0x5a, 0x9d, 0xa3, 0x8e, 0xd4, 0x2e, 0xaf, 0xa0, 0x3b, 0xb0, 0x19, 0x83,
+	0xe9, 0xf5, 0xa5, 0x6e, 0x99, 0x4b, 0x08, 0xd0, 0xe9, 0x2a, 0x7d, 0xdc, 0x3b, 0xc6, 0xd2, 0x60,
+	0x50, 0xe6, 0xd1, 0x36, 0x6c, 0xc5, 0x60, 0x5a, 0xa7, 0xbd, 0x81, 0xd4, 0x2e, 0xa7, 0xc4, 0xf7,
+	0x1c, 0xe4, 0x83, 0xa2, 0xd1, 0x3e, 0x54, 0xc3, 0xd8, 0x3e, 0xee, 0xf4, 0x70, 0x47, 0xfe, 0xf7,
+	0x8d, 0x94, 0xee, 0x82, 0x10, 0x8b, 0x3a, 0xed, 0xfd, 0xb3, 0xcc, 0xa1, 0x5d, 0xb8, 0x13, 0x7b,
+	0xdb, 0xed, 0xe1, 0x57, 0x8d, 0xd3, 0x68, 0x42, 0x33, 0xc0, 0x49, 0xe7, 0xf8, 0xa4, 0x9c, 0x4a,
+	0xf4, 0x3f, 0xc3, 0xc7, 0x52, 0x57, 0x2e, 0xa7, 0xc5, 0xaf, 0x39, 0x28, 0xcd, 0x53, 0xd5, 0x53,
+	0x92, 0xb0, 0x8f, 0xf4, 0x2f, 0x59, 0xc2, 0xdd, 0xc6, 0x69, 0x7c, 0x47, 0x1f, 0xc2, 0xbd, 0x45,
+	0xe0, 0x3e, 0xee, 0xb5, 0xa4, 0x81, 0xd7, 0x1c, 0x0e, 0x3d, 0x80, 0xbd, 0x45, 0xd0, 0xc1, 0xcb,
+	0x4e, 0xbf, 0x2f, 0xb5, 0xcb, 0xfc, 0xe1, 0xf7, 0x59, 0x40, 0x21, 0x1a, 0x0d, 0x88, 0x75, 0xa5,
+	0x0f, 0x09, 0x6a, 0xc3, 0x46, 0x44, 0x57, 0xd0, 0x62, 0xcd, 0xad, 0x44, 0xe4, 0x03, 0x9d, 0x01,
+	0x8a, 0xa0, 0x6d, 0xb4, 0xb3, 0x58, 0x70, 0x2b, 0xbb, 0x4b, 0x64, 0x0d, 0x1d, 0x01, 0x8a, 0xea,
+	0x05, 0x0b, 0x9b, 0x28, 0x24, 0x31, 0xe9, 0x5d, 0x80, 0x90, 0xb4, 0x76, 0xa2, 0x5b, 0x6d, 0xa5,
+	0x95, 0x7b, 0x4b, 0x50, 0x2c, 0xe1, 0x36, 0x6c, 0x44, 0xf6, 0x41, 0xd6, 0xcd, 0xa4, 0xff, 0x31,
+	0x62, 0xd2, 0xb5, 0xe1, 0xee, 0xa2, 0xcd, 0x12, 0xd5, 0xa8, 0xc7, 0x2d, 0x56, 0xf0, 0xca, 0xc3,
+	0x5b, 0x20, 0xaf, 0x53, 0x8f, 0xa8, 0x28, 0x4b, 0x3d, 0x49, 0x5d, 0x63, 0x52, 0x3f, 0x02, 0x14,
+	0xd5, 0x57, 0xf6, 0x8b, 0x25, 0x0a, 0x6f, 0x4c, 0x9c, 0x36, 0x6c, 0x44, 0xa4, 0x97, 0x65, 0x93,
+	0x24, 0xc9, 0x31, 0x51, 0x4e, 0xe0, 0x0f, 0x31, 0x9a, 0x8b, 0x7c, 0xde, 0x25, 0xab, 0x71, 0x34,
+	0xd2, 0x79, 0x96, 0x4e, 0xd2, 0x27, 0xbf, 0x0e, 0x00, 0x8c, 0x38, 0x38, 0xa1, 0xd7, 0x11, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+const _ = grpc.SupportPackageIsVersion6
 
 // ApplicationServiceClient is the client API for ApplicationService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type ApplicationServiceClient interface {
 	CreateApplication(ctx context.Context, in *CreateApplicationRequest, opts ...grpc.CallOption) (*Application, error)
+	// CreateApplications creates batch of applications, result of every item is returned in order of request
+	CreateApplications(ctx context.Context, in *CreateApplicationsRequest, opts ...grpc.CallOption) (*CreateApplicationsResponse, error)
 	GetApplicationById(ctx context.Context, in *GetApplicationByIdRequest, opts ...grpc.CallOption) (*Application, error)
 	GetApplicationsByFilters(ctx context.Context, in *GetApplicationsByFiltersRequest, opts ...grpc.CallOption) (*GetApplicationsByFiltersResponse, error)
 	UpdateApplication(ctx context.Context, in *UpdateApplicationRequest, opts ...grpc.CallOption) (*Application, error)
+	// BulkUpdateApplicationsStatus sets status of every application matching filter
+	BulkUpdateApplicationsStatus(ctx context.Context, in *BulkUpdateApplicationsStatusRequest, opts ...grpc.CallOption) (*BulkUpdateApplicationsStatusResponse, error)
+	// DeleteApplication sets tombstone of application, it's hidden from search until it's restored
+	DeleteApplication(ctx context.Context, in *DeleteApplicationRequest, opts ...grpc.CallOption) (*Application, error)
+	RestoreApplication(ctx context.Context, in *RestoreApplicationRequest, opts ...grpc.CallOption) (*Application, error)
+	// AssignApplication sets operator handling application, UnassignApplication removes it
+	AssignApplication(ctx context.Context, in *AssignApplicationRequest, opts ...grpc.CallOption) (*Application, error)
+	UnassignApplication(ctx context.Context, in *UnassignApplicationRequest, opts ...grpc.CallOption) (*Application, error)
 }
 
 type applicationServiceClient struct {
-	cc *grpc.ClientConn
+	cc grpc.ClientConnInterface
 }
 
-func NewApplicationServiceClient(cc *grpc.ClientConn) ApplicationServiceClient {
+func NewApplicationServiceClient(cc grpc.ClientConnInterface) ApplicationServiceClient {
 	return &applicationServiceClient{cc}
 }
 
@@ -576,6 +1412,15 @@ func (c *applicationServiceClient) CreateApplication(ctx context.Context, in *Cr
 	return out, nil
 }
 
+func (c *applicationServiceClient) CreateApplications(ctx context.Context, in *CreateApplicationsRequest, opts ...grpc.CallOption) (*CreateApplicationsResponse, error) {
+	out := new(CreateApplicationsResponse)
+	err := c.cc.Invoke(ctx, "/api.ApplicationService/CreateApplications", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *applicationServiceClient) GetApplicationById(ctx context.Context, in *GetApplicationByIdRequest, opts ...grpc.CallOption) (*Application, error) {
 	out := new(Application)
 	err := c.cc.Invoke(ctx, "/api.ApplicationService/GetApplicationById", in, out, opts...)
@@ -603,12 +1448,67 @@ func (c *applicationServiceClient) UpdateApplication(ctx context.Context, in *Up
 	return out, nil
 }
 
+func (c *applicationServiceClient) BulkUpdateApplicationsStatus(ctx context.Context, in *BulkUpdateApplicationsStatusRequest, opts ...grpc.CallOption) (*BulkUpdateApplicationsStatusResponse, error) {
+	out := new(BulkUpdateApplicationsStatusResponse)
+	err := c.cc.Invoke(ctx, "/api.ApplicationService/BulkUpdateApplicationsStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationServiceClient) DeleteApplication(ctx context.Context, in *DeleteApplicationRequest, opts ...grpc.CallOption) (*Application, error) {
+	out := new(Application)
+	err := c.cc.Invoke(ctx, "/api.ApplicationService/DeleteApplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationServiceClient) RestoreApplication(ctx context.Context, in *RestoreApplicationRequest, opts ...grpc.CallOption) (*Application, error) {
+	out := new(Application)
+	err := c.cc.Invoke(ctx, "/api.ApplicationService/RestoreApplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationServiceClient) AssignApplication(ctx context.Context, in *AssignApplicationRequest, opts ...grpc.CallOption) (*Application, error) {
+	out := new(Application)
+	err := c.cc.Invoke(ctx, "/api.ApplicationService/AssignApplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationServiceClient) UnassignApplication(ctx context.Context, in *UnassignApplicationRequest, opts ...grpc.CallOption) (*Application, error) {
+	out := new(Application)
+	err := c.cc.Invoke(ctx, "/api.ApplicationService/UnassignApplication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ApplicationServiceServer is the server API for ApplicationService service.
 type ApplicationServiceServer interface {
 	CreateApplication(context.Context, *CreateApplicationRequest) (*Application, error)
+	// CreateApplications creates batch of applications, result of every item is returned in order of request
+	CreateApplications(context.Context, *CreateApplicationsRequest) (*CreateApplicationsResponse, error)
 	GetApplicationById(context.Context, *GetApplicationByIdRequest) (*Application, error)
 	GetApplicationsByFilters(context.Context, *GetApplicationsByFiltersRequest) (*GetApplicationsByFiltersResponse, error)
 	UpdateApplication(context.Context, *UpdateApplicationRequest) (*Application, error)
+	// BulkUpdateApplicationsStatus sets status of every application matching filter
+	BulkUpdateApplicationsStatus(context.Context, *BulkUpdateApplicationsStatusRequest) (*BulkUpdateApplicationsStatusResponse, error)
+	// DeleteApplication sets tombstone of application, it's hidden from search until it's restored
+	DeleteApplication(context.Context, *DeleteApplicationRequest) (*Application, error)
+	RestoreApplication(context.Context, *RestoreApplicationRequest) (*Application, error)
+	// AssignApplication sets operator handling application, UnassignApplication removes it
+	AssignApplication(context.Context, *AssignApplicationRequest) (*Application, error)
+	UnassignApplication(context.Context, *UnassignApplicationRequest) (*Application, error)
 }
 
 // UnimplementedApplicationServiceServer can be embedded to have forward compatible implementations.
@@ -618,6 +1518,9 @@ type UnimplementedApplicationServiceServer struct {
 func (*UnimplementedApplicationServiceServer) CreateApplication(ctx context.Context, req *CreateApplicationRequest) (*Application, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateApplication not implemented")
 }
+func (*UnimplementedApplicationServiceServer) CreateApplications(ctx context.Context, req *CreateApplicationsRequest) (*CreateApplicationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateApplications not implemented")
+}
 func (*UnimplementedApplicationServiceServer) GetApplicationById(ctx context.Context, req *GetApplicationByIdRequest) (*Application, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetApplicationById not implemented")
 }
@@ -627,6 +1530,21 @@ func (*UnimplementedApplicationServiceServer) GetApplicationsByFilters(ctx conte
 func (*UnimplementedApplicationServiceServer) UpdateApplication(ctx context.Context, req *UpdateApplicationRequest) (*Application, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateApplication not implemented")
 }
+func (*UnimplementedApplicationServiceServer) BulkUpdateApplicationsStatus(ctx context.Context, req *BulkUpdateApplicationsStatusRequest) (*BulkUpdateApplicationsStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkUpdateApplicationsStatus not implemented")
+}
+func (*UnimplementedApplicationServiceServer) DeleteApplication(ctx context.Context, req *DeleteApplicationRequest) (*Application, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteApplication not implemented")
+}
+func (*UnimplementedApplicationServiceServer) RestoreApplication(ctx context.Context, req *RestoreApplicationRequest) (*Application, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreApplication not implemented")
+}
+func (*UnimplementedApplicationServiceServer) AssignApplication(ctx context.Context, req *AssignApplicationRequest) (*Application, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignApplication not implemented")
+}
+func (*UnimplementedApplicationServiceServer) UnassignApplication(ctx context.Context, req *UnassignApplicationRequest) (*Application, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnassignApplication not implemented")
+}
 
 func RegisterApplicationServiceServer(s *grpc.Server, srv ApplicationServiceServer) {
 	s.RegisterService(&_ApplicationService_serviceDesc, srv)
@@ -650,6 +1568,24 @@ func _ApplicationService_CreateApplication_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApplicationService_CreateApplications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApplicationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).CreateApplications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ApplicationService/CreateApplications",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).CreateApplications(ctx, req.(*CreateApplicationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApplicationService_GetApplicationById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetApplicationByIdRequest)
 	if err := dec(in); err != nil {
@@ -704,6 +1640,96 @@ func _ApplicationService_UpdateApplication_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApplicationService_BulkUpdateApplicationsStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkUpdateApplicationsStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).BulkUpdateApplicationsStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ApplicationService/BulkUpdateApplicationsStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).BulkUpdateApplicationsStatus(ctx, req.(*BulkUpdateApplicationsStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationService_DeleteApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteApplicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).DeleteApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ApplicationService/DeleteApplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).DeleteApplication(ctx, req.(*DeleteApplicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationService_RestoreApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreApplicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).RestoreApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ApplicationService/RestoreApplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).RestoreApplication(ctx, req.(*RestoreApplicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationService_AssignApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignApplicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).AssignApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ApplicationService/AssignApplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).AssignApplication(ctx, req.(*AssignApplicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationService_UnassignApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnassignApplicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).UnassignApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ApplicationService/UnassignApplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).UnassignApplication(ctx, req.(*UnassignApplicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ApplicationService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "api.ApplicationService",
 	HandlerType: (*ApplicationServiceServer)(nil),
@@ -712,6 +1738,10 @@ var _ApplicationService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateApplication",
 			Handler:    _ApplicationService_CreateApplication_Handler,
 		},
+		{
+			MethodName: "CreateApplications",
+			Handler:    _ApplicationService_CreateApplications_Handler,
+		},
 		{
 			MethodName: "GetApplicationById",
 			Handler:    _ApplicationService_GetApplicationById_Handler,
@@ -724,6 +1754,26 @@ var _ApplicationService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateApplication",
 			Handler:    _ApplicationService_UpdateApplication_Handler,
 		},
+		{
+			MethodName: "BulkUpdateApplicationsStatus",
+			Handler:    _ApplicationService_BulkUpdateApplicationsStatus_Handler,
+		},
+		{
+			MethodName: "DeleteApplication",
+			Handler:    _ApplicationService_DeleteApplication_Handler,
+		},
+		{
+			MethodName: "RestoreApplication",
+			Handler:    _ApplicationService_RestoreApplication_Handler,
+		},
+		{
+			MethodName: "AssignApplication",
+			Handler:    _ApplicationService_AssignApplication_Handler,
+		},
+		{
+			MethodName: "UnassignApplication",
+			Handler:    _ApplicationService_UnassignApplication_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "application.proto",
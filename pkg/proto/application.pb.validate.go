@@ -138,6 +138,35 @@ func (m *GetApplicationsByFiltersRequest) Validate() error {
 
 	// no validation rules for UserId
 
+	// no validation rules for IncludeDeleted
+
+	// no validation rules for Reason
+
+	// no validation rules for AssigneeId
+
+	// no validation rules for Unassigned
+
+	// no validation rules for Overdue
+
+	if v, ok := interface{}(m.GetDueBefore()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetApplicationsByFiltersRequestValidationError{
+				field:  "DueBefore",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if val := m.GetPageSize(); val < 0 || val > 1000 {
+		return GetApplicationsByFiltersRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [0, 1000]",
+		}
+	}
+
+	// no validation rules for PageToken
+
 	return nil
 }
 
@@ -305,6 +334,8 @@ func (m *GetApplicationsByFiltersResponse) Validate() error {
 
 	}
 
+	// no validation rules for NextPageToken
+
 	return nil
 }
 
@@ -382,6 +413,27 @@ func (m *UpdateApplicationRequest) Validate() error {
 		}
 	}
 
+	// no validation rules for ExpectedStatus
+
+	if v, ok := interface{}(m.GetExpectedVersion()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateApplicationRequestValidationError{
+				field:  "ExpectedVersion",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Reason
+
+	if utf8.RuneCountInString(m.GetComment()) > 1000 {
+		return UpdateApplicationRequestValidationError{
+			field:  "Comment",
+			reason: "value length must be at most 1000 runes",
+		}
+	}
+
 	return nil
 }
 
@@ -445,6 +497,185 @@ var _UpdateApplicationRequest_Status_NotInLookup = map[Application_Status]struct
 	0: {},
 }
 
+// Validate checks the field values on BulkUpdateApplicationsStatusRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, an error is returned.
+func (m *BulkUpdateApplicationsStatusRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetFilter() == nil {
+		return BulkUpdateApplicationsStatusRequestValidationError{
+			field:  "Filter",
+			reason: "value is required",
+		}
+	}
+
+	if v, ok := interface{}(m.GetFilter()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BulkUpdateApplicationsStatusRequestValidationError{
+				field:  "Filter",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if _, ok := _BulkUpdateApplicationsStatusRequest_Status_NotInLookup[m.GetStatus()]; ok {
+		return BulkUpdateApplicationsStatusRequestValidationError{
+			field:  "Status",
+			reason: "value must not be in list [0]",
+		}
+	}
+
+	// no validation rules for DryRun
+
+	// no validation rules for Reason
+
+	if utf8.RuneCountInString(m.GetComment()) > 1000 {
+		return BulkUpdateApplicationsStatusRequestValidationError{
+			field:  "Comment",
+			reason: "value length must be at most 1000 runes",
+		}
+	}
+
+	return nil
+}
+
+// BulkUpdateApplicationsStatusRequestValidationError is the validation error
+// returned by BulkUpdateApplicationsStatusRequest.Validate if the designated
+// constraints aren't met.
+type BulkUpdateApplicationsStatusRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BulkUpdateApplicationsStatusRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BulkUpdateApplicationsStatusRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BulkUpdateApplicationsStatusRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BulkUpdateApplicationsStatusRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BulkUpdateApplicationsStatusRequestValidationError) ErrorName() string {
+	return "BulkUpdateApplicationsStatusRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BulkUpdateApplicationsStatusRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBulkUpdateApplicationsStatusRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BulkUpdateApplicationsStatusRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BulkUpdateApplicationsStatusRequestValidationError{}
+
+var _BulkUpdateApplicationsStatusRequest_Status_NotInLookup = map[Application_Status]struct{}{
+	0: {},
+}
+
+// Validate checks the field values on BulkUpdateApplicationsStatusResponse
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, an error is returned.
+func (m *BulkUpdateApplicationsStatusResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for MatchedCount
+
+	// no validation rules for UpdatedCount
+
+	return nil
+}
+
+// BulkUpdateApplicationsStatusResponseValidationError is the validation error
+// returned by BulkUpdateApplicationsStatusResponse.Validate if the designated
+// constraints aren't met.
+type BulkUpdateApplicationsStatusResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BulkUpdateApplicationsStatusResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BulkUpdateApplicationsStatusResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BulkUpdateApplicationsStatusResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BulkUpdateApplicationsStatusResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BulkUpdateApplicationsStatusResponseValidationError) ErrorName() string {
+	return "BulkUpdateApplicationsStatusResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BulkUpdateApplicationsStatusResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBulkUpdateApplicationsStatusResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BulkUpdateApplicationsStatusResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BulkUpdateApplicationsStatusResponseValidationError{}
+
 // Validate checks the field values on CreateApplicationRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, an error is returned.
@@ -455,6 +686,15 @@ func (m *CreateApplicationRequest) Validate() error {
 
 	// no validation rules for UserId
 
+	if utf8.RuneCountInString(m.GetIdempotencyKey()) > 255 {
+		return CreateApplicationRequestValidationError{
+			field:  "IdempotencyKey",
+			reason: "value length must be at most 255 runes",
+		}
+	}
+
+	// no validation rules for Priority
+
 	return nil
 }
 
@@ -514,22 +754,44 @@ var _ interface {
 	ErrorName() string
 } = CreateApplicationRequestValidationError{}
 
-// Validate checks the field values on GetApplicationByIdRequest with the rules
+// Validate checks the field values on CreateApplicationsRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, an error is returned.
-func (m *GetApplicationByIdRequest) Validate() error {
+func (m *CreateApplicationsRequest) Validate() error {
 	if m == nil {
 		return nil
 	}
 
-	// no validation rules for Id
+	if l := len(m.GetApplications()); l < 1 || l > 1000 {
+		return CreateApplicationsRequestValidationError{
+			field:  "Applications",
+			reason: "value must contain between 1 and 1000 items, inclusive",
+		}
+	}
+
+	for idx, item := range m.GetApplications() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CreateApplicationsRequestValidationError{
+					field:  fmt.Sprintf("Applications[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Mode
 
 	return nil
 }
 
-// GetApplicationByIdRequestValidationError is the validation error returned by
-// GetApplicationByIdRequest.Validate if the designated constraints aren't met.
-type GetApplicationByIdRequestValidationError struct {
+// CreateApplicationsRequestValidationError is the validation error returned by
+// CreateApplicationsRequest.Validate if the designated constraints aren't met.
+type CreateApplicationsRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -537,24 +799,24 @@ type GetApplicationByIdRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetApplicationByIdRequestValidationError) Field() string { return e.field }
+func (e CreateApplicationsRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetApplicationByIdRequestValidationError) Reason() string { return e.reason }
+func (e CreateApplicationsRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetApplicationByIdRequestValidationError) Cause() error { return e.cause }
+func (e CreateApplicationsRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetApplicationByIdRequestValidationError) Key() bool { return e.key }
+func (e CreateApplicationsRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetApplicationByIdRequestValidationError) ErrorName() string {
-	return "GetApplicationByIdRequestValidationError"
+func (e CreateApplicationsRequestValidationError) ErrorName() string {
+	return "CreateApplicationsRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetApplicationByIdRequestValidationError) Error() string {
+func (e CreateApplicationsRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -566,14 +828,14 @@ func (e GetApplicationByIdRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetApplicationByIdRequest.%s: %s%s",
+		"invalid %sCreateApplicationsRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetApplicationByIdRequestValidationError{}
+var _ error = CreateApplicationsRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -581,60 +843,37 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetApplicationByIdRequestValidationError{}
+} = CreateApplicationsRequestValidationError{}
 
-// Validate checks the field values on Application with the rules defined in
-// the proto definition for this message. If any rules are violated, an error
-// is returned.
-func (m *Application) Validate() error {
+// Validate checks the field values on CreateApplicationsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *CreateApplicationsResponse) Validate() error {
 	if m == nil {
 		return nil
 	}
 
-	// no validation rules for Id
-
-	if _, ok := _Application_Status_NotInLookup[m.GetStatus()]; ok {
-		return ApplicationValidationError{
-			field:  "Status",
-			reason: "value must not be in list [0]",
-		}
-	}
-
-	// no validation rules for UserId
-
-	if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return ApplicationValidationError{
-				field:  "CreatedAt",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	for idx, item := range m.GetResults() {
+		_, _ = idx, item
 
-	if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return ApplicationValidationError{
-				field:  "UpdatedAt",
-				reason: "embedded message failed validation",
-				cause:  err,
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CreateApplicationsResponseValidationError{
+					field:  fmt.Sprintf("Results[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
 			}
 		}
-	}
 
-	if _, ok := _Application_ExternalStatus_NotInLookup[m.GetExternalStatus()]; ok {
-		return ApplicationValidationError{
-			field:  "ExternalStatus",
-			reason: "value must not be in list [0]",
-		}
 	}
 
 	return nil
 }
 
-// ApplicationValidationError is the validation error returned by
-// Application.Validate if the designated constraints aren't met.
-type ApplicationValidationError struct {
+// CreateApplicationsResponseValidationError is the validation error returned
+// by CreateApplicationsResponse.Validate if the designated constraints aren't met.
+type CreateApplicationsResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -642,22 +881,24 @@ type ApplicationValidationError struct {
 }
 
 // Field function returns field value.
-func (e ApplicationValidationError) Field() string { return e.field }
+func (e CreateApplicationsResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ApplicationValidationError) Reason() string { return e.reason }
+func (e CreateApplicationsResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ApplicationValidationError) Cause() error { return e.cause }
+func (e CreateApplicationsResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ApplicationValidationError) Key() bool { return e.key }
+func (e CreateApplicationsResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ApplicationValidationError) ErrorName() string { return "ApplicationValidationError" }
+func (e CreateApplicationsResponseValidationError) ErrorName() string {
+	return "CreateApplicationsResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e ApplicationValidationError) Error() string {
+func (e CreateApplicationsResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -669,14 +910,14 @@ func (e ApplicationValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sApplication.%s: %s%s",
+		"invalid %sCreateApplicationsResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ApplicationValidationError{}
+var _ error = CreateApplicationsResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -684,12 +925,652 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ApplicationValidationError{}
+} = CreateApplicationsResponseValidationError{}
 
-var _Application_Status_NotInLookup = map[Application_Status]struct{}{
-	0: {},
+// Validate checks the field values on Error with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *Error) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Code
+
+	// no validation rules for Message
+
+	return nil
+}
+
+// ErrorValidationError is the validation error returned by Error.Validate if
+// the designated constraints aren't met.
+type ErrorValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ErrorValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ErrorValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ErrorValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ErrorValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ErrorValidationError) ErrorName() string { return "ErrorValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ErrorValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sError.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ErrorValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ErrorValidationError{}
+
+// Validate checks the field values on GetApplicationByIdRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *GetApplicationByIdRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Id
+
+	// no validation rules for IncludeDeleted
+
+	return nil
+}
+
+// GetApplicationByIdRequestValidationError is the validation error returned by
+// GetApplicationByIdRequest.Validate if the designated constraints aren't met.
+type GetApplicationByIdRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetApplicationByIdRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetApplicationByIdRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetApplicationByIdRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetApplicationByIdRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetApplicationByIdRequestValidationError) ErrorName() string {
+	return "GetApplicationByIdRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetApplicationByIdRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetApplicationByIdRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetApplicationByIdRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetApplicationByIdRequestValidationError{}
+
+// Validate checks the field values on DeleteApplicationRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *DeleteApplicationRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Id
+
+	return nil
+}
+
+// DeleteApplicationRequestValidationError is the validation error returned by
+// DeleteApplicationRequest.Validate if the designated constraints aren't met.
+type DeleteApplicationRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteApplicationRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteApplicationRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteApplicationRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteApplicationRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteApplicationRequestValidationError) ErrorName() string {
+	return "DeleteApplicationRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteApplicationRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteApplicationRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteApplicationRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteApplicationRequestValidationError{}
+
+// Validate checks the field values on RestoreApplicationRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *RestoreApplicationRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Id
+
+	return nil
+}
+
+// RestoreApplicationRequestValidationError is the validation error returned by
+// RestoreApplicationRequest.Validate if the designated constraints aren't met.
+type RestoreApplicationRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RestoreApplicationRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RestoreApplicationRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RestoreApplicationRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RestoreApplicationRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RestoreApplicationRequestValidationError) ErrorName() string {
+	return "RestoreApplicationRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RestoreApplicationRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRestoreApplicationRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RestoreApplicationRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RestoreApplicationRequestValidationError{}
+
+// Validate checks the field values on AssignApplicationRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *AssignApplicationRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Id
+
+	// no validation rules for AssigneeId
+
+	return nil
+}
+
+// AssignApplicationRequestValidationError is the validation error returned by
+// AssignApplicationRequest.Validate if the designated constraints aren't met.
+type AssignApplicationRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AssignApplicationRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AssignApplicationRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AssignApplicationRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AssignApplicationRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AssignApplicationRequestValidationError) ErrorName() string {
+	return "AssignApplicationRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e AssignApplicationRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAssignApplicationRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AssignApplicationRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AssignApplicationRequestValidationError{}
+
+// Validate checks the field values on UnassignApplicationRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *UnassignApplicationRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Id
+
+	return nil
+}
+
+// UnassignApplicationRequestValidationError is the validation error returned
+// by UnassignApplicationRequest.Validate if the designated constraints aren't met.
+type UnassignApplicationRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UnassignApplicationRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UnassignApplicationRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UnassignApplicationRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UnassignApplicationRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UnassignApplicationRequestValidationError) ErrorName() string {
+	return "UnassignApplicationRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UnassignApplicationRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUnassignApplicationRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UnassignApplicationRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UnassignApplicationRequestValidationError{}
+
+// Validate checks the field values on Application with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *Application) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Id
+
+	if _, ok := _Application_Status_NotInLookup[m.GetStatus()]; ok {
+		return ApplicationValidationError{
+			field:  "Status",
+			reason: "value must not be in list [0]",
+		}
+	}
+
+	// no validation rules for UserId
+
+	if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ApplicationValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ApplicationValidationError{
+				field:  "UpdatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if _, ok := _Application_ExternalStatus_NotInLookup[m.GetExternalStatus()]; ok {
+		return ApplicationValidationError{
+			field:  "ExternalStatus",
+			reason: "value must not be in list [0]",
+		}
+	}
+
+	// no validation rules for Version
+
+	if v, ok := interface{}(m.GetDeletedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ApplicationValidationError{
+				field:  "DeletedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Reason
+
+	// no validation rules for ReasonComment
+
+	// no validation rules for AssigneeId
+
+	// no validation rules for Priority
+
+	if v, ok := interface{}(m.GetDueAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ApplicationValidationError{
+				field:  "DueAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Breached
+
+	return nil
+}
+
+// ApplicationValidationError is the validation error returned by
+// Application.Validate if the designated constraints aren't met.
+type ApplicationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ApplicationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ApplicationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ApplicationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ApplicationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ApplicationValidationError) ErrorName() string { return "ApplicationValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ApplicationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sApplication.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ApplicationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ApplicationValidationError{}
+
+var _Application_Status_NotInLookup = map[Application_Status]struct{}{
+	0: {},
 }
 
 var _Application_ExternalStatus_NotInLookup = map[Application_ExternalStatus]struct{}{
 	0: {},
 }
+
+// Validate checks the field values on CreateApplicationsResponse_Result with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, an error is returned.
+func (m *CreateApplicationsResponse_Result) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if v, ok := interface{}(m.GetApplication()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateApplicationsResponse_ResultValidationError{
+				field:  "Application",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetError()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateApplicationsResponse_ResultValidationError{
+				field:  "Error",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// CreateApplicationsResponse_ResultValidationError is the validation error
+// returned by CreateApplicationsResponse_Result.Validate if the designated
+// constraints aren't met.
+type CreateApplicationsResponse_ResultValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateApplicationsResponse_ResultValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateApplicationsResponse_ResultValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateApplicationsResponse_ResultValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateApplicationsResponse_ResultValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateApplicationsResponse_ResultValidationError) ErrorName() string {
+	return "CreateApplicationsResponse_ResultValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateApplicationsResponse_ResultValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateApplicationsResponse_Result.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateApplicationsResponse_ResultValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateApplicationsResponse_ResultValidationError{}
@@ -0,0 +1,1130 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: webhook.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type WebhookDelivery_State int32
+
+const (
+	WebhookDelivery_WEBHOOK_DELIVERY_STATE_UNSPECIFIED WebhookDelivery_State = 0
+	WebhookDelivery_WEBHOOK_DELIVERY_STATE_PENDING     WebhookDelivery_State = 1
+	WebhookDelivery_WEBHOOK_DELIVERY_STATE_SUCCEEDED   WebhookDelivery_State = 2
+	// delivery has failed all attempts
+	WebhookDelivery_WEBHOOK_DELIVERY_STATE_DEAD WebhookDelivery_State = 3
+)
+
+var WebhookDelivery_State_name = map[int32]string{
+	0: "WEBHOOK_DELIVERY_STATE_UNSPECIFIED",
+	1: "WEBHOOK_DELIVERY_STATE_PENDING",
+	2: "WEBHOOK_DELIVERY_STATE_SUCCEEDED",
+	3: "WEBHOOK_DELIVERY_STATE_DEAD",
+}
+
+var WebhookDelivery_State_value = map[string]int32{
+	"WEBHOOK_DELIVERY_STATE_UNSPECIFIED": 0,
+	"WEBHOOK_DELIVERY_STATE_PENDING":     1,
+	"WEBHOOK_DELIVERY_STATE_SUCCEEDED":   2,
+	"WEBHOOK_DELIVERY_STATE_DEAD":        3,
+}
+
+func (x WebhookDelivery_State) String() string {
+	return proto.EnumName(WebhookDelivery_State_name, int32(x))
+}
+
+func (WebhookDelivery_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{11, 0}
+}
+
+type CreateWebhookSubscriptionRequest struct {
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// secret signs payloads with HMAC-SHA256, it's never returned back
+	Secret string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	// event types of subscription, empty means all events
+	EventTypes []string `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// optional filters, subscription receives events of applications matching all of them
+	UserId               string             `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status               Application_Status `protobuf:"varint,5,opt,name=status,proto3,enum=api.Application_Status" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *CreateWebhookSubscriptionRequest) Reset()         { *m = CreateWebhookSubscriptionRequest{} }
+func (m *CreateWebhookSubscriptionRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateWebhookSubscriptionRequest) ProtoMessage()    {}
+func (*CreateWebhookSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{0}
+}
+
+func (m *CreateWebhookSubscriptionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWebhookSubscriptionRequest.Unmarshal(m, b)
+}
+func (m *CreateWebhookSubscriptionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWebhookSubscriptionRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateWebhookSubscriptionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWebhookSubscriptionRequest.Merge(m, src)
+}
+func (m *CreateWebhookSubscriptionRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateWebhookSubscriptionRequest.Size(m)
+}
+func (m *CreateWebhookSubscriptionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWebhookSubscriptionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateWebhookSubscriptionRequest proto.InternalMessageInfo
+
+func (m *CreateWebhookSubscriptionRequest) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *CreateWebhookSubscriptionRequest) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *CreateWebhookSubscriptionRequest) GetEventTypes() []string {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
+func (m *CreateWebhookSubscriptionRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *CreateWebhookSubscriptionRequest) GetStatus() Application_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Application_APPLICATION_STATUS_UNSPECIFIED
+}
+
+type GetWebhookSubscriptionRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWebhookSubscriptionRequest) Reset()         { *m = GetWebhookSubscriptionRequest{} }
+func (m *GetWebhookSubscriptionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetWebhookSubscriptionRequest) ProtoMessage()    {}
+func (*GetWebhookSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{1}
+}
+
+func (m *GetWebhookSubscriptionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWebhookSubscriptionRequest.Unmarshal(m, b)
+}
+func (m *GetWebhookSubscriptionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWebhookSubscriptionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetWebhookSubscriptionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWebhookSubscriptionRequest.Merge(m, src)
+}
+func (m *GetWebhookSubscriptionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetWebhookSubscriptionRequest.Size(m)
+}
+func (m *GetWebhookSubscriptionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWebhookSubscriptionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWebhookSubscriptionRequest proto.InternalMessageInfo
+
+func (m *GetWebhookSubscriptionRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ListWebhookSubscriptionsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhookSubscriptionsRequest) Reset()         { *m = ListWebhookSubscriptionsRequest{} }
+func (m *ListWebhookSubscriptionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWebhookSubscriptionsRequest) ProtoMessage()    {}
+func (*ListWebhookSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{2}
+}
+
+func (m *ListWebhookSubscriptionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhookSubscriptionsRequest.Unmarshal(m, b)
+}
+func (m *ListWebhookSubscriptionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhookSubscriptionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWebhookSubscriptionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhookSubscriptionsRequest.Merge(m, src)
+}
+func (m *ListWebhookSubscriptionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWebhookSubscriptionsRequest.Size(m)
+}
+func (m *ListWebhookSubscriptionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhookSubscriptionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhookSubscriptionsRequest proto.InternalMessageInfo
+
+type ListWebhookSubscriptionsResponse struct {
+	Subscriptions        []*WebhookSubscription `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ListWebhookSubscriptionsResponse) Reset()         { *m = ListWebhookSubscriptionsResponse{} }
+func (m *ListWebhookSubscriptionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListWebhookSubscriptionsResponse) ProtoMessage()    {}
+func (*ListWebhookSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{3}
+}
+
+func (m *ListWebhookSubscriptionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhookSubscriptionsResponse.Unmarshal(m, b)
+}
+func (m *ListWebhookSubscriptionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhookSubscriptionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListWebhookSubscriptionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhookSubscriptionsResponse.Merge(m, src)
+}
+func (m *ListWebhookSubscriptionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListWebhookSubscriptionsResponse.Size(m)
+}
+func (m *ListWebhookSubscriptionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhookSubscriptionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhookSubscriptionsResponse proto.InternalMessageInfo
+
+func (m *ListWebhookSubscriptionsResponse) GetSubscriptions() []*WebhookSubscription {
+	if m != nil {
+		return m.Subscriptions
+	}
+	return nil
+}
+
+type UpdateWebhookSubscriptionRequest struct {
+	Id  string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// empty secret keeps current one
+	Secret               string             `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	EventTypes           []string           `protobuf:"bytes,4,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	UserId               string             `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status               Application_Status `protobuf:"varint,6,opt,name=status,proto3,enum=api.Application_Status" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *UpdateWebhookSubscriptionRequest) Reset()         { *m = UpdateWebhookSubscriptionRequest{} }
+func (m *UpdateWebhookSubscriptionRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateWebhookSubscriptionRequest) ProtoMessage()    {}
+func (*UpdateWebhookSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{4}
+}
+
+func (m *UpdateWebhookSubscriptionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateWebhookSubscriptionRequest.Unmarshal(m, b)
+}
+func (m *UpdateWebhookSubscriptionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateWebhookSubscriptionRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateWebhookSubscriptionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateWebhookSubscriptionRequest.Merge(m, src)
+}
+func (m *UpdateWebhookSubscriptionRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateWebhookSubscriptionRequest.Size(m)
+}
+func (m *UpdateWebhookSubscriptionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateWebhookSubscriptionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateWebhookSubscriptionRequest proto.InternalMessageInfo
+
+func (m *UpdateWebhookSubscriptionRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UpdateWebhookSubscriptionRequest) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *UpdateWebhookSubscriptionRequest) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *UpdateWebhookSubscriptionRequest) GetEventTypes() []string {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
+func (m *UpdateWebhookSubscriptionRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *UpdateWebhookSubscriptionRequest) GetStatus() Application_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Application_APPLICATION_STATUS_UNSPECIFIED
+}
+
+type DeleteWebhookSubscriptionRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWebhookSubscriptionRequest) Reset()         { *m = DeleteWebhookSubscriptionRequest{} }
+func (m *DeleteWebhookSubscriptionRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteWebhookSubscriptionRequest) ProtoMessage()    {}
+func (*DeleteWebhookSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{5}
+}
+
+func (m *DeleteWebhookSubscriptionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWebhookSubscriptionRequest.Unmarshal(m, b)
+}
+func (m *DeleteWebhookSubscriptionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWebhookSubscriptionRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteWebhookSubscriptionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWebhookSubscriptionRequest.Merge(m, src)
+}
+func (m *DeleteWebhookSubscriptionRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteWebhookSubscriptionRequest.Size(m)
+}
+func (m *DeleteWebhookSubscriptionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWebhookSubscriptionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWebhookSubscriptionRequest proto.InternalMessageInfo
+
+func (m *DeleteWebhookSubscriptionRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteWebhookSubscriptionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWebhookSubscriptionResponse) Reset()         { *m = DeleteWebhookSubscriptionResponse{} }
+func (m *DeleteWebhookSubscriptionResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteWebhookSubscriptionResponse) ProtoMessage()    {}
+func (*DeleteWebhookSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{6}
+}
+
+func (m *DeleteWebhookSubscriptionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWebhookSubscriptionResponse.Unmarshal(m, b)
+}
+func (m *DeleteWebhookSubscriptionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWebhookSubscriptionResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteWebhookSubscriptionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWebhookSubscriptionResponse.Merge(m, src)
+}
+func (m *DeleteWebhookSubscriptionResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteWebhookSubscriptionResponse.Size(m)
+}
+func (m *DeleteWebhookSubscriptionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWebhookSubscriptionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWebhookSubscriptionResponse proto.InternalMessageInfo
+
+type ListWebhookDeliveriesRequest struct {
+	SubscriptionId       string   `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhookDeliveriesRequest) Reset()         { *m = ListWebhookDeliveriesRequest{} }
+func (m *ListWebhookDeliveriesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWebhookDeliveriesRequest) ProtoMessage()    {}
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{7}
+}
+
+func (m *ListWebhookDeliveriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhookDeliveriesRequest.Unmarshal(m, b)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhookDeliveriesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhookDeliveriesRequest.Merge(m, src)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWebhookDeliveriesRequest.Size(m)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhookDeliveriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhookDeliveriesRequest proto.InternalMessageInfo
+
+func (m *ListWebhookDeliveriesRequest) GetSubscriptionId() string {
+	if m != nil {
+		return m.SubscriptionId
+	}
+	return ""
+}
+
+type ListWebhookDeliveriesResponse struct {
+	Deliveries           []*WebhookDelivery `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListWebhookDeliveriesResponse) Reset()         { *m = ListWebhookDeliveriesResponse{} }
+func (m *ListWebhookDeliveriesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListWebhookDeliveriesResponse) ProtoMessage()    {}
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{8}
+}
+
+func (m *ListWebhookDeliveriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhookDeliveriesResponse.Unmarshal(m, b)
+}
+func (m *ListWebhookDeliveriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhookDeliveriesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListWebhookDeliveriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhookDeliveriesResponse.Merge(m, src)
+}
+func (m *ListWebhookDeliveriesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListWebhookDeliveriesResponse.Size(m)
+}
+func (m *ListWebhookDeliveriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhookDeliveriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhookDeliveriesResponse proto.InternalMessageInfo
+
+func (m *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if m != nil {
+		return m.Deliveries
+	}
+	return nil
+}
+
+type RedeliverWebhookDeliveryRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RedeliverWebhookDeliveryRequest) Reset()         { *m = RedeliverWebhookDeliveryRequest{} }
+func (m *RedeliverWebhookDeliveryRequest) String() string { return proto.CompactTextString(m) }
+func (*RedeliverWebhookDeliveryRequest) ProtoMessage()    {}
+func (*RedeliverWebhookDeliveryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{9}
+}
+
+func (m *RedeliverWebhookDeliveryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RedeliverWebhookDeliveryRequest.Unmarshal(m, b)
+}
+func (m *RedeliverWebhookDeliveryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RedeliverWebhookDeliveryRequest.Marshal(b, m, deterministic)
+}
+func (m *RedeliverWebhookDeliveryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RedeliverWebhookDeliveryRequest.Merge(m, src)
+}
+func (m *RedeliverWebhookDeliveryRequest) XXX_Size() int {
+	return xxx_messageInfo_RedeliverWebhookDeliveryRequest.Size(m)
+}
+func (m *RedeliverWebhookDeliveryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RedeliverWebhookDeliveryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RedeliverWebhookDeliveryRequest proto.InternalMessageInfo
+
+func (m *RedeliverWebhookDeliveryRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type WebhookSubscription struct {
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url                  string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes           []string               `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	UserId               string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status               Application_Status     `protobuf:"varint,5,opt,name=status,proto3,enum=api.Application_Status" json:"status,omitempty"`
+	CreatedAt            *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *WebhookSubscription) Reset()         { *m = WebhookSubscription{} }
+func (m *WebhookSubscription) String() string { return proto.CompactTextString(m) }
+func (*WebhookSubscription) ProtoMessage()    {}
+func (*WebhookSubscription) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{10}
+}
+
+func (m *WebhookSubscription) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookSubscription.Unmarshal(m, b)
+}
+func (m *WebhookSubscription) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookSubscription.Marshal(b, m, deterministic)
+}
+func (m *WebhookSubscription) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookSubscription.Merge(m, src)
+}
+func (m *WebhookSubscription) XXX_Size() int {
+	return xxx_messageInfo_WebhookSubscription.Size(m)
+}
+func (m *WebhookSubscription) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookSubscription.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookSubscription proto.InternalMessageInfo
+
+func (m *WebhookSubscription) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *WebhookSubscription) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *WebhookSubscription) GetEventTypes() []string {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
+func (m *WebhookSubscription) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *WebhookSubscription) GetStatus() Application_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Application_APPLICATION_STATUS_UNSPECIFIED
+}
+
+func (m *WebhookSubscription) GetCreatedAt() *timestamppb.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *WebhookSubscription) GetUpdatedAt() *timestamppb.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type WebhookDelivery struct {
+	Id                   string                     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SubscriptionId       string                     `protobuf:"bytes,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	EventId              string                     `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType            string                     `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	State                WebhookDelivery_State      `protobuf:"varint,5,opt,name=state,proto3,enum=api.WebhookDelivery_State" json:"state,omitempty"`
+	Attempts             []*WebhookDelivery_Attempt `protobuf:"bytes,6,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	NextAttemptAt        *timestamppb.Timestamp     `protobuf:"bytes,7,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	CreatedAt            *timestamppb.Timestamp     `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            *timestamppb.Timestamp     `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *WebhookDelivery) Reset()         { *m = WebhookDelivery{} }
+func (m *WebhookDelivery) String() string { return proto.CompactTextString(m) }
+func (*WebhookDelivery) ProtoMessage()    {}
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{11}
+}
+
+func (m *WebhookDelivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDelivery.Unmarshal(m, b)
+}
+func (m *WebhookDelivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDelivery.Marshal(b, m, deterministic)
+}
+func (m *WebhookDelivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDelivery.Merge(m, src)
+}
+func (m *WebhookDelivery) XXX_Size() int {
+	return xxx_messageInfo_WebhookDelivery.Size(m)
+}
+func (m *WebhookDelivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDelivery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDelivery proto.InternalMessageInfo
+
+func (m *WebhookDelivery) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetSubscriptionId() string {
+	if m != nil {
+		return m.SubscriptionId
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetEventId() string {
+	if m != nil {
+		return m.EventId
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetEventType() string {
+	if m != nil {
+		return m.EventType
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetState() WebhookDelivery_State {
+	if m != nil {
+		return m.State
+	}
+	return WebhookDelivery_WEBHOOK_DELIVERY_STATE_UNSPECIFIED
+}
+
+func (m *WebhookDelivery) GetAttempts() []*WebhookDelivery_Attempt {
+	if m != nil {
+		return m.Attempts
+	}
+	return nil
+}
+
+func (m *WebhookDelivery) GetNextAttemptAt() *timestamppb.Timestamp {
+	if m != nil {
+		return m.NextAttemptAt
+	}
+	return nil
+}
+
+func (m *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *WebhookDelivery) GetUpdatedAt() *timestamppb.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type WebhookDelivery_Attempt struct {
+	At *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=at,proto3" json:"at,omitempty"`
+	// status code of response, 0 if request has failed
+	StatusCode           int32    `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WebhookDelivery_Attempt) Reset()         { *m = WebhookDelivery_Attempt{} }
+func (m *WebhookDelivery_Attempt) String() string { return proto.CompactTextString(m) }
+func (*WebhookDelivery_Attempt) ProtoMessage()    {}
+func (*WebhookDelivery_Attempt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{11, 0}
+}
+
+func (m *WebhookDelivery_Attempt) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDelivery_Attempt.Unmarshal(m, b)
+}
+func (m *WebhookDelivery_Attempt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDelivery_Attempt.Marshal(b, m, deterministic)
+}
+func (m *WebhookDelivery_Attempt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDelivery_Attempt.Merge(m, src)
+}
+func (m *WebhookDelivery_Attempt) XXX_Size() int {
+	return xxx_messageInfo_WebhookDelivery_Attempt.Size(m)
+}
+func (m *WebhookDelivery_Attempt) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDelivery_Attempt.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDelivery_Attempt proto.InternalMessageInfo
+
+func (m *WebhookDelivery_Attempt) GetAt() *timestamppb.Timestamp {
+	if m != nil {
+		return m.At
+	}
+	return nil
+}
+
+func (m *WebhookDelivery_Attempt) GetStatusCode() int32 {
+	if m != nil {
+		return m.StatusCode
+	}
+	return 0
+}
+
+func (m *WebhookDelivery_Attempt) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("api.WebhookDelivery_State", WebhookDelivery_State_name, WebhookDelivery_State_value)
+	proto.RegisterType((*CreateWebhookSubscriptionRequest)(nil), "api.CreateWebhookSubscriptionRequest")
+	proto.RegisterType((*GetWebhookSubscriptionRequest)(nil), "api.GetWebhookSubscriptionRequest")
+	proto.RegisterType((*ListWebhookSubscriptionsRequest)(nil), "api.ListWebhookSubscriptionsRequest")
+	proto.RegisterType((*ListWebhookSubscriptionsResponse)(nil), "api.ListWebhookSubscriptionsResponse")
+	proto.RegisterType((*UpdateWebhookSubscriptionRequest)(nil), "api.UpdateWebhookSubscriptionRequest")
+	proto.RegisterType((*DeleteWebhookSubscriptionRequest)(nil), "api.DeleteWebhookSubscriptionRequest")
+	proto.RegisterType((*DeleteWebhookSubscriptionResponse)(nil), "api.DeleteWebhookSubscriptionResponse")
+	proto.RegisterType((*ListWebhookDeliveriesRequest)(nil), "api.ListWebhookDeliveriesRequest")
+	proto.RegisterType((*ListWebhookDeliveriesResponse)(nil), "api.ListWebhookDeliveriesResponse")
+	proto.RegisterType((*RedeliverWebhookDeliveryRequest)(nil), "api.RedeliverWebhookDeliveryRequest")
+	proto.RegisterType((*WebhookSubscription)(nil), "api.WebhookSubscription")
+	proto.RegisterType((*WebhookDelivery)(nil), "api.WebhookDelivery")
+	proto.RegisterType((*WebhookDelivery_Attempt)(nil), "api.WebhookDelivery.Attempt")
+}
+
+func init() { proto.RegisterFile("webhook.proto", fileDescriptor_4a0479a603100288) }
+
+var fileDescriptor_4a0479a603100288 = []byte{
+	// 857 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0x4d, 0x72, 0xe3, 0x44,
+	0x18, 0x45, 0x52, 0x64, 0xc7, 0x5f, 0x2a, 0x8e, 0x69, 0x42, 0x22, 0x8b, 0x04, 0xcb, 0x22, 0x09,
+	0x2e, 0x16, 0x36, 0x18, 0x16, 0xb0, 0xa1, 0xca, 0xb1, 0x44, 0x70, 0x91, 0x4a, 0x52, 0xb2, 0x9d,
+	0x14, 0x55, 0x29, 0x54, 0xb2, 0xd5, 0x04, 0x81, 0x63, 0x09, 0xa9, 0x6d, 0xc8, 0x0d, 0x38, 0x02,
+	0x35, 0xb7, 0x98, 0xc5, 0x6c, 0x66, 0x35, 0x27, 0x98, 0xcd, 0x9c, 0x62, 0x6e, 0x31, 0xa5, 0x56,
+	0xdb, 0xa3, 0x71, 0xf4, 0x93, 0xcc, 0x62, 0x76, 0xea, 0xaf, 0xdf, 0x6b, 0xf5, 0xf7, 0x5e, 0x77,
+	0x3f, 0xd8, 0xfc, 0x07, 0x8f, 0xfe, 0x70, 0xdd, 0xbf, 0x9a, 0x9e, 0xef, 0x12, 0x17, 0x09, 0x96,
+	0xe7, 0xc8, 0xb5, 0x1b, 0xd7, 0xbd, 0x99, 0xe0, 0x16, 0x2d, 0x8d, 0x66, 0xbf, 0xb7, 0x88, 0x73,
+	0x8b, 0x03, 0x62, 0xdd, 0x7a, 0x11, 0x4a, 0xfe, 0xd8, 0xf2, 0xbc, 0x89, 0x33, 0xb6, 0x88, 0xe3,
+	0x4e, 0x59, 0x69, 0x77, 0x6e, 0x4d, 0x1c, 0xdb, 0x22, 0xb8, 0xb5, 0xf8, 0x88, 0x26, 0xd4, 0x97,
+	0x1c, 0x28, 0x5d, 0x1f, 0x5b, 0x04, 0x5f, 0x45, 0x7f, 0xea, 0xcf, 0x46, 0xc1, 0xd8, 0x77, 0xbc,
+	0x90, 0x6c, 0xe0, 0xbf, 0x67, 0x38, 0x20, 0x68, 0x0f, 0x84, 0x99, 0x3f, 0x91, 0x38, 0x85, 0x6b,
+	0x94, 0x8e, 0xe1, 0xf9, 0xeb, 0x17, 0x82, 0xe8, 0x0b, 0xff, 0x71, 0x9c, 0x11, 0x96, 0x51, 0x1d,
+	0x0a, 0x01, 0x1e, 0xfb, 0x98, 0x48, 0x3c, 0x05, 0x94, 0x42, 0xc0, 0x9a, 0xcf, 0x57, 0x2a, 0x06,
+	0x9b, 0x40, 0x35, 0xd8, 0xc0, 0x73, 0x3c, 0x25, 0x26, 0xb9, 0xf3, 0x70, 0x20, 0x09, 0x8a, 0xd0,
+	0x28, 0x19, 0x40, 0x4b, 0x83, 0xb0, 0x82, 0x76, 0xa1, 0x38, 0x0b, 0xb0, 0x6f, 0x3a, 0xb6, 0xb4,
+	0x16, 0x2e, 0x62, 0x14, 0xc2, 0x61, 0xcf, 0x46, 0x2d, 0x28, 0x04, 0xc4, 0x22, 0xb3, 0x40, 0x12,
+	0x15, 0xae, 0x51, 0x6e, 0xef, 0x36, 0x2d, 0xcf, 0x69, 0x76, 0x62, 0x0d, 0xf6, 0xe9, 0xb4, 0xc1,
+	0x60, 0x6a, 0x0b, 0xf6, 0x4f, 0x30, 0xc9, 0x68, 0xa6, 0x0c, 0xbc, 0x63, 0x47, 0xbd, 0x18, 0xbc,
+	0x63, 0xab, 0x75, 0xa8, 0x9d, 0x3a, 0x41, 0x12, 0x23, 0x60, 0x14, 0x75, 0x04, 0x4a, 0x3a, 0x24,
+	0xf0, 0xdc, 0x69, 0x80, 0xd1, 0x8f, 0xb0, 0x19, 0xc4, 0x27, 0x24, 0x4e, 0x11, 0x1a, 0x1b, 0x6d,
+	0x89, 0xee, 0x37, 0x69, 0x3b, 0xef, 0xc2, 0xd5, 0x57, 0x1c, 0x28, 0x43, 0xcf, 0xce, 0x36, 0x62,
+	0x65, 0xef, 0x0b, 0x63, 0xf8, 0x64, 0x63, 0x76, 0x96, 0xc6, 0x08, 0x91, 0xa6, 0xc9, 0x6e, 0xac,
+	0x65, 0xb9, 0x21, 0xa6, 0xb8, 0x51, 0x78, 0x98, 0x1b, 0x6d, 0x50, 0x34, 0x3c, 0xc1, 0x8f, 0x69,
+	0x4a, 0xfd, 0x02, 0xea, 0x19, 0x9c, 0x48, 0x6e, 0xf5, 0x04, 0xf6, 0x62, 0x96, 0x68, 0x78, 0xe2,
+	0xcc, 0xb1, 0xef, 0xe0, 0x85, 0x65, 0xe8, 0x4b, 0xd8, 0x8a, 0xeb, 0x6b, 0x2e, 0xff, 0x50, 0x8e,
+	0x97, 0x7b, 0xb6, 0x3a, 0x84, 0xfd, 0x94, 0x85, 0x98, 0xb1, 0xdf, 0x01, 0xd8, 0xcb, 0x2a, 0x73,
+	0x75, 0x3b, 0xee, 0x2a, 0xe3, 0xdc, 0x19, 0x31, 0x9c, 0xfa, 0x0d, 0xd4, 0x0c, 0xcc, 0xc6, 0xab,
+	0xb8, 0x94, 0xbe, 0xff, 0xe7, 0xe1, 0x93, 0x84, 0x96, 0xef, 0x99, 0x5e, 0x89, 0x99, 0x1e, 0x19,
+	0xfd, 0xe1, 0xae, 0x17, 0xfa, 0x01, 0x60, 0x4c, 0x9f, 0x0b, 0xdb, 0xb4, 0x08, 0x3d, 0x05, 0x1b,
+	0x6d, 0xb9, 0x19, 0xbd, 0x48, 0xcd, 0xc5, 0x8b, 0xd4, 0x1c, 0x2c, 0x5e, 0x24, 0xa3, 0xc4, 0xd0,
+	0x1d, 0x12, 0x52, 0x67, 0x9e, 0xbd, 0xa0, 0x16, 0xf3, 0xa9, 0x0c, 0xdd, 0x21, 0xea, 0x33, 0x11,
+	0xb6, 0x56, 0x54, 0xbc, 0x27, 0x4b, 0x82, 0xe3, 0x7c, 0x92, 0xe3, 0xa8, 0x0a, 0xeb, 0x91, 0x5a,
+	0x8e, 0xcd, 0x2e, 0x46, 0x91, 0x8e, 0x7b, 0x36, 0xda, 0x07, 0x78, 0x2b, 0x24, 0x93, 0xaa, 0xb4,
+	0xd4, 0x11, 0x7d, 0x0d, 0x62, 0x28, 0x03, 0x66, 0x62, 0xc9, 0x49, 0xa7, 0x80, 0x0a, 0x86, 0x8d,
+	0x08, 0x88, 0xbe, 0x87, 0x75, 0x8b, 0x10, 0x7c, 0xeb, 0x91, 0xf0, 0xca, 0x84, 0x47, 0x67, 0x2f,
+	0x91, 0xd4, 0x89, 0x40, 0xc6, 0x12, 0x8d, 0x8e, 0x61, 0x6b, 0x8a, 0xff, 0x25, 0x26, 0x2b, 0x3c,
+	0x4c, 0xb2, 0xcd, 0x90, 0xc2, 0xd6, 0x8a, 0x14, 0x8f, 0x99, 0xb5, 0xfe, 0xfe, 0x66, 0x95, 0x1e,
+	0x61, 0x96, 0x3c, 0x81, 0x22, 0xdb, 0x02, 0xfa, 0x0a, 0x78, 0x8b, 0x48, 0x5c, 0x2e, 0x9b, 0xb7,
+	0xe8, 0xab, 0x14, 0x9d, 0x31, 0x73, 0xec, 0xda, 0x98, 0x7a, 0x27, 0x1a, 0x10, 0x95, 0xba, 0xae,
+	0x8d, 0xd1, 0x36, 0x88, 0xd8, 0xf7, 0x5d, 0x9f, 0x99, 0x16, 0x0d, 0xd4, 0x27, 0x1c, 0x88, 0x54,
+	0x72, 0x74, 0x04, 0xea, 0x95, 0x7e, 0xfc, 0xf3, 0xf9, 0xf9, 0x2f, 0xa6, 0xa6, 0x9f, 0xf6, 0x2e,
+	0x75, 0xe3, 0x57, 0xb3, 0x3f, 0xe8, 0x0c, 0x74, 0x73, 0x78, 0xd6, 0xbf, 0xd0, 0xbb, 0xbd, 0x9f,
+	0x7a, 0xba, 0x56, 0xf9, 0x08, 0xa9, 0xf0, 0x79, 0x0a, 0xee, 0x42, 0x3f, 0xd3, 0x7a, 0x67, 0x27,
+	0x15, 0x0e, 0x1d, 0x80, 0x92, 0x82, 0xe9, 0x0f, 0xbb, 0x5d, 0x5d, 0xd7, 0x74, 0xad, 0xc2, 0xa3,
+	0x1a, 0x7c, 0x96, 0x82, 0xd2, 0xf4, 0x8e, 0x56, 0x11, 0xda, 0x4f, 0x45, 0x28, 0x2f, 0xae, 0x34,
+	0xf6, 0xe7, 0xce, 0x18, 0xa3, 0x6b, 0xa8, 0xa6, 0xe6, 0x2d, 0x3a, 0xa4, 0x87, 0x23, 0x2f, 0x8f,
+	0xe5, 0xd4, 0x50, 0x41, 0x97, 0xb0, 0x93, 0x9c, 0x7e, 0x48, 0xa5, 0x9c, 0xcc, 0x68, 0xcc, 0x58,
+	0xf7, 0x06, 0xa4, 0xb4, 0x04, 0x44, 0x07, 0x94, 0x95, 0x93, 0xa1, 0xf2, 0x61, 0x0e, 0x8a, 0xbd,
+	0xb6, 0xd7, 0x50, 0x4d, 0x4d, 0x41, 0x26, 0x4f, 0x5e, 0x4a, 0x66, 0xb4, 0xf1, 0x27, 0x54, 0x53,
+	0xa3, 0x85, 0xad, 0x9e, 0x17, 0x57, 0xf2, 0x51, 0x1e, 0x8c, 0x75, 0xf2, 0x1b, 0x7c, 0x9a, 0x18,
+	0x2c, 0xa8, 0xbe, 0xaa, 0xc4, 0xbd, 0xf4, 0x92, 0xd5, 0x2c, 0x08, 0x5b, 0xff, 0x12, 0xa4, 0xb4,
+	0x84, 0x61, 0x96, 0xe4, 0x04, 0x90, 0x9c, 0x98, 0x62, 0xa3, 0x02, 0xbd, 0x9f, 0xdf, 0xbe, 0x19,
+	0x00, 0x34, 0x49, 0x65, 0x91, 0x7b, 0x0a, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// WebhookServiceClient is the client API for WebhookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WebhookServiceClient interface {
+	CreateWebhookSubscription(ctx context.Context, in *CreateWebhookSubscriptionRequest, opts ...grpc.CallOption) (*WebhookSubscription, error)
+	GetWebhookSubscription(ctx context.Context, in *GetWebhookSubscriptionRequest, opts ...grpc.CallOption) (*WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context, in *ListWebhookSubscriptionsRequest, opts ...grpc.CallOption) (*ListWebhookSubscriptionsResponse, error)
+	UpdateWebhookSubscription(ctx context.Context, in *UpdateWebhookSubscriptionRequest, opts ...grpc.CallOption) (*WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, in *DeleteWebhookSubscriptionRequest, opts ...grpc.CallOption) (*DeleteWebhookSubscriptionResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	// RedeliverWebhookDelivery schedules delivery again, it's used for dead deliveries
+	RedeliverWebhookDelivery(ctx context.Context, in *RedeliverWebhookDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error)
+}
+
+type webhookServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhookServiceClient(cc grpc.ClientConnInterface) WebhookServiceClient {
+	return &webhookServiceClient{cc}
+}
+
+func (c *webhookServiceClient) CreateWebhookSubscription(ctx context.Context, in *CreateWebhookSubscriptionRequest, opts ...grpc.CallOption) (*WebhookSubscription, error) {
+	out := new(WebhookSubscription)
+	err := c.cc.Invoke(ctx, "/api.WebhookService/CreateWebhookSubscription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) GetWebhookSubscription(ctx context.Context, in *GetWebhookSubscriptionRequest, opts ...grpc.CallOption) (*WebhookSubscription, error) {
+	out := new(WebhookSubscription)
+	err := c.cc.Invoke(ctx, "/api.WebhookService/GetWebhookSubscription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListWebhookSubscriptions(ctx context.Context, in *ListWebhookSubscriptionsRequest, opts ...grpc.CallOption) (*ListWebhookSubscriptionsResponse, error) {
+	out := new(ListWebhookSubscriptionsResponse)
+	err := c.cc.Invoke(ctx, "/api.WebhookService/ListWebhookSubscriptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) UpdateWebhookSubscription(ctx context.Context, in *UpdateWebhookSubscriptionRequest, opts ...grpc.CallOption) (*WebhookSubscription, error) {
+	out := new(WebhookSubscription)
+	err := c.cc.Invoke(ctx, "/api.WebhookService/UpdateWebhookSubscription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) DeleteWebhookSubscription(ctx context.Context, in *DeleteWebhookSubscriptionRequest, opts ...grpc.CallOption) (*DeleteWebhookSubscriptionResponse, error) {
+	out := new(DeleteWebhookSubscriptionResponse)
+	err := c.cc.Invoke(ctx, "/api.WebhookService/DeleteWebhookSubscription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, "/api.WebhookService/ListWebhookDeliveries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) RedeliverWebhookDelivery(ctx context.Context, in *RedeliverWebhookDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, "/api.WebhookService/RedeliverWebhookDelivery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhookServiceServer is the server API for WebhookService service.
+type WebhookServiceServer interface {
+	CreateWebhookSubscription(context.Context, *CreateWebhookSubscriptionRequest) (*WebhookSubscription, error)
+	GetWebhookSubscription(context.Context, *GetWebhookSubscriptionRequest) (*WebhookSubscription, error)
+	ListWebhookSubscriptions(context.Context, *ListWebhookSubscriptionsRequest) (*ListWebhookSubscriptionsResponse, error)
+	UpdateWebhookSubscription(context.Context, *UpdateWebhookSubscriptionRequest) (*WebhookSubscription, error)
+	DeleteWebhookSubscription(context.Context, *DeleteWebhookSubscriptionRequest) (*DeleteWebhookSubscriptionResponse, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	// RedeliverWebhookDelivery schedules delivery again, it's used for dead deliveries
+	RedeliverWebhookDelivery(context.Context, *RedeliverWebhookDeliveryRequest) (*WebhookDelivery, error)
+}
+
+// UnimplementedWebhookServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedWebhookServiceServer struct {
+}
+
+func (*UnimplementedWebhookServiceServer) CreateWebhookSubscription(ctx context.Context, req *CreateWebhookSubscriptionRequest) (*WebhookSubscription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhookSubscription not implemented")
+}
+func (*UnimplementedWebhookServiceServer) GetWebhookSubscription(ctx context.Context, req *GetWebhookSubscriptionRequest) (*WebhookSubscription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhookSubscription not implemented")
+}
+func (*UnimplementedWebhookServiceServer) ListWebhookSubscriptions(ctx context.Context, req *ListWebhookSubscriptionsRequest) (*ListWebhookSubscriptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookSubscriptions not implemented")
+}
+func (*UnimplementedWebhookServiceServer) UpdateWebhookSubscription(ctx context.Context, req *UpdateWebhookSubscriptionRequest) (*WebhookSubscription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWebhookSubscription not implemented")
+}
+func (*UnimplementedWebhookServiceServer) DeleteWebhookSubscription(ctx context.Context, req *DeleteWebhookSubscriptionRequest) (*DeleteWebhookSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhookSubscription not implemented")
+}
+func (*UnimplementedWebhookServiceServer) ListWebhookDeliveries(ctx context.Context, req *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (*UnimplementedWebhookServiceServer) RedeliverWebhookDelivery(ctx context.Context, req *RedeliverWebhookDeliveryRequest) (*WebhookDelivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeliverWebhookDelivery not implemented")
+}
+
+func RegisterWebhookServiceServer(s *grpc.Server, srv WebhookServiceServer) {
+	s.RegisterService(&_WebhookService_serviceDesc, srv)
+}
+
+func _WebhookService_CreateWebhookSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).CreateWebhookSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.WebhookService/CreateWebhookSubscription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).CreateWebhookSubscription(ctx, req.(*CreateWebhookSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_GetWebhookSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhookSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).GetWebhookSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.WebhookService/GetWebhookSubscription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).GetWebhookSubscription(ctx, req.(*GetWebhookSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ListWebhookSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookSubscriptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).ListWebhookSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.WebhookService/ListWebhookSubscriptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).ListWebhookSubscriptions(ctx, req.(*ListWebhookSubscriptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_UpdateWebhookSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWebhookSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).UpdateWebhookSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.WebhookService/UpdateWebhookSubscription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).UpdateWebhookSubscription(ctx, req.(*UpdateWebhookSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_DeleteWebhookSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).DeleteWebhookSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.WebhookService/DeleteWebhookSubscription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).DeleteWebhookSubscription(ctx, req.(*DeleteWebhookSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.WebhookService/ListWebhookDeliveries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_RedeliverWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeliverWebhookDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).RedeliverWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.WebhookService/RedeliverWebhookDelivery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).RedeliverWebhookDelivery(ctx, req.(*RedeliverWebhookDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _WebhookService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.WebhookService",
+	HandlerType: (*WebhookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWebhookSubscription",
+			Handler:    _WebhookService_CreateWebhookSubscription_Handler,
+		},
+		{
+			MethodName: "GetWebhookSubscription",
+			Handler:    _WebhookService_GetWebhookSubscription_Handler,
+		},
+		{
+			MethodName: "ListWebhookSubscriptions",
+			Handler:    _WebhookService_ListWebhookSubscriptions_Handler,
+		},
+		{
+			MethodName: "UpdateWebhookSubscription",
+			Handler:    _WebhookService_UpdateWebhookSubscription_Handler,
+		},
+		{
+			MethodName: "DeleteWebhookSubscription",
+			Handler:    _WebhookService_DeleteWebhookSubscription_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _WebhookService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "RedeliverWebhookDelivery",
+			Handler:    _WebhookService_RedeliverWebhookDelivery_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "webhook.proto",
+}